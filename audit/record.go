@@ -0,0 +1,79 @@
+// Package audit describes the audit trail recorded when files are redacted.
+package audit
+
+import (
+	"github.com/waives/surf/ch360/request"
+	"io"
+	"time"
+)
+
+// The methods used to choose the areas to redact.
+const (
+	MethodExtractor = "extractor"
+	MethodAreas     = "areas"
+	MethodPattern   = "pattern"
+)
+
+// Configuration is the configuration a set of files was redacted with.
+type Configuration struct {
+	Method        string   `json:"method"`
+	Extractor     string   `json:"extractor,omitempty"`
+	Fields        []string `json:"fields,omitempty"`
+	ExcludeFields []string `json:"exclude_fields,omitempty"`
+	AreasFile     string   `json:"areas_file,omitempty"`
+	Patterns      []string `json:"patterns,omitempty"`
+	Preview       bool     `json:"preview"`
+	Bookmarks     bool     `json:"bookmarks"`
+}
+
+// RedactedFile is the result of redacting a file when an audit trail is being
+// recorded.
+type RedactedFile struct {
+	// Contents is the redacted PDF.
+	Contents     io.ReadCloser
+	SourceSHA256 string
+	Request      *request.RedactedPdfRequest
+}
+
+// Record is the audit trail for a single redacted file. It describes where each
+// mark was made and why, but never includes the text which was redacted.
+type Record struct {
+	SourceFile    string        `json:"source_file"`
+	SourceSHA256  string        `json:"source_sha256"`
+	OutputFile    string        `json:"output_file"`
+	OutputSHA256  string        `json:"output_sha256"`
+	RedactedAt    time.Time     `json:"redacted_at"`
+	Configuration Configuration `json:"configuration"`
+	MarksApplied  bool          `json:"marks_applied"`
+	Marks         []Mark        `json:"marks"`
+}
+
+// Mark is a single area of a redacted file.
+type Mark struct {
+	PageNumber int     `json:"page_number"`
+	Top        float32 `json:"top"`
+	Left       float32 `json:"left"`
+	Bottom     float32 `json:"bottom"`
+	Right      float32 `json:"right"`
+	// Field is the extractor field the mark was made for, if known.
+	Field string `json:"field,omitempty"`
+	// Pattern is the regular expression the mark was made for, if any.
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// MarksFor returns the Marks described by a redaction request.
+func MarksFor(redactRequest *request.RedactedPdfRequest) []Mark {
+	marks := make([]Mark, len(redactRequest.Marks))
+	for i, mark := range redactRequest.Marks {
+		marks[i] = Mark{
+			PageNumber: int(mark.Area.PageNumber),
+			Top:        mark.Area.Top,
+			Left:       mark.Area.Left,
+			Bottom:     mark.Area.Bottom,
+			Right:      mark.Area.Right,
+			Field:      mark.Field,
+			Pattern:    mark.Pattern,
+		}
+	}
+	return marks
+}
@@ -0,0 +1,34 @@
+package audit_test
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/waives/surf/audit"
+	"github.com/waives/surf/ch360/request"
+	"testing"
+)
+
+func TestMarksFor_Returns_Each_Mark_With_Its_Origin(t *testing.T) {
+	redactRequest := &request.RedactedPdfRequest{
+		Marks: []request.RedactionMark{
+			{Area: request.RedactionArea{Top: 1, Left: 2, Bottom: 3, Right: 4, PageNumber: 1}, Field: "Name"},
+			{Area: request.RedactionArea{Top: 5, Left: 6, Bottom: 7, Right: 8, PageNumber: 3}, Pattern: `\d+`},
+		},
+	}
+
+	marks := audit.MarksFor(redactRequest)
+
+	assert.Equal(t, []audit.Mark{
+		{PageNumber: 1, Top: 1, Left: 2, Bottom: 3, Right: 4, Field: "Name"},
+		{PageNumber: 3, Top: 5, Left: 6, Bottom: 7, Right: 8, Pattern: `\d+`},
+	}, marks)
+}
+
+func TestMarkOrigins_Are_Not_Sent_To_Waives(t *testing.T) {
+	body, err := json.Marshal(request.RedactionMark{Field: "Name", Pattern: `\d+`})
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"area": {"top": 0, "left": 0, "bottom": 0, "right": 0, "page_number": 0}}`,
+		string(body))
+}
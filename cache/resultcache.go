@@ -0,0 +1,195 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	fileRWPermissions os.FileMode = 0600
+	dirRWPermissions  os.FileMode = 0700
+)
+
+// Key identifies a cached result: the contents of the file which was processed (by
+// its SHA256 hash), the operation performed and its parameters.
+type Key struct {
+	Sha256    string
+	Operation string
+	// Name is the name of the classifier or extractor used, if any.
+	Name string
+	// Mode is the read mode used, if any.
+	Mode string
+}
+
+// The operations results are cached for.
+const (
+	OperationClassify = "classify"
+	OperationExtract  = "extract"
+	OperationRead     = "read"
+)
+
+// ResultCache is a content-addressed store of serialised results, on the local
+// filesystem. Each result is stored as a single file, grouped in a directory per
+// operation.
+type ResultCache struct {
+	directory string
+}
+
+func NewResultCache(directory string) *ResultCache {
+	return &ResultCache{
+		directory: directory,
+	}
+}
+
+// Get retrieves the result stored against the provided key. The returned bool
+// indicates whether a result was found.
+func (c *ResultCache) Get(key Key) ([]byte, bool, error) {
+	path := c.pathFor(key)
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	// record the time of last use, so it's the least recently used entries which are pruned
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+
+	return contents, true, nil
+}
+
+// Put stores a result against the provided key, replacing any existing result.
+func (c *ResultCache) Put(key Key, contents []byte) error {
+	path := c.pathFor(key)
+
+	if err := os.MkdirAll(filepath.Dir(path), dirRWPermissions); err != nil {
+		return err
+	}
+
+	// write to a temporary file first so that an interrupted write can't leave a
+	// truncated result in the cache
+	tmpFile, err := ioutil.TempFile(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmpFile.Write(contents)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpFile.Name(), fileRWPermissions)
+	}
+	if err == nil {
+		err = os.Rename(tmpFile.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmpFile.Name())
+	}
+
+	return err
+}
+
+// OperationStats describes the results cached for a single operation.
+type OperationStats struct {
+	Operation string
+	Entries   int
+	Size      int64
+}
+
+// Stats describes the results held in the cache.
+type Stats []OperationStats
+
+// Stats returns the number and total size of the cached results for each operation.
+func (c *ResultCache) Stats() (Stats, error) {
+	statsByOperation := map[string]*OperationStats{}
+	var stats Stats
+
+	err := c.walkEntries(func(operation, path string, info os.FileInfo) error {
+		operationStats, found := statsByOperation[operation]
+		if !found {
+			operationStats = &OperationStats{Operation: operation}
+			statsByOperation[operation] = operationStats
+		}
+
+		operationStats.Entries++
+		operationStats.Size += info.Size()
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, operation := range []string{OperationClassify, OperationExtract, OperationRead} {
+		if operationStats, found := statsByOperation[operation]; found {
+			stats = append(stats, *operationStats)
+		}
+	}
+
+	return stats, nil
+}
+
+// Prune removes all cached results which have not been used within the specified
+// duration, or all results if it is zero. It returns the number of results removed.
+func (c *ResultCache) Prune(unusedFor time.Duration) (int, error) {
+	removed := 0
+	cutoff := time.Now().Add(-unusedFor)
+
+	err := c.walkEntries(func(operation, path string, info os.FileInfo) error {
+		if unusedFor > 0 && info.ModTime().After(cutoff) {
+			return nil
+		}
+
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		removed++
+		return nil
+	})
+
+	return removed, err
+}
+
+func (c *ResultCache) walkEntries(fn func(operation, path string, info os.FileInfo) error) error {
+	err := filepath.Walk(c.directory, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".") {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(c.directory, path)
+		if err != nil {
+			return err
+		}
+
+		operation := strings.Split(filepath.ToSlash(relativePath), "/")[0]
+		return fn(operation, path, info)
+	})
+
+	if os.IsNotExist(err) {
+		// nothing has been cached yet
+		return nil
+	}
+
+	return err
+}
+
+func (c *ResultCache) pathFor(key Key) string {
+	hash := sha256.Sum256([]byte(strings.Join([]string{
+		strings.ToLower(key.Sha256), key.Operation, key.Name, key.Mode}, "\x00")))
+	filename := hex.EncodeToString(hash[:])
+
+	return filepath.Join(c.directory, key.Operation, filename[:2], filename)
+}
@@ -0,0 +1,113 @@
+package cache_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/cache"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+type ResultCacheSuite struct {
+	suite.Suite
+	directory string
+	sut       *cache.ResultCache
+	key       cache.Key
+}
+
+func (suite *ResultCacheSuite) SetupTest() {
+	var err error
+	suite.directory, err = ioutil.TempDir("", "surf-cache-test")
+	require.NoError(suite.T(), err)
+
+	suite.sut = cache.NewResultCache(suite.directory)
+	suite.key = cache.Key{
+		Sha256:    "1307990e6ba5ca145eb35e99182a9bec46531bc54ddf656a602c780fa0240dee",
+		Operation: cache.OperationClassify,
+		Name:      "classifier",
+	}
+}
+
+func (suite *ResultCacheSuite) TearDownTest() {
+	_ = os.RemoveAll(suite.directory)
+}
+
+func TestResultCacheSuiteRunner(t *testing.T) {
+	suite.Run(t, new(ResultCacheSuite))
+}
+
+func (suite *ResultCacheSuite) TestGet_Returns_Not_Found_For_Empty_Cache() {
+	_, found, err := suite.sut.Get(suite.key)
+
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), found)
+}
+
+func (suite *ResultCacheSuite) TestGet_Returns_Stored_Result() {
+	require.NoError(suite.T(), suite.sut.Put(suite.key, []byte("result")))
+
+	contents, found, err := suite.sut.Get(suite.key)
+
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), found)
+	assert.Equal(suite.T(), "result", string(contents))
+}
+
+func (suite *ResultCacheSuite) TestGet_Does_Not_Return_Result_For_Different_Key() {
+	require.NoError(suite.T(), suite.sut.Put(suite.key, []byte("result")))
+	otherKey := suite.key
+	otherKey.Name = "other-classifier"
+
+	_, found, err := suite.sut.Get(otherKey)
+
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), found)
+}
+
+func (suite *ResultCacheSuite) TestPut_Replaces_Existing_Result() {
+	require.NoError(suite.T(), suite.sut.Put(suite.key, []byte("result")))
+	require.NoError(suite.T(), suite.sut.Put(suite.key, []byte("new result")))
+
+	contents, _, _ := suite.sut.Get(suite.key)
+
+	assert.Equal(suite.T(), "new result", string(contents))
+}
+
+func (suite *ResultCacheSuite) TestStats_Groups_Results_By_Operation() {
+	readKey := cache.Key{Sha256: suite.key.Sha256, Operation: cache.OperationRead, Mode: "txt"}
+	require.NoError(suite.T(), suite.sut.Put(suite.key, []byte("12345")))
+	require.NoError(suite.T(), suite.sut.Put(readKey, []byte("123")))
+
+	stats, err := suite.sut.Stats()
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), cache.Stats{
+		{Operation: cache.OperationClassify, Entries: 1, Size: 5},
+		{Operation: cache.OperationRead, Entries: 1, Size: 3},
+	}, stats)
+}
+
+func (suite *ResultCacheSuite) TestPrune_Removes_All_Results_With_Zero_Duration() {
+	require.NoError(suite.T(), suite.sut.Put(suite.key, []byte("result")))
+
+	removed, err := suite.sut.Prune(0)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, removed)
+	_, found, _ := suite.sut.Get(suite.key)
+	assert.False(suite.T(), found)
+}
+
+func (suite *ResultCacheSuite) TestPrune_Keeps_Recently_Used_Results() {
+	require.NoError(suite.T(), suite.sut.Put(suite.key, []byte("result")))
+
+	removed, err := suite.sut.Prune(time.Hour)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, removed)
+	_, found, _ := suite.sut.Get(suite.key)
+	assert.True(suite.T(), found)
+}
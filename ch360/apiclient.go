@@ -3,6 +3,7 @@ package ch360
 import (
 	"github.com/waives/surf/auth"
 	"github.com/waives/surf/net"
+	"github.com/waives/surf/telemetry"
 	"io"
 )
 
@@ -27,17 +28,26 @@ func NewApiClient(httpClient net.HttpDoer,
 	apiUrl string,
 	clientId string,
 	clientSecret string,
-	httpLogSink io.Writer) *ApiClient {
+	httpLogSink io.Writer,
+	httpLogFormat HttpLogFormat,
+	rateLimits net.RateLimits,
+	retryPolicies net.RetryPolicies,
+	recorder *telemetry.Recorder) *ApiClient {
 
 	var myHttpClient = httpClient
 
+	if recorder != nil {
+		myHttpClient = net.NewInstrumentedHttpClient(myHttpClient, recorder, OperationName)
+	}
+
 	if httpLogSink != nil {
-		myHttpClient = NewLoggingDoer(myHttpClient, httpLogSink)
+		myHttpClient = NewLoggingDoer(myHttpClient, httpLogSink).WithFormat(httpLogFormat)
 	}
 
 	myHttpClient = net.NewUserAgentHttpClient(myHttpClient, "surf/"+Version)
 	myHttpClient = net.NewContextAwareHttpClient(myHttpClient)
-	myHttpClient = net.NewRetryingHttpClient(myHttpClient, 3, 2)
+	myHttpClient = net.NewRateLimitingHttpClient(myHttpClient, rateLimits).WithRecorder(recorder)
+	myHttpClient = net.NewRetryingHttpClient(myHttpClient, 3, 2).WithPolicies(retryPolicies)
 
 	tokenRetriever := NewTokenRetriever(myHttpClient, apiUrl)
 
@@ -27,10 +27,8 @@ type Classifier struct {
 type ClassifierList []Classifier
 
 func (client *ClassifiersClient) Create(ctx context.Context, name string) error {
-	_, err := newRequest(ctx, "POST", client.baseUrl+"/classifiers/"+name, nil).
-		issue(client.requestSender)
-
-	return err
+	return newRequest(ctx, "POST", client.baseUrl+"/classifiers/"+name, nil).
+		send(client.requestSender)
 }
 
 func (client *ClassifiersClient) Upload(ctx context.Context, name string, contents io.Reader) error {
@@ -38,18 +36,14 @@ func (client *ClassifiersClient) Upload(ctx context.Context, name string, conten
 		"Content-Type": "application/vnd.waives.classifier+zip",
 	}
 
-	_, err := newRequest(ctx, "POST", client.baseUrl+"/classifiers/"+name, contents).
+	return newRequest(ctx, "POST", client.baseUrl+"/classifiers/"+name, contents).
 		withHeaders(headers).
-		issue(client.requestSender)
-
-	return err
+		send(client.requestSender)
 }
 
 func (client *ClassifiersClient) Delete(ctx context.Context, name string) error {
-	_, err := newRequest(ctx, "DELETE", client.baseUrl+"/classifiers/"+name, nil).
-		issue(client.requestSender)
-
-	return err
+	return newRequest(ctx, "DELETE", client.baseUrl+"/classifiers/"+name, nil).
+		send(client.requestSender)
 }
 
 type TrainClassifierRequest struct {
@@ -62,11 +56,9 @@ func (client *ClassifiersClient) Train(ctx context.Context, name string, samples
 		"Content-Type": "application/zip",
 	}
 
-	_, err := newRequest(ctx, "POST", client.baseUrl+"/classifiers/"+name+"/samples", samplesArchive).
+	return newRequest(ctx, "POST", client.baseUrl+"/classifiers/"+name+"/samples", samplesArchive).
 		withHeaders(headers).
-		issue(client.requestSender)
-
-	return err
+		send(client.requestSender)
 }
 
 func (client *ClassifiersClient) GetAll(ctx context.Context) (ClassifierList, error) {
@@ -76,6 +68,7 @@ func (client *ClassifiersClient) GetAll(ctx context.Context) (ClassifierList, er
 	if err != nil {
 		return nil, err
 	}
+	defer response.Body.Close()
 
 	buf := bytes.Buffer{}
 	_, err = buf.ReadFrom(response.Body)
@@ -28,7 +28,7 @@ const apiUrl = "baseUrl"
 
 func (suite *ClassifiersClientSuite) SetupTest() {
 	suite.httpClient = new(mocks.HttpDoer)
-	suite.httpClient.On("Do", mock.Anything).Return(nil, nil)
+	suite.httpClient.On("Do", mock.Anything).Return(AnHttpResponse([]byte("")), nil)
 
 	suite.sut = ch360.NewClassifiersClient(apiUrl, suite.httpClient)
 	suite.classifierName = "classifier-name"
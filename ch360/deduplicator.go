@@ -0,0 +1,137 @@
+package ch360
+
+import (
+	"context"
+	"github.com/waives/surf/ioutils"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Deduplicator avoids uploading and processing the same file contents more than once.
+// Files are identified by their SHA256 hash:
+//   - if a document with the same hash already exists in waives, it is used in place of
+//     a new upload (and is not deleted afterwards);
+//   - identical files submitted concurrently for the same operation are processed once
+//     only, and the result is shared between them. Results are not kept once they have
+//     been shared, so identical files submitted later are processed again.
+//
+// A single Deduplicator is intended to be shared across all the files in a batch.
+type Deduplicator struct {
+	documentGetter DocumentGetter
+
+	existingDocsMutex sync.Mutex
+	existingDocs      map[string]Document
+
+	mutex   sync.Mutex
+	results map[string]*sharedResult
+}
+
+type sharedResult struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+func NewDeduplicator(documentGetter DocumentGetter) *Deduplicator {
+	return &Deduplicator{
+		documentGetter: documentGetter,
+		results:        map[string]*sharedResult{},
+	}
+}
+
+// process runs fn with a document containing fileContents, creating (and then deleting)
+// the document only if one with the same contents does not already exist. operation
+// identifies the work performed by fn; calls with identical contents and the same
+// operation made while the first is in progress receive the result of the first call.
+func (d *Deduplicator) process(ctx context.Context,
+	fileContents io.Reader,
+	operation string,
+	creator DocumentCreator,
+	deleter DocumentDeleter,
+	fn func(Document) (interface{}, error)) (interface{}, error) {
+
+	hash, fileContents, err := ioutils.Sha256(fileContents)
+	if err != nil {
+		return nil, err
+	}
+
+	key := hash + "/" + operation
+
+	d.mutex.Lock()
+	if result, found := d.results[key]; found {
+		d.mutex.Unlock()
+
+		select {
+		case <-result.done:
+			return result.value, result.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	result := &sharedResult{done: make(chan struct{})}
+	d.results[key] = result
+	d.mutex.Unlock()
+
+	result.value, result.err = d.processOnce(ctx, hash, fileContents, creator, deleter, fn)
+
+	// the callers already waiting hold the result; it isn't kept for later calls, so that
+	// (possibly large) results are not held in memory for the rest of the batch
+	d.mutex.Lock()
+	delete(d.results, key)
+	d.mutex.Unlock()
+	close(result.done)
+
+	return result.value, result.err
+}
+
+func (d *Deduplicator) processOnce(ctx context.Context,
+	hash string,
+	fileContents io.Reader,
+	creator DocumentCreator,
+	deleter DocumentDeleter,
+	fn func(Document) (interface{}, error)) (interface{}, error) {
+
+	existingDoc, found, err := d.existingDocument(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if found {
+		return fn(existingDoc)
+	}
+
+	var value interface{}
+	err = CreateDocumentFor(fileContents, creator, deleter,
+		func(document Document) error {
+			value, err = fn(document)
+			return err
+		})
+
+	return value, err
+}
+
+// existingDocument looks for a document with the provided hash amongst those which
+// existed in waives when they were first successfully retrieved. A failure to retrieve
+// them (e.g. as ctx was cancelled) is returned to the caller only, and the next caller
+// tries again.
+func (d *Deduplicator) existingDocument(ctx context.Context, hash string) (Document, bool, error) {
+	d.existingDocsMutex.Lock()
+	defer d.existingDocsMutex.Unlock()
+
+	if d.existingDocs == nil {
+		documents, err := d.documentGetter.GetAll(ctx)
+		if err != nil {
+			return Document{}, false, err
+		}
+
+		d.existingDocs = map[string]Document{}
+		for _, document := range documents {
+			d.existingDocs[strings.ToLower(document.Sha256)] = document
+		}
+	}
+
+	document, found := d.existingDocs[strings.ToLower(hash)]
+	return document, found, nil
+}
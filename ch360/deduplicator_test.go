@@ -0,0 +1,215 @@
+package ch360_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/ch360/mocks"
+	"github.com/waives/surf/ch360/results"
+	"github.com/waives/surf/test/generators"
+	"io"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+)
+
+type DeduplicatorSuite struct {
+	suite.Suite
+	documentCreator      *mocks.DocumentCreator
+	documentDeleter      *mocks.DocumentDeleter
+	documentClassifier   *mocks.DocumentClassifier
+	documentReader       *mocks.DocumentReader
+	documentGetter       *mocks.DocumentGetter
+	document             ch360.Document
+	classificationResult *results.ClassificationResult
+	fileContent          []byte
+	ctx                  context.Context
+}
+
+func (suite *DeduplicatorSuite) SetupTest() {
+	suite.document = ch360.Document{
+		Id: generators.String("documentId"),
+	}
+	suite.classificationResult = &results.ClassificationResult{
+		DocumentType: generators.String("documentType"),
+	}
+	suite.fileContent = []byte("some data")
+
+	suite.documentCreator = new(mocks.DocumentCreator)
+	suite.documentClassifier = new(mocks.DocumentClassifier)
+	suite.documentReader = new(mocks.DocumentReader)
+	suite.documentDeleter = new(mocks.DocumentDeleter)
+	suite.documentGetter = new(mocks.DocumentGetter)
+
+	suite.documentCreator.On("Create", mock.Anything, mock.Anything).Return(suite.document, nil)
+	suite.documentClassifier.On("Classify", mock.Anything, mock.Anything,
+		mock.Anything).Return(suite.classificationResult, nil)
+	suite.documentReader.On("Read", mock.Anything, mock.Anything).Return(nil)
+	suite.documentReader.On("ReadResult", mock.Anything, mock.Anything, mock.Anything).
+		Return(ioutil.NopCloser(bytes.NewBufferString("read result")), nil).Once()
+	suite.documentDeleter.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	suite.documentGetter.On("GetAll", mock.Anything).Return(nil, nil)
+
+	suite.ctx = context.Background()
+}
+
+func TestDeduplicatorSuiteRunner(t *testing.T) {
+	suite.Run(t, new(DeduplicatorSuite))
+}
+
+func (suite *DeduplicatorSuite) fileClassifier() *ch360.FileClassifier {
+	return ch360.NewFileClassifier(suite.documentCreator, suite.documentClassifier,
+		suite.documentDeleter).
+		WithDeduplicator(ch360.NewDeduplicator(suite.documentGetter))
+}
+
+// concurrently calls first and then second, holding up the creation of first's document
+// until second has had time to start.
+func (suite *DeduplicatorSuite) concurrently(first, second func()) {
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	suite.documentCreator.ExpectedCalls = nil
+	suite.documentCreator.On("Create", mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) {
+			started <- struct{}{}
+			<-release
+		}).
+		Return(suite.document, nil)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		first()
+	}()
+	<-started
+	go func() {
+		defer wg.Done()
+		second()
+	}()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+}
+
+func (suite *DeduplicatorSuite) TestIdentical_Files_Are_Only_Uploaded_And_Classified_Once_At_A_Time() {
+	sut := suite.fileClassifier()
+	var (
+		result1, result2 *results.ClassificationResult
+		err1, err2       error
+	)
+
+	suite.concurrently(func() {
+		result1, err1 = sut.Classify(suite.ctx, bytes.NewBuffer(suite.fileContent), "classifier")
+	}, func() {
+		result2, err2 = sut.Classify(suite.ctx, bytes.NewBuffer(suite.fileContent), "classifier")
+	})
+
+	assert.NoError(suite.T(), err1)
+	assert.NoError(suite.T(), err2)
+	assert.Equal(suite.T(), suite.classificationResult, result1)
+	assert.Equal(suite.T(), suite.classificationResult, result2)
+	suite.documentCreator.AssertNumberOfCalls(suite.T(), "Create", 1)
+	suite.documentClassifier.AssertNumberOfCalls(suite.T(), "Classify", 1)
+	suite.documentDeleter.AssertNumberOfCalls(suite.T(), "Delete", 1)
+}
+
+func (suite *DeduplicatorSuite) TestIdentical_Files_Are_Processed_Again_Once_The_First_Has_Finished() {
+	sut := suite.fileClassifier()
+
+	_, _ = sut.Classify(suite.ctx, bytes.NewBuffer(suite.fileContent), "classifier")
+	_, _ = sut.Classify(suite.ctx, bytes.NewBuffer(suite.fileContent), "classifier")
+
+	suite.documentCreator.AssertNumberOfCalls(suite.T(), "Create", 2)
+	suite.documentClassifier.AssertNumberOfCalls(suite.T(), "Classify", 2)
+}
+
+func (suite *DeduplicatorSuite) TestIdentical_Files_Are_Processed_Again_For_A_Different_Operation() {
+	sut := suite.fileClassifier()
+
+	_, _ = sut.Classify(suite.ctx, bytes.NewBuffer(suite.fileContent), "classifier1")
+	_, _ = sut.Classify(suite.ctx, bytes.NewBuffer(suite.fileContent), "classifier2")
+
+	suite.documentClassifier.AssertNumberOfCalls(suite.T(), "Classify", 2)
+}
+
+func (suite *DeduplicatorSuite) TestDifferent_Files_Are_Each_Processed() {
+	sut := suite.fileClassifier()
+
+	_, _ = sut.Classify(suite.ctx, bytes.NewBuffer(suite.fileContent), "classifier")
+	_, _ = sut.Classify(suite.ctx, bytes.NewBufferString("other data"), "classifier")
+
+	suite.documentCreator.AssertNumberOfCalls(suite.T(), "Create", 2)
+	suite.documentClassifier.AssertNumberOfCalls(suite.T(), "Classify", 2)
+}
+
+func (suite *DeduplicatorSuite) TestExisting_Document_With_Same_Hash_Is_Reused_And_Not_Deleted() {
+	existingDocument := ch360.Document{
+		Id: generators.String("existingDocumentId"),
+		// sha256 of "some data"
+		Sha256: "1307990E6BA5CA145EB35E99182A9BEC46531BC54DDF656A602C780FA0240DEE",
+	}
+	suite.documentGetter.ExpectedCalls = nil
+	suite.documentGetter.On("GetAll", mock.Anything).
+		Return(ch360.DocumentList{existingDocument}, nil)
+	sut := suite.fileClassifier()
+
+	_, err := sut.Classify(suite.ctx, bytes.NewBuffer(suite.fileContent), "classifier")
+
+	assert.NoError(suite.T(), err)
+	suite.documentCreator.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+	suite.documentDeleter.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+	suite.documentClassifier.AssertCalled(suite.T(), "Classify", suite.ctx, existingDocument.Id,
+		"classifier")
+}
+
+func (suite *DeduplicatorSuite) TestExisting_Documents_Are_Retrieved_Again_After_A_Failure() {
+	existingDocument := ch360.Document{
+		Id: generators.String("existingDocumentId"),
+		// sha256 of "some data"
+		Sha256: "1307990E6BA5CA145EB35E99182A9BEC46531BC54DDF656A602C780FA0240DEE",
+	}
+	expectedErr := errors.New("simulated error")
+	suite.documentGetter.ExpectedCalls = nil
+	suite.documentGetter.On("GetAll", mock.Anything).Return(nil, expectedErr).Once()
+	suite.documentGetter.On("GetAll", mock.Anything).
+		Return(ch360.DocumentList{existingDocument}, nil)
+	sut := suite.fileClassifier()
+
+	_, err1 := sut.Classify(suite.ctx, bytes.NewBuffer(suite.fileContent), "classifier")
+	_, err2 := sut.Classify(suite.ctx, bytes.NewBuffer(suite.fileContent), "classifier")
+
+	assert.Equal(suite.T(), expectedErr, err1)
+	assert.NoError(suite.T(), err2)
+	suite.documentCreator.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+	suite.documentClassifier.AssertCalled(suite.T(), "Classify", suite.ctx, existingDocument.Id,
+		"classifier")
+}
+
+func (suite *DeduplicatorSuite) TestFileReader_Returns_Independent_Readers_For_Identical_Files() {
+	sut := ch360.NewFileReader(suite.documentCreator, suite.documentReader, suite.documentDeleter).
+		WithDeduplicator(ch360.NewDeduplicator(suite.documentGetter))
+	var (
+		result1, result2 io.ReadCloser
+		err1, err2       error
+	)
+
+	suite.concurrently(func() {
+		result1, err1 = sut.Read(suite.ctx, bytes.NewBuffer(suite.fileContent), ch360.ReadText)
+	}, func() {
+		result2, err2 = sut.Read(suite.ctx, bytes.NewBuffer(suite.fileContent), ch360.ReadText)
+	})
+
+	assert.NoError(suite.T(), err1)
+	assert.NoError(suite.T(), err2)
+	contents1, _ := ioutil.ReadAll(result1)
+	contents2, _ := ioutil.ReadAll(result2)
+	assert.Equal(suite.T(), "read result", string(contents1))
+	assert.Equal(suite.T(), "read result", string(contents2))
+	suite.documentReader.AssertNumberOfCalls(suite.T(), "ReadResult", 1)
+}
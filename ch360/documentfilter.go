@@ -0,0 +1,109 @@
+package ch360
+
+import (
+	"sort"
+	"strings"
+)
+
+// DocumentFilter describes the criteria used to select documents from a
+// DocumentList. Any zero-valued criteria are ignored.
+type DocumentFilter struct {
+	// FileType is matched case-insensitively against either the whole of a
+	// document's file type (e.g. "Image:TIFF"), or any part of it (e.g. "tiff").
+	FileType string
+	Sha256   string
+	// LargerThan selects documents with a size (in bytes) greater than this value.
+	LargerThan int
+}
+
+// IsEmpty returns true if the filter has no criteria, and so matches all documents.
+func (f DocumentFilter) IsEmpty() bool {
+	return f == DocumentFilter{}
+}
+
+// Matches returns true if the provided document meets all the filter's criteria.
+func (f DocumentFilter) Matches(document Document) bool {
+	if f.FileType != "" && !fileTypeMatches(document.FileType, f.FileType) {
+		return false
+	}
+
+	if f.Sha256 != "" && !strings.EqualFold(document.Sha256, f.Sha256) {
+		return false
+	}
+
+	if f.LargerThan > 0 && document.Size <= f.LargerThan {
+		return false
+	}
+
+	return true
+}
+
+func fileTypeMatches(fileType, wanted string) bool {
+	if strings.EqualFold(fileType, wanted) {
+		return true
+	}
+
+	for _, part := range strings.Split(fileType, ":") {
+		if strings.EqualFold(part, wanted) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Filter returns the documents in the list which match the provided filter.
+func (documents DocumentList) Filter(filter DocumentFilter) DocumentList {
+	var filtered DocumentList
+
+	for _, document := range documents {
+		if filter.Matches(document) {
+			filtered = append(filtered, document)
+		}
+	}
+
+	return filtered
+}
+
+// The fields by which a DocumentList can be sorted.
+const (
+	SortDocumentsById     = "id"
+	SortDocumentsBySize   = "size"
+	SortDocumentsByType   = "type"
+	SortDocumentsBySha256 = "sha256"
+)
+
+var DocumentSortFields = []string{
+	SortDocumentsById,
+	SortDocumentsBySize,
+	SortDocumentsByType,
+	SortDocumentsBySha256,
+}
+
+// SortBy returns a copy of the list, sorted by the specified field (one of
+// DocumentSortFields). Unknown fields leave the order unchanged.
+func (documents DocumentList) SortBy(field string) DocumentList {
+	sorted := make(DocumentList, len(documents))
+	copy(sorted, documents)
+
+	var less func(a, b Document) bool
+
+	switch field {
+	case SortDocumentsById:
+		less = func(a, b Document) bool { return a.Id < b.Id }
+	case SortDocumentsBySize:
+		less = func(a, b Document) bool { return a.Size < b.Size }
+	case SortDocumentsByType:
+		less = func(a, b Document) bool { return a.FileType < b.FileType }
+	case SortDocumentsBySha256:
+		less = func(a, b Document) bool { return a.Sha256 < b.Sha256 }
+	default:
+		return sorted
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return less(sorted[i], sorted[j])
+	})
+
+	return sorted
+}
@@ -0,0 +1,68 @@
+package ch360_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/waives/surf/ch360"
+	"testing"
+)
+
+var filterableDocuments = ch360.DocumentList{
+	{Id: "b", Size: 300, FileType: "PDF:PDFMisc", Sha256: "aaa"},
+	{Id: "c", Size: 100, FileType: "Image:TIFF", Sha256: "bbb"},
+	{Id: "a", Size: 200, FileType: "Image:TIFF", Sha256: "ccc"},
+}
+
+func TestDocumentList_Filter(t *testing.T) {
+	fixtures := []struct {
+		filter      ch360.DocumentFilter
+		expectedIds []string
+	}{
+		{ch360.DocumentFilter{}, []string{"b", "c", "a"}},
+		{ch360.DocumentFilter{FileType: "tiff"}, []string{"c", "a"}},
+		{ch360.DocumentFilter{FileType: "pdf"}, []string{"b"}},
+		{ch360.DocumentFilter{FileType: "image:tiff"}, []string{"c", "a"}},
+		{ch360.DocumentFilter{FileType: "docx"}, nil},
+		{ch360.DocumentFilter{Sha256: "BBB"}, []string{"c"}},
+		{ch360.DocumentFilter{LargerThan: 150}, []string{"b", "a"}},
+		{ch360.DocumentFilter{FileType: "tiff", LargerThan: 150}, []string{"a"}},
+	}
+
+	for _, fixture := range fixtures {
+		filtered := filterableDocuments.Filter(fixture.filter)
+
+		assert.Equal(t, fixture.expectedIds, idsOf(filtered), "filter: %+v", fixture.filter)
+	}
+}
+
+func TestDocumentList_SortBy(t *testing.T) {
+	fixtures := []struct {
+		field       string
+		expectedIds []string
+	}{
+		{ch360.SortDocumentsById, []string{"a", "b", "c"}},
+		{ch360.SortDocumentsBySize, []string{"c", "a", "b"}},
+		{ch360.SortDocumentsByType, []string{"c", "a", "b"}},
+		{ch360.SortDocumentsBySha256, []string{"b", "c", "a"}},
+		{"", []string{"b", "c", "a"}},
+	}
+
+	for _, fixture := range fixtures {
+		sorted := filterableDocuments.SortBy(fixture.field)
+
+		assert.Equal(t, fixture.expectedIds, idsOf(sorted), "field: %s", fixture.field)
+	}
+}
+
+func TestDocumentList_SortBy_Does_Not_Modify_Original_List(t *testing.T) {
+	_ = filterableDocuments.SortBy(ch360.SortDocumentsById)
+
+	assert.Equal(t, []string{"b", "c", "a"}, idsOf(filterableDocuments))
+}
+
+func idsOf(documents ch360.DocumentList) []string {
+	var ids []string
+	for _, document := range documents {
+		ids = append(ids, document.Id)
+	}
+	return ids
+}
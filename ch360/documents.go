@@ -3,10 +3,14 @@ package ch360
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/waives/surf/ch360/request"
 	"github.com/waives/surf/ch360/results"
+	"github.com/waives/surf/ioutils"
 	"github.com/waives/surf/net"
 	"io"
 )
@@ -103,7 +107,102 @@ type classifyDocumentResponse struct {
 	} `json:"classification_results"`
 }
 
+// maxAmbiguousCreateRetries is the number of times an upload which may (or may not)
+// have created a document is retried, if it is found not to have.
+const maxAmbiguousCreateRetries = 3
+
+// Create uploads the file contents as a new document. If the upload fails in a way
+// which means the document may have been created (see net.AmbiguousResultError), it
+// is only uploaded again if there is no document with the same SHA-256 hash; if there
+// is, that document is returned in place of the one which would have been created.
+//
+// If the contents are an io.ReadSeeker (e.g. an *os.File), they are streamed from it
+// (and rewound to be sent again), otherwise they are read into memory.
 func (client *DocumentsClient) Create(ctx context.Context, fileContents io.Reader) (Document,
+	error) {
+	contents, start, err := rewindable(fileContents)
+	if err != nil {
+		return Document{}, err
+	}
+
+	document, err := client.create(ctx, contents)
+
+	if !net.IsAmbiguousResult(err) {
+		return document, err
+	}
+
+	rewind := func() error {
+		_, seekErr := contents.Seek(start, io.SeekStart)
+		return seekErr
+	}
+
+	for retries := 0; retries < maxAmbiguousCreateRetries; retries++ {
+		existing, listErr := client.withContents(ctx, contents, rewind)
+		if listErr != nil {
+			return Document{}, fmt.Errorf("The document may have been created, but this could "+
+				"not be checked (%s). Error: %s", listErr.Error(), err.Error())
+		}
+
+		if len(existing) > 0 {
+			// the document was (most likely) created, and is used as if the upload had
+			// succeeded, so that it is deleted along with any other
+			return existing[0], nil
+		}
+
+		// the document was not created, so it is safe to upload it again
+		if rewindErr := rewind(); rewindErr != nil {
+			return Document{}, rewindErr
+		}
+
+		document, err = client.create(ctx, contents)
+		if !net.IsAmbiguousResult(err) {
+			return document, err
+		}
+	}
+
+	return Document{}, err
+}
+
+// rewindable returns the reader (and its current position) if it can be rewound to be
+// read again, otherwise a reader over its contents, read into memory.
+func rewindable(reader io.Reader) (io.ReadSeeker, int64, error) {
+	if seeker, ok := reader.(io.ReadSeeker); ok {
+		// files which can't be seeked (such as pipes) are read into memory
+		if start, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+			return seeker, start, nil
+		}
+	}
+
+	contents, err := ioutils.DrainClose(reader)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return bytes.NewReader(contents.Bytes()), 0, nil
+}
+
+// withContents returns the existing documents with the same contents as the reader,
+// which is rewound before it is read.
+func (client *DocumentsClient) withContents(ctx context.Context, contents io.Reader,
+	rewind func() error) (DocumentList, error) {
+	if err := rewind(); err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, contents); err != nil {
+		return nil, err
+	}
+
+	documents, err := client.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return documents.Filter(DocumentFilter{Sha256: hex.EncodeToString(hash.Sum(nil))}), nil
+}
+
+func (client *DocumentsClient) create(ctx context.Context, fileContents io.Reader) (Document,
 	error) {
 	response, err := newRequest(ctx, "POST", client.baseUrl+"/documents", fileContents).
 		issue(client.requestSender)
@@ -111,6 +210,7 @@ func (client *DocumentsClient) Create(ctx context.Context, fileContents io.Reade
 	if err != nil {
 		return Document{}, err
 	}
+	defer response.Body.Close()
 
 	buf := bytes.Buffer{}
 	_, err = buf.ReadFrom(response.Body)
@@ -129,10 +229,8 @@ func (client *DocumentsClient) Create(ctx context.Context, fileContents io.Reade
 }
 
 func (client *DocumentsClient) Delete(ctx context.Context, documentId string) error {
-	_, err := newRequest(ctx, "DELETE", client.baseUrl+"/documents/"+documentId, nil).
-		issue(client.requestSender)
-
-	return err
+	return newRequest(ctx, "DELETE", client.baseUrl+"/documents/"+documentId, nil).
+		send(client.requestSender)
 }
 
 func (client *DocumentsClient) Classify(ctx context.Context, documentId string, classifierName string) (*results.ClassificationResult, error) {
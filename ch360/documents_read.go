@@ -37,6 +37,16 @@ func (mode ReadMode) IsBinary() bool {
 	return mode != ReadText
 }
 
+var readModeNames = map[ReadMode]string{
+	ReadPDF:   "pdf",
+	ReadText:  "txt",
+	ReadWvdoc: "wvdoc",
+}
+
+func (mode ReadMode) String() string {
+	return readModeNames[mode]
+}
+
 var readModeHeaders = map[ReadMode]string{
 	ReadPDF:   "application/pdf",
 	ReadText:  "text/plain",
@@ -3,6 +3,8 @@ package ch360_test
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -10,9 +12,13 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/waives/surf/ch360"
 	"github.com/waives/surf/ch360/results"
+	"github.com/waives/surf/net"
 	"github.com/waives/surf/net/mocks"
 	"github.com/waives/surf/test/generators"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -80,6 +86,25 @@ func (suite *DocumentsClientSuite) Test_CreateDocument_Issues_Create_Document_Re
 	suite.AssertRequestHasBody(suite.fileContents.Bytes())
 }
 
+func (suite *DocumentsClientSuite) Test_CreateDocument_Streams_Rewindable_File_Contents() {
+	// Arrange
+	contents := bytes.NewReader(suite.fileContents.Bytes())
+	suite.httpClient.On("Do", mock.Anything).Return(exampleCreateDocHttpResponse, nil)
+
+	// Act
+	suite.sut.Create(context.Background(), contents)
+
+	// Assert
+	request := suite.request()
+	require.NotNil(suite.T(), request.GetBody)
+	assert.Equal(suite.T(), int64(suite.fileContents.Len()), request.ContentLength)
+	suite.AssertRequestHasBody(suite.fileContents.Bytes())
+
+	// the request can be sent again
+	request.Body, _ = request.GetBody()
+	suite.AssertRequestHasBody(suite.fileContents.Bytes())
+}
+
 func (suite *DocumentsClientSuite) Test_CreateDocument_Returns_Document() {
 	suite.httpClient.On("Do", mock.Anything).Return(exampleCreateDocHttpResponse, nil)
 
@@ -108,8 +133,91 @@ func (suite *DocumentsClientSuite) Test_CreateDocument_Returns_Error_If_Document
 	assert.Equal(suite.T(), expectedErr, err)
 }
 
+func (suite *DocumentsClientSuite) onRequest(method string, urlPath string) *mock.Call {
+	return suite.httpClient.On("Do", mock.MatchedBy(func(request *http.Request) bool {
+		return request.Method == method && request.URL.Path == urlPath
+	}))
+}
+
+func (suite *DocumentsClientSuite) Test_CreateDocument_Uploads_Again_After_Ambiguous_Failure_If_Not_Created() {
+	// Arrange
+	expectedContents := suite.fileContents.Bytes()
+	var uploadedContents [][]byte
+	suite.onRequest("POST", apiUrl+"/documents").
+		Run(func(args mock.Arguments) {
+			body, _ := ioutil.ReadAll(args.Get(0).(*http.Request).Body)
+			uploadedContents = append(uploadedContents, body)
+		}).
+		Return(nil, &net.AmbiguousResultError{Err: errors.New("connection reset")}).Once()
+	suite.onRequest("GET", apiUrl+"/documents").Return(exampleGetAllDocsHttpResponse, nil)
+	suite.onRequest("POST", apiUrl+"/documents").
+		Run(func(args mock.Arguments) {
+			body, _ := ioutil.ReadAll(args.Get(0).(*http.Request).Body)
+			uploadedContents = append(uploadedContents, body)
+		}).
+		Return(exampleCreateDocHttpResponse, nil)
+
+	// Act
+	document, err := suite.sut.Create(context.Background(), suite.fileContents)
+
+	// Assert
+	require.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "X9wK2AgWkk-m7e4f9oD5ew", document.Id)
+	assert.Equal(suite.T(), [][]byte{expectedContents, expectedContents}, uploadedContents)
+}
+
+func (suite *DocumentsClientSuite) Test_CreateDocument_Returns_Existing_Document_After_Ambiguous_Failure() {
+	// Arrange
+	hash := sha256.Sum256(suite.fileContents.Bytes())
+	existingDocs := strings.Replace(exampleGetAllDocumentsResponse,
+		"dffe7ff587dfbd7c1dca771529c802994d5dad432986e1aaeae189b9acd40753", hex.EncodeToString(hash[:]), 1)
+	suite.onRequest("POST", apiUrl+"/documents").
+		Return(nil, &net.AmbiguousResultError{Err: errors.New("connection reset")})
+	suite.onRequest("GET", apiUrl+"/documents").Return(AnHttpResponse([]byte(existingDocs)), nil)
+
+	// Act
+	document, err := suite.sut.Create(context.Background(), suite.fileContents)
+
+	// Assert
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "yOq34IxGWk-_kAfQUdlcbw", document.Id)
+	suite.httpClient.AssertNumberOfCalls(suite.T(), "Do", 2)
+}
+
+func (suite *DocumentsClientSuite) Test_CreateDocument_Returns_Rewind_Error_After_Ambiguous_Failure() {
+	// Arrange
+	expectedErr := errors.New("simulated seek error")
+	contents := &failingSeeker{ReadSeeker: bytes.NewReader(suite.fileContents.Bytes()), err: expectedErr}
+	suite.onRequest("POST", apiUrl+"/documents").
+		Return(nil, &net.AmbiguousResultError{Err: errors.New("connection reset")})
+	// the contents can be rewound to check for an existing document, but not to upload them again
+	suite.onRequest("GET", apiUrl+"/documents").
+		Run(func(mock.Arguments) { contents.failing = true }).
+		Return(exampleGetAllDocsHttpResponse, nil)
+
+	// Act
+	_, err := suite.sut.Create(context.Background(), contents)
+
+	// Assert
+	assert.Equal(suite.T(), expectedErr, err)
+}
+
+// failingSeeker fails to seek once failing is set.
+type failingSeeker struct {
+	io.ReadSeeker
+	failing bool
+	err     error
+}
+
+func (s *failingSeeker) Seek(offset int64, whence int) (int64, error) {
+	if s.failing {
+		return 0, s.err
+	}
+	return s.ReadSeeker.Seek(offset, whence)
+}
+
 func (suite *DocumentsClientSuite) Test_DeleteDocument_Issues_Delete_Document_Request() {
-	suite.httpClient.On("Do", mock.Anything).Return(nil, nil)
+	suite.httpClient.On("Do", mock.Anything).Return(AnHttpResponse([]byte("")), nil)
 
 	suite.sut.Delete(context.Background(), suite.documentId)
 
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"github.com/pkg/errors"
 	"github.com/waives/surf/net"
 	"io"
+	"os"
 )
 
 type ExtractorsClient struct {
@@ -27,10 +29,8 @@ type Extractor struct {
 type ExtractorList []Extractor
 
 func (client *ExtractorsClient) Create(ctx context.Context, name string, config io.Reader) error {
-	_, err := newRequest(ctx, "POST", client.baseUrl+"/extractors/"+name, config).
-		issue(client.requestSender)
-
-	return err
+	return newRequest(ctx, "POST", client.baseUrl+"/extractors/"+name, config).
+		send(client.requestSender)
 }
 
 func (client *ExtractorsClient) CreateFromJson(ctx context.Context, name string, jsonTemplate io.Reader) error {
@@ -65,6 +65,43 @@ func NewModulesTemplateFromJson(stream io.Reader) (*ExtractorTemplate, error) {
 	return &template, err
 }
 
+// NewModulesTemplateFromFile reads an extractor template from the named json file.
+func NewModulesTemplateFromFile(filename string) (*ExtractorTemplate, error) {
+	file, err := os.Open(filename)
+
+	if err != nil {
+		// err is guaranteed to be os.PathError
+		pathErr := err.(*os.PathError)
+		return nil, errors.Errorf("failed to open template file '%s': %v", filename,
+			pathErr.Err.Error())
+	}
+	defer file.Close()
+
+	template, err := NewModulesTemplateFromJson(file)
+
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to read json template '%s'", filename)
+	}
+
+	return template, nil
+}
+
+// Equals reports whether two templates define the same extractor.
+func (template ExtractorTemplate) Equals(other ExtractorTemplate) bool {
+	// compare serialised forms, so that nil and empty collections are treated alike
+	templateJson, err := json.Marshal(template)
+	if err != nil {
+		return false
+	}
+
+	otherJson, err := json.Marshal(other)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(templateJson, otherJson)
+}
+
 func (client *ExtractorsClient) CreateFromModules(ctx context.Context, name string, modules ExtractorTemplate) error {
 	headers := map[string]string{
 		"Content-Type": "application/json",
@@ -76,20 +113,63 @@ func (client *ExtractorsClient) CreateFromModules(ctx context.Context, name stri
 		return err
 	}
 
-	_, err = newRequest(ctx, "POST", client.baseUrl+"/extractors/"+name, bytes.NewBuffer(jsonTemplate)).
+	return newRequest(ctx, "POST", client.baseUrl+"/extractors/"+name, bytes.NewBuffer(jsonTemplate)).
+		withHeaders(headers).
+		send(client.requestSender)
+}
+
+// GetTemplate retrieves the definition of the named extractor, in the same form as
+// is used to create it.
+func (client *ExtractorsClient) GetTemplate(ctx context.Context, name string) (*ExtractorTemplate, error) {
+	headers := map[string]string{
+		"Accept": "application/json",
+	}
+
+	response, err := newRequest(ctx, "GET", client.baseUrl+"/extractors/"+name, nil).
 		withHeaders(headers).
 		issue(client.requestSender)
 
-	return err
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return NewModulesTemplateFromJson(response.Body)
 }
 
-func (client *ExtractorsClient) Delete(ctx context.Context, name string) error {
-	_, err := newRequest(ctx, "DELETE", client.baseUrl+"/extractors/"+name, nil).
-		issue(client.requestSender)
+// ExtractorReplacer is the subset of the ExtractorsClient needed to replace an extractor.
+type ExtractorReplacer interface {
+	Delete(ctx context.Context, name string) error
+	CreateFromModules(ctx context.Context, name string, modules ExtractorTemplate) error
+}
+
+// ReplaceExtractor replaces the definition of an existing extractor. There's no update
+// operation, so the extractor is deleted and created again from the replacement template.
+// If the replacement is rejected, the previous definition is put back rather than leave
+// the extractor missing, and the error from the replacement is returned.
+func ReplaceExtractor(ctx context.Context, extractors ExtractorReplacer, name string,
+	previous ExtractorTemplate, replacement ExtractorTemplate) error {
+	if err := extractors.Delete(ctx, name); err != nil {
+		return err
+	}
+
+	err := extractors.CreateFromModules(ctx, name, replacement)
+
+	if err != nil {
+		if restoreErr := extractors.CreateFromModules(ctx, name, previous); restoreErr != nil {
+			return errors.WithMessagef(err, "extractor '%s' could not be restored (%v)",
+				name, restoreErr)
+		}
+	}
 
 	return err
 }
 
+func (client *ExtractorsClient) Delete(ctx context.Context, name string) error {
+	return newRequest(ctx, "DELETE", client.baseUrl+"/extractors/"+name, nil).
+		send(client.requestSender)
+}
+
 func (client *ExtractorsClient) GetAll(ctx context.Context) (ExtractorList, error) {
 	response, err := newRequest(ctx, "GET", client.baseUrl+"/extractors", nil).
 		issue(client.requestSender)
@@ -97,6 +177,7 @@ func (client *ExtractorsClient) GetAll(ctx context.Context) (ExtractorList, erro
 	if err != nil {
 		return nil, err
 	}
+	defer response.Body.Close()
 
 	buf := bytes.Buffer{}
 	_, err = buf.ReadFrom(response.Body)
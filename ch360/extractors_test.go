@@ -3,6 +3,7 @@ package ch360_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -25,7 +26,7 @@ type ExtractorsClientSuite struct {
 
 func (suite *ExtractorsClientSuite) SetupTest() {
 	suite.httpClient = new(mocks.HttpDoer)
-	suite.httpClient.On("Do", mock.Anything).Return(nil, nil)
+	suite.httpClient.On("Do", mock.Anything).Return(anHttpResponse([]byte("")), nil)
 
 	suite.sut = ch360.NewExtractorsClient(apiUrl, suite.httpClient)
 	suite.extractorName = "extractor-name"
@@ -122,6 +123,64 @@ func (suite *ExtractorsClientSuite) Test_GetAll_Returns_List_Of_Extractors() {
 	assert.Equal(suite.T(), AListOfExtractors("my-extractor", "amount"), extractors)
 }
 
+func (suite *ExtractorsClientSuite) Test_GetTemplate_Issues_Get_Extractor_Request() {
+	// Arrange
+	suite.ClearExpectedCalls()
+	suite.httpClient.On("Do", mock.Anything).Return(
+		AnHttpResponse([]byte(modulesTemplateJson)),
+		nil)
+
+	// Act
+	suite.sut.GetTemplate(suite.ctx, suite.extractorName)
+
+	// Assert
+	suite.AssertRequestIssued("GET", apiUrl+"/extractors/"+suite.extractorName).
+		WithHeaders(suite.T(), map[string][]string{
+			"Accept": {"application/json"},
+		})
+}
+
+func (suite *ExtractorsClientSuite) Test_GetTemplate_Returns_Extractor_Template() {
+	// Arrange
+	suite.ClearExpectedCalls()
+	suite.httpClient.On("Do", mock.Anything).Return(
+		AnHttpResponse([]byte(modulesTemplateJson)),
+		nil)
+
+	// Act
+	template, err := suite.sut.GetTemplate(suite.ctx, suite.extractorName)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), suite.modulesTemplate, template)
+}
+
+func (suite *ExtractorsClientSuite) Test_GetTemplate_Returns_Error_From_Request() {
+	// Arrange
+	expectedErr := errors.New("simulated error")
+	suite.ClearExpectedCalls()
+	suite.httpClient.On("Do", mock.Anything).Return(nil, expectedErr)
+
+	// Act
+	_, err := suite.sut.GetTemplate(suite.ctx, suite.extractorName)
+
+	// Assert
+	assert.Equal(suite.T(), expectedErr, err)
+}
+
+func (suite *ExtractorsClientSuite) Test_ExtractorTemplate_Equals_Identical_Template() {
+	other := aModulesTemplate()
+
+	assert.True(suite.T(), suite.modulesTemplate.Equals(*other))
+}
+
+func (suite *ExtractorsClientSuite) Test_ExtractorTemplate_Does_Not_Equal_Modified_Template() {
+	other := aModulesTemplate()
+	other.Modules[0].Arguments["format"] = "[0-9]"
+
+	assert.False(suite.T(), suite.modulesTemplate.Equals(*other))
+}
+
 func AListOfExtractors(names ...string) ch360.ExtractorList {
 	var expected ch360.ExtractorList
 
@@ -10,6 +10,7 @@ type FileClassifier struct {
 	docCreator    DocumentCreator
 	docClassifier DocumentClassifier
 	docDeleter    DocumentDeleter
+	deduplicator  *Deduplicator
 }
 
 func NewFileClassifier(creator DocumentCreator, classifier DocumentClassifier,
@@ -21,9 +22,31 @@ func NewFileClassifier(creator DocumentCreator, classifier DocumentClassifier,
 	}
 }
 
+// WithDeduplicator configures the FileClassifier to avoid uploading and classifying
+// files whose contents are already in waives or are being processed via the provided
+// Deduplicator.
+func (f *FileClassifier) WithDeduplicator(deduplicator *Deduplicator) *FileClassifier {
+	f.deduplicator = deduplicator
+	return f
+}
+
 func (f *FileClassifier) Classify(ctx context.Context, fileContents io.Reader,
 	classifierName string) (*results.ClassificationResult, error) {
 
+	if f.deduplicator != nil {
+		result, err := f.deduplicator.process(ctx, fileContents, "classify/"+classifierName,
+			f.docCreator, f.docDeleter,
+			func(document Document) (interface{}, error) {
+				return f.docClassifier.Classify(ctx, document.Id, classifierName)
+			})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return result.(*results.ClassificationResult), nil
+	}
+
 	var (
 		result *results.ClassificationResult
 		err    error
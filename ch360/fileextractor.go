@@ -10,6 +10,7 @@ type FileExtractor struct {
 	docCreator   DocumentCreator
 	docExtractor DocumentExtractor
 	docDeleter   DocumentDeleter
+	deduplicator *Deduplicator
 }
 
 func NewFileExtractor(creator DocumentCreator, extractor DocumentExtractor, deleter DocumentDeleter) *FileExtractor {
@@ -20,9 +21,31 @@ func NewFileExtractor(creator DocumentCreator, extractor DocumentExtractor, dele
 	}
 }
 
+// WithDeduplicator configures the FileExtractor to avoid uploading and extracting
+// files whose contents are already in waives or are being processed via the provided
+// Deduplicator.
+func (f *FileExtractor) WithDeduplicator(deduplicator *Deduplicator) *FileExtractor {
+	f.deduplicator = deduplicator
+	return f
+}
+
 // Extract creates a document, performs extraction, deletes the doc,
 // then returns the extraction result.
 func (f *FileExtractor) Extract(ctx context.Context, fileContents io.Reader, extractorName string) (*results.ExtractionResult, error) {
+	if f.deduplicator != nil {
+		result, err := f.deduplicator.process(ctx, fileContents, "extract/"+extractorName,
+			f.docCreator, f.docDeleter,
+			func(document Document) (interface{}, error) {
+				return f.docExtractor.Extract(ctx, document.Id, extractorName)
+			})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return result.(*results.ExtractionResult), nil
+	}
+
 	var (
 		extractionResult *results.ExtractionResult
 		err              error
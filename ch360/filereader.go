@@ -1,16 +1,21 @@
 package ch360
 
 import (
+	"bytes"
 	"context"
+	"github.com/waives/surf/ioutils"
 	"io"
+	"io/ioutil"
+	"strings"
 )
 
 // Helper struct which creates a document from a file, performs a read, downloads the read
 // result, then deletes the document.
 type FileReader struct {
-	docCreator DocumentCreator
-	docReader  DocumentReader
-	docDeleter DocumentDeleter
+	docCreator   DocumentCreator
+	docReader    DocumentReader
+	docDeleter   DocumentDeleter
+	deduplicator *Deduplicator
 }
 
 func NewFileReader(creator DocumentCreator, reader DocumentReader, deleter DocumentDeleter) *FileReader {
@@ -21,9 +26,21 @@ func NewFileReader(creator DocumentCreator, reader DocumentReader, deleter Docum
 	}
 }
 
+// WithDeduplicator configures the FileReader to avoid uploading and reading
+// files whose contents are already in waives or are being processed via the provided
+// Deduplicator.
+func (f *FileReader) WithDeduplicator(deduplicator *Deduplicator) *FileReader {
+	f.deduplicator = deduplicator
+	return f
+}
+
 // Read creates a document from fileContents, performs a read,
 // then returns the read results in the format according to mode.
 func (f *FileReader) Read(ctx context.Context, fileContents io.Reader, mode ReadMode) (io.ReadCloser, error) {
+	if f.deduplicator != nil {
+		return f.readDeduplicated(ctx, fileContents, mode)
+	}
+
 	var (
 		result io.ReadCloser
 		err    error
@@ -40,3 +57,124 @@ func (f *FileReader) Read(ctx context.Context, fileContents io.Reader, mode Read
 
 	return result, err
 }
+
+// readDeduplicated reads via the FileReader's Deduplicator. Since the result may be
+// shared between several files, it is buffered in memory and each caller is given
+// its own reader over it.
+func (f *FileReader) readDeduplicated(ctx context.Context, fileContents io.Reader,
+	mode ReadMode) (io.ReadCloser, error) {
+	result, err := f.deduplicator.process(ctx, fileContents, "read/"+mode.String(),
+		f.docCreator, f.docDeleter,
+		func(document Document) (interface{}, error) {
+			if err := f.docReader.Read(ctx, document.Id); err != nil {
+				return nil, err
+			}
+
+			readResult, err := f.docReader.ReadResult(ctx, document.Id, mode)
+			if err != nil {
+				return nil, err
+			}
+
+			buf, err := ioutils.DrainClose(readResult)
+			if err != nil {
+				return nil, err
+			}
+
+			return buf.Bytes(), nil
+		})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ioutil.NopCloser(bytes.NewReader(result.([]byte))), nil
+}
+
+// ReadModes creates a document from fileContents and performs a single read, then
+// returns the read results in each of the formats according to modes.
+func (f *FileReader) ReadModes(ctx context.Context, fileContents io.Reader,
+	modes []ReadMode) (map[ReadMode]io.ReadCloser, error) {
+	if f.deduplicator != nil {
+		return f.readModesDeduplicated(ctx, fileContents, modes)
+	}
+
+	var (
+		results = map[ReadMode]io.ReadCloser{}
+		err     error
+	)
+
+	err = CreateDocumentFor(fileContents, f.docCreator, f.docDeleter,
+		func(document Document) error {
+			if err := f.docReader.Read(ctx, document.Id); err != nil {
+				return err
+			}
+
+			for _, mode := range modes {
+				result, err := f.docReader.ReadResult(ctx, document.Id, mode)
+				if err != nil {
+					closeAll(results)
+					return err
+				}
+				results[mode] = result
+			}
+
+			return nil
+		})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// readModesDeduplicated reads via the FileReader's Deduplicator, buffering the results
+// in memory as readDeduplicated does.
+func (f *FileReader) readModesDeduplicated(ctx context.Context, fileContents io.Reader,
+	modes []ReadMode) (map[ReadMode]io.ReadCloser, error) {
+	modeNames := make([]string, len(modes))
+	for i, mode := range modes {
+		modeNames[i] = mode.String()
+	}
+
+	result, err := f.deduplicator.process(ctx, fileContents, "read/"+strings.Join(modeNames, ","),
+		f.docCreator, f.docDeleter,
+		func(document Document) (interface{}, error) {
+			if err := f.docReader.Read(ctx, document.Id); err != nil {
+				return nil, err
+			}
+
+			buffers := map[ReadMode][]byte{}
+			for _, mode := range modes {
+				readResult, err := f.docReader.ReadResult(ctx, document.Id, mode)
+				if err != nil {
+					return nil, err
+				}
+
+				buf, err := ioutils.DrainClose(readResult)
+				if err != nil {
+					return nil, err
+				}
+				buffers[mode] = buf.Bytes()
+			}
+
+			return buffers, nil
+		})
+
+	if err != nil {
+		return nil, err
+	}
+
+	results := map[ReadMode]io.ReadCloser{}
+	for mode, buf := range result.(map[ReadMode][]byte) {
+		results[mode] = ioutil.NopCloser(bytes.NewReader(buf))
+	}
+
+	return results, nil
+}
+
+func closeAll(readClosers map[ReadMode]io.ReadCloser) {
+	for _, readCloser := range readClosers {
+		readCloser.Close()
+	}
+}
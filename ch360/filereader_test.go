@@ -142,3 +142,41 @@ func (suite *fileReaderSuite) Test_Delete_Not_Called_If_Create_Returns_Error() {
 	// Assert
 	suite.docDeleter.AssertNumberOfCalls(suite.T(), "Delete", 0)
 }
+
+func (suite *fileReaderSuite) Test_ReadModes_Reads_Once_And_Gets_Each_Result() {
+	modes := []ch360.ReadMode{ch360.ReadPDF, ch360.ReadText, ch360.ReadWvdoc}
+
+	results, err := suite.sut.ReadModes(suite.ctx, suite.fileContents, modes)
+
+	suite.Require().NoError(err)
+	suite.Assert().Len(results, len(modes))
+	suite.docCreator.AssertNumberOfCalls(suite.T(), "Create", 1)
+	suite.docReader.AssertNumberOfCalls(suite.T(), "Read", 1)
+	for _, mode := range modes {
+		suite.Assert().Contains(results, mode)
+		suite.docReader.
+			AssertCalled(suite.T(), "ReadResult", suite.ctx, suite.documentId, mode)
+	}
+	suite.docDeleter.AssertCalled(suite.T(), "Delete", context.Background(), suite.documentId)
+}
+
+func (suite *fileReaderSuite) Test_ReadModes_Returns_Error_From_ReadResult() {
+	expectedErr := errors.New("generated err")
+	suite.docReader.ExpectedCalls = nil
+	suite.docReader.
+		On("Read", mock.Anything, mock.Anything).
+		Return(nil)
+	suite.docReader.
+		On("ReadResult", mock.Anything, mock.Anything, ch360.ReadPDF).
+		Return(ioutil.NopCloser(suite.fileContents), nil)
+	suite.docReader.
+		On("ReadResult", mock.Anything, mock.Anything, ch360.ReadText).
+		Return(nil, expectedErr)
+
+	results, receivedErr := suite.sut.ReadModes(suite.ctx, suite.fileContents,
+		[]ch360.ReadMode{ch360.ReadPDF, ch360.ReadText})
+
+	suite.Assert().Equal(expectedErr, receivedErr)
+	suite.Assert().Nil(results)
+	suite.docDeleter.AssertCalled(suite.T(), "Delete", context.Background(), suite.documentId)
+}
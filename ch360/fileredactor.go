@@ -28,6 +28,13 @@ func NewFileRedactor(creator DocumentCreator, extractor DocumentExtractor,
 // then redacts it with the results of the extraction.
 func (f *FileRedactor) Redact(ctx context.Context, fileContents io.Reader,
 	extractorName string) (io.ReadCloser, error) {
+	return f.RedactWith(ctx, fileContents, NewExtractorRedaction(f.docExtractor, extractorName))
+}
+
+// RedactWith creates a document from the fileContents, then redacts it with
+// the request built for it by the provided RedactionRequestBuilder.
+func (f *FileRedactor) RedactWith(ctx context.Context, fileContents io.Reader,
+	builder RedactionRequestBuilder) (io.ReadCloser, error) {
 	var (
 		redacted io.ReadCloser
 		err      error
@@ -35,16 +42,12 @@ func (f *FileRedactor) Redact(ctx context.Context, fileContents io.Reader,
 
 	err = CreateDocumentFor(fileContents, f.docCreator, f.docDeleter,
 		func(document Document) error {
-			// use the results from extraction...
-			extractionResult, err := f.docExtractor.ExtractForRedaction(ctx, document.Id,
-				extractorName)
+			redactRequest, err := builder.BuildRedactionRequest(ctx, document.Id)
 
 			if err != nil {
 				return err
 			}
 
-			// ...as the input to redaction
-			redactRequest := (*request.RedactedPdfRequest)(extractionResult)
 			redacted, err = f.docRedactor.Redact(ctx, document.Id, *redactRequest)
 
 			return err
@@ -52,3 +55,23 @@ func (f *FileRedactor) Redact(ctx context.Context, fileContents io.Reader,
 
 	return redacted, err
 }
+
+// BuildRedactionRequest creates a document from the fileContents, then returns
+// the request the provided RedactionRequestBuilder builds for it, without
+// redacting the document.
+func (f *FileRedactor) BuildRedactionRequest(ctx context.Context, fileContents io.Reader,
+	builder RedactionRequestBuilder) (*request.RedactedPdfRequest, error) {
+	var (
+		redactRequest *request.RedactedPdfRequest
+		err           error
+	)
+
+	err = CreateDocumentFor(fileContents, f.docCreator, f.docDeleter,
+		func(document Document) error {
+			redactRequest, err = builder.BuildRedactionRequest(ctx, document.Id)
+
+			return err
+		})
+
+	return redactRequest, err
+}
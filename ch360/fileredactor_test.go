@@ -142,3 +142,44 @@ func (suite *FileRedactorSuite) TestFileRedactor_Redact_Deletes_Document_If_Reda
 
 	suite.documentDeleter.AssertCalled(suite.T(), "Delete", mock.Anything, suite.documentId)
 }
+
+func (suite *FileRedactorSuite) TestFileRedactor_RedactWith_Redacts_With_Built_Request() {
+	redactRequest := &request.RedactedPdfRequest{ApplyMarks: true}
+	builder := new(mocks.RedactionRequestBuilder)
+	builder.On("BuildRedactionRequest", mock.Anything, suite.documentId).Return(redactRequest, nil)
+
+	_, err := suite.sut.RedactWith(suite.ctx, suite.testFileContentBuf, builder)
+
+	assert.Nil(suite.T(), err)
+	suite.documentRedactor.AssertCalled(suite.T(), "Redact", mock.Anything, suite.documentId,
+		*redactRequest)
+	suite.documentExtractor.AssertNotCalled(suite.T(), "ExtractForRedaction",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *FileRedactorSuite) TestFileRedactor_RedactWith_Deletes_Document_If_Building_Request_Fails() {
+	expectedErr := errors.New("simulated error")
+	builder := new(mocks.RedactionRequestBuilder)
+	builder.On("BuildRedactionRequest", mock.Anything, mock.Anything).Return(nil, expectedErr)
+
+	_, err := suite.sut.RedactWith(suite.ctx, suite.testFileContentBuf, builder)
+
+	assert.Equal(suite.T(), expectedErr, err)
+	suite.documentRedactor.AssertNotCalled(suite.T(), "Redact", mock.Anything, mock.Anything,
+		mock.Anything)
+	suite.documentDeleter.AssertCalled(suite.T(), "Delete", mock.Anything, suite.documentId)
+}
+
+func (suite *FileRedactorSuite) TestFileRedactor_BuildRedactionRequest_Returns_Request_Without_Redacting() {
+	expected := &request.RedactedPdfRequest{ApplyMarks: true}
+	builder := new(mocks.RedactionRequestBuilder)
+	builder.On("BuildRedactionRequest", mock.Anything, suite.documentId).Return(expected, nil)
+
+	actual, err := suite.sut.BuildRedactionRequest(suite.ctx, suite.testFileContentBuf, builder)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expected, actual)
+	suite.documentRedactor.AssertNotCalled(suite.T(), "Redact", mock.Anything, mock.Anything,
+		mock.Anything)
+	suite.documentDeleter.AssertCalled(suite.T(), "Delete", mock.Anything, suite.documentId)
+}
@@ -6,19 +6,40 @@ import (
 	"fmt"
 	"github.com/waives/surf/net"
 	"io"
-	"io/ioutil"
+	"mime"
 	"net/http"
 	"net/http/httputil"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
+// HttpLogFormat is the format in which a LoggingDoer logs HTTP requests and responses.
+type HttpLogFormat string
+
+const (
+	// HttpLogText logs each request and response as it happens, in HTTP's text format.
+	HttpLogText HttpLogFormat = "text"
+	// HttpLogJson logs a json record (on its own line) for each request and its response,
+	// when the response has been read.
+	HttpLogJson HttpLogFormat = "json"
+)
+
+// HttpLogFormats are the names of the formats a LoggingDoer can log in.
+var HttpLogFormats = []string{string(HttpLogText), string(HttpLogJson)}
+
+// maxLoggedBodySize is the size of the largest request / response body which is logged.
+const maxLoggedBodySize = 1 << 20
+
 // LoggingDoer is an HttpDoer decorator that logs all HTTP requests and
 // responses to the specified io.Writer. It indents any json request /
-// response bodies, and redacts any non-json bodies.
+// response bodies, and redacts any non-json (or very large) bodies, which
+// are not read into memory. Secrets, such as Authorization headers and
+// client_secret fields, are never logged.
 type LoggingDoer struct {
 	wrappedSender net.HttpDoer
 	out           io.Writer
+	format        HttpLogFormat
 	mutex         sync.Mutex
 	count         uint32
 }
@@ -27,11 +48,23 @@ func NewLoggingDoer(httpDoer net.HttpDoer, out io.Writer) *LoggingDoer {
 	return &LoggingDoer{
 		wrappedSender: httpDoer,
 		out:           out,
+		format:        HttpLogText,
 	}
 }
 
+// WithFormat configures the format requests and responses are logged in.
+func (d *LoggingDoer) WithFormat(format HttpLogFormat) *LoggingDoer {
+	d.format = format
+	return d
+}
+
 func (d *LoggingDoer) Do(request *http.Request) (*http.Response, error) {
 	requestId := atomic.AddUint32(&d.count, 1)
+
+	if d.format == HttpLogJson {
+		return d.doWithRecord(request, requestId)
+	}
+
 	requestBytes := d.formatRequest(request, requestId)
 
 	d.safeWrite(requestBytes)
@@ -55,25 +88,25 @@ func (d *LoggingDoer) safeWrite(bytes []byte) {
 }
 
 func (d *LoggingDoer) formatRequest(request *http.Request, requestId uint32) []byte {
+	scrubbedRequest := *request
+	scrubbedRequest.Header = net.ScrubHeaders(request.Header)
 
-	requestBytes, err := httputil.DumpRequestOut(request, false)
+	requestBytes, err := httputil.DumpRequestOut(&scrubbedRequest, false)
 
 	if err != nil {
 		return nil
 	}
 
-	bodyBuffer := net.RequestBodyBytes(request)
+	body, wholeBody, complete, err := net.PeekBody(request.Body, maxLoggedBodySize)
+	request.Body = wholeBody
 
-	logBuffer := bytes.NewBufferString(fmt.Sprintf("[%04d -->] ", requestId))
-	logBuffer.Write(requestBytes)
-
-	if json.Valid(bodyBuffer.Bytes()) {
-		_ = json.Indent(logBuffer, bodyBuffer.Bytes(), "", "  ")
-		logBuffer.WriteString("\n")
-	} else {
-		logBuffer.WriteString("<binary request body>\n")
+	if err != nil {
+		return nil
 	}
 
+	logBuffer := bytes.NewBufferString(fmt.Sprintf("[%04d -->] ", requestId))
+	logBuffer.Write(requestBytes)
+	logBuffer.Write(formatBody(request.Header.Get("Content-Type"), body, complete, "request"))
 	logBuffer.WriteString("\n")
 
 	return logBuffer.Bytes()
@@ -81,7 +114,10 @@ func (d *LoggingDoer) formatRequest(request *http.Request, requestId uint32) []b
 
 func (d *LoggingDoer) formatResponse(response *http.Response, requestId uint32) []byte {
 	// get headers
-	responseHeaders, err := httputil.DumpResponse(response, false)
+	scrubbedResponse := *response
+	scrubbedResponse.Header = net.ScrubHeaders(response.Header)
+
+	responseHeaders, err := httputil.DumpResponse(&scrubbedResponse, false)
 
 	if err != nil {
 		return nil
@@ -91,33 +127,130 @@ func (d *LoggingDoer) formatResponse(response *http.Response, requestId uint32)
 
 	logBuffer.Write(responseHeaders)
 
-	bodyBuffer := bytes.Buffer{}
+	// the response body is reset to be read from the start
+	body, wholeBody, complete, err := net.PeekBody(response.Body, maxLoggedBodySize)
+	response.Body = wholeBody
+
+	if err != nil {
+		return nil
+	}
 
-	// reset the response body
-	if response.Body != nil {
-		_, err := bodyBuffer.ReadFrom(response.Body)
+	logBuffer.Write(formatBody(response.Header.Get("Content-Type"), body, complete, "response"))
+	logBuffer.WriteString("\n")
 
-		if err != nil {
-			return nil
+	return logBuffer.Bytes()
+}
+
+// formatBody returns a (scrubbed) json or form body, indenting json, or a placeholder
+// for any other body.
+func formatBody(contentType string, body []byte, complete bool, kind string) []byte {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	if complete && json.Valid(body) {
+		formattedJson := bytes.Buffer{}
+		err := json.Indent(&formattedJson, net.ScrubBody(contentType, body), "", "  ")
+
+		if err == nil {
+			formattedJson.WriteString("\n")
+			return formattedJson.Bytes()
 		}
+	}
 
-		response.Body = ioutil.NopCloser(bytes.NewReader(bodyBuffer.Bytes()))
+	if complete && mediaType == "application/x-www-form-urlencoded" {
+		return append(net.ScrubBody(contentType, body), '\n')
 	}
 
-	// format json
-	if json.Valid(bodyBuffer.Bytes()) {
-		formattedJson := bytes.Buffer{}
-		err = json.Indent(&formattedJson, bodyBuffer.Bytes(), "", "  ")
+	return []byte("<binary " + kind + " body>\n")
+}
+
+// httpExchangeRecord is the json record logged for each request and its response.
+type httpExchangeRecord struct {
+	RequestId     uint32    `json:"request_id"`
+	Time          time.Time `json:"time"`
+	Method        string    `json:"method"`
+	Url           string    `json:"url"`
+	Attempt       int       `json:"attempt,omitempty"`
+	Status        int       `json:"status,omitempty"`
+	DurationMs    float64   `json:"duration_ms"`
+	RequestBytes  int64     `json:"request_bytes"`
+	ResponseBytes int64     `json:"response_bytes"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// doWithRecord sends the request, logging a json record of it when its response body
+// has been closed (so that its size is known). The duration is the time taken to receive
+// the response, not to read its body.
+func (d *LoggingDoer) doWithRecord(request *http.Request, requestId uint32) (*http.Response, error) {
+	record := &httpExchangeRecord{
+		RequestId: requestId,
+		Time:      time.Now().UTC(),
+		Method:    request.Method,
+		Url:       request.URL.String(),
+		Attempt:   net.RetryAttempt(request.Context()),
+	}
+
+	var requestBody *countingBody
+	if request.Body != nil && request.Body != http.NoBody {
+		requestBody = &countingBody{ReadCloser: request.Body}
+		request.Body = requestBody
+	}
 
-		if err != nil {
-			return nil
+	writeRecord := func() {
+		if requestBody != nil {
+			record.RequestBytes = atomic.LoadInt64(&requestBody.count)
 		}
 
-		logBuffer.Write(formattedJson.Bytes())
-		logBuffer.WriteString("\n\n")
-	} else {
-		logBuffer.WriteString("<binary response body>\n\n")
+		recordBytes, err := json.Marshal(record)
+		if err == nil {
+			d.safeWrite(append(recordBytes, '\n'))
+		}
 	}
 
-	return logBuffer.Bytes()
+	response, capturedErr := d.wrappedSender.Do(request)
+	record.DurationMs = float64(time.Since(record.Time)) / float64(time.Millisecond)
+
+	if capturedErr != nil {
+		record.Error = capturedErr.Error()
+	}
+	if response != nil {
+		record.Status = response.StatusCode
+	}
+
+	if response == nil || response.Body == nil {
+		writeRecord()
+		return response, capturedErr
+	}
+
+	responseBody := &countingBody{ReadCloser: response.Body}
+	responseBody.onClose = func() {
+		record.ResponseBytes = atomic.LoadInt64(&responseBody.count)
+		writeRecord()
+	}
+	response.Body = responseBody
+
+	return response, capturedErr
+}
+
+// countingBody counts the bytes read from a request or response body.
+type countingBody struct {
+	io.ReadCloser
+	count   int64
+	onClose func()
+	once    sync.Once
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	atomic.AddInt64(&b.count, int64(n))
+	return n, err
+}
+
+func (b *countingBody) Close() error {
+	err := b.ReadCloser.Close()
+
+	if b.onClose != nil {
+		b.once.Do(b.onClose)
+	}
+
+	return err
 }
@@ -3,10 +3,14 @@ package ch360
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/waives/surf/net/mocks"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -77,3 +81,117 @@ func formatJson(input string) string {
 
 	return dst.String()
 }
+
+func Test_LoggingHttpDoer_Does_Not_Log_Large_Bodies(t *testing.T) {
+	// Arrange
+	httpDoer := mocks.HttpDoer{}
+	logSink := bytes.Buffer{}
+	sut := NewLoggingDoer(&httpDoer, &logSink)
+	largeJson := []byte(`["` + strings.Repeat("x", maxLoggedBodySize) + `"]`)
+	request, _ := http.NewRequest("POST", "https://api.cloudhub360.com", bytes.NewReader(largeJson))
+	response := http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader(largeJson)),
+	}
+	var sentBody []byte
+	httpDoer.
+		On("Do", request).
+		Run(func(args mock.Arguments) {
+			sentBody, _ = ioutil.ReadAll(args.Get(0).(*http.Request).Body)
+		}).
+		Return(&response, nil)
+
+	// Act
+	actualResponse, _ := sut.Do(request)
+
+	// Assert
+	assert.Contains(t, logSink.String(), "<binary request body>")
+	assert.Contains(t, logSink.String(), "<binary response body>")
+	assert.Equal(t, largeJson, sentBody)
+	receivedBody, _ := ioutil.ReadAll(actualResponse.Body)
+	assert.Equal(t, largeJson, receivedBody)
+}
+
+func Test_LoggingHttpDoer_Does_Not_Log_Secrets(t *testing.T) {
+	// Arrange
+	httpDoer := mocks.HttpDoer{}
+	logSink := bytes.Buffer{}
+	sut := NewLoggingDoer(&httpDoer, &logSink)
+	request, _ := http.NewRequest("POST", "https://api.cloudhub360.com/oauth/token",
+		strings.NewReader("client_id=id&client_secret=very-secret"))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Authorization", "Bearer secret-token")
+	response := http.Response{
+		StatusCode: 200,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(strings.NewReader(`{"access_token":"secret-token"}`)),
+	}
+	httpDoer.On("Do", request).Return(&response, nil)
+
+	// Act
+	actualResponse, _ := sut.Do(request)
+
+	// Assert
+	assert.NotContains(t, logSink.String(), "very-secret")
+	assert.NotContains(t, logSink.String(), "secret-token")
+	assert.Contains(t, logSink.String(), "client_id=id&client_secret=REDACTED")
+	assert.Equal(t, "Bearer secret-token", request.Header.Get("Authorization"))
+	receivedBody, _ := ioutil.ReadAll(actualResponse.Body)
+	assert.Equal(t, `{"access_token":"secret-token"}`, string(receivedBody))
+}
+
+func Test_LoggingHttpDoer_Logs_Json_Record_When_Response_Is_Closed(t *testing.T) {
+	// Arrange
+	httpDoer := mocks.HttpDoer{}
+	logSink := bytes.Buffer{}
+	sut := NewLoggingDoer(&httpDoer, &logSink).WithFormat(HttpLogJson)
+	request, _ := http.NewRequest("POST", "https://api.cloudhub360.com/documents",
+		strings.NewReader("document contents"))
+	request.Header.Set("Authorization", "Bearer secret-token")
+	response := http.Response{
+		StatusCode: 201,
+		Body:       ioutil.NopCloser(strings.NewReader(`{"id":"documentId"}`)),
+	}
+	httpDoer.
+		On("Do", request).
+		Run(func(args mock.Arguments) {
+			_, _ = ioutil.ReadAll(args.Get(0).(*http.Request).Body)
+		}).
+		Return(&response, nil)
+
+	// Act
+	actualResponse, _ := sut.Do(request)
+	assert.Empty(t, logSink.String())
+	_, _ = ioutil.ReadAll(actualResponse.Body)
+	_ = actualResponse.Body.Close()
+
+	// Assert
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(logSink.Bytes(), &record))
+	assert.Equal(t, 1.0, record["request_id"])
+	assert.Equal(t, "POST", record["method"])
+	assert.Equal(t, "https://api.cloudhub360.com/documents", record["url"])
+	assert.Equal(t, 201.0, record["status"])
+	assert.Equal(t, float64(len("document contents")), record["request_bytes"])
+	assert.Equal(t, float64(len(`{"id":"documentId"}`)), record["response_bytes"])
+	assert.Contains(t, record, "duration_ms")
+	assert.NotContains(t, logSink.String(), "secret-token")
+}
+
+func Test_LoggingHttpDoer_Logs_Json_Record_For_Error(t *testing.T) {
+	// Arrange
+	httpDoer := mocks.HttpDoer{}
+	logSink := bytes.Buffer{}
+	sut := NewLoggingDoer(&httpDoer, &logSink).WithFormat(HttpLogJson)
+	request, _ := http.NewRequest("GET", "https://api.cloudhub360.com/documents", nil)
+	httpDoer.On("Do", request).Return(nil, errors.New("connection reset"))
+
+	// Act
+	_, _ = sut.Do(request)
+
+	// Assert
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(logSink.Bytes(), &record))
+	assert.Equal(t, "connection reset", record["error"])
+	assert.NotContains(t, record, "status")
+}
@@ -20,21 +20,27 @@ func NewModulesClient(baseUrl string, requestSender net.HttpDoer) *ModulesClient
 }
 
 type Module struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Summary     string            `json:"summary"`
+	Description string            `json:"description"`
+	Fields      []ModuleField     `json:"fields"`
+	Parameters  []ModuleParameter `json:"parameters"`
+}
+
+// ModuleField is a field output by a module.
+type ModuleField struct {
+	Name        string      `json:"name"`
+	Description interface{} `json:"description"`
+}
+
+// ModuleParameter is an argument accepted by a module.
+type ModuleParameter struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
-	Summary     string `json:"summary"`
+	Type        string `json:"type"`
 	Description string `json:"description"`
-	Fields      []struct {
-		Name        string      `json:"name"`
-		Description interface{} `json:"description"`
-	} `json:"fields"`
-	Parameters []struct {
-		ID          string `json:"id"`
-		Name        string `json:"name"`
-		Type        string `json:"type"`
-		Description string `json:"description"`
-		Required    bool   `json:"required"`
-	} `json:"parameters"`
+	Required    bool   `json:"required"`
 }
 
 type ModuleList []Module
@@ -73,3 +79,33 @@ func (l ModuleList) Find(id string) *Module {
 
 	return nil
 }
+
+// Search performs a case-insensitive search for the provided text in
+// the ID, name, summary and field names of each Module, and returns the
+// Modules which contain it.
+func (l ModuleList) Search(text string) ModuleList {
+	text = strings.ToLower(text)
+	results := ModuleList{}
+
+	for _, module := range l {
+		if module.contains(text) {
+			results = append(results, module)
+		}
+	}
+
+	return results
+}
+
+func (module Module) contains(lowerText string) bool {
+	candidates := []string{module.ID, module.Name, module.Summary}
+	for _, field := range module.Fields {
+		candidates = append(candidates, field.Name)
+	}
+
+	for _, candidate := range candidates {
+		if strings.Contains(strings.ToLower(candidate), lowerText) {
+			return true
+		}
+	}
+	return false
+}
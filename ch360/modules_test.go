@@ -102,6 +102,37 @@ func (suite *ModulesClientSuite) Test_ModuleList_Find() {
 	}
 }
 
+func (suite *ModulesClientSuite) Test_ModuleList_Search() {
+	modules := suite.aListOfModules()
+	fixtures := []struct {
+		text     string
+		expected ch360.ModuleList
+	}{
+		{
+			// name, case insensitive
+			text:     "reference NUMBER",
+			expected: ch360.ModuleList{modules[0]},
+		}, {
+			// summary
+			text:     "currency symbols",
+			expected: ch360.ModuleList{modules[1]},
+		}, {
+			// id
+			text:     "waives.",
+			expected: modules,
+		}, {
+			text:     "not-present",
+			expected: ch360.ModuleList{},
+		},
+	}
+
+	for _, fixture := range fixtures {
+		result := modules.Search(fixture.text)
+
+		assert.Equal(suite.T(), fixture.expected, result)
+	}
+}
+
 func (suite *ModulesClientSuite) aListOfModules() ch360.ModuleList {
 	var modulesResponse struct {
 		Modules ch360.ModuleList
@@ -0,0 +1,95 @@
+package ch360
+
+import (
+	"github.com/waives/surf/net"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// DefaultOperation is the name used to configure the settings of any request which
+// is not one of the named Operations.
+const DefaultOperation = "default"
+
+type operation struct {
+	method string
+	path   string
+}
+
+// operations are the kinds of API request whose settings (such as their timeouts) can
+// be configured by name.
+var operations = map[string]operation{
+	"upload":   {"POST", "/documents"},
+	"read":     {"", "/documents/*/reads"},
+	"classify": {"POST", "/documents/*/classify/*"},
+	"extract":  {"POST", "/documents/*/extract/*"},
+	"redact":   {"POST", "/documents/*/redact"},
+}
+
+// OtherOperation is the name of the operation performed by requests which are not one
+// of the named Operations, when they are recorded.
+const OtherOperation = "other"
+
+// OperationName returns the name of the operation the request performs, or
+// OtherOperation if it is not one of the named Operations.
+func OperationName(request *http.Request) string {
+	for _, name := range Operations() {
+		op := operations[name]
+		rule := net.TimeoutRule{Method: op.method, Path: op.path}
+
+		if rule.Matches(request) {
+			return name
+		}
+	}
+
+	return OtherOperation
+}
+
+// Operations returns the names of the operations whose settings can be configured,
+// in alphabetical order.
+func Operations() []string {
+	var names []string
+	for name := range operations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// DefaultTimeouts returns the default time limits on requests: reads, which may take a
+// while to perform OCR, have longer to complete than other requests.
+func DefaultTimeouts() net.Timeouts {
+	timeouts := net.Timeouts{
+		Default: 2 * time.Minute,
+	}
+
+	timeouts, _ = WithTimeout(timeouts, "read", 10*time.Minute)
+	return timeouts
+}
+
+// WithTimeout returns the timeouts with the timeout for the named operation (or the
+// DefaultOperation) replaced. It returns false if there is no such operation.
+func WithTimeout(timeouts net.Timeouts, operationName string, timeout time.Duration) (net.Timeouts, bool) {
+	if operationName == DefaultOperation {
+		timeouts.Default = timeout
+		return timeouts, true
+	}
+
+	op, ok := operations[operationName]
+	if !ok {
+		return timeouts, false
+	}
+
+	rules := []net.TimeoutRule{
+		{Method: op.method, Path: op.path, Timeout: timeout},
+	}
+	for _, rule := range timeouts.Rules {
+		if rule.Method != op.method || rule.Path != op.path {
+			rules = append(rules, rule)
+		}
+	}
+	timeouts.Rules = rules
+
+	return timeouts, true
+}
@@ -0,0 +1,64 @@
+package ch360_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDefaultTimeouts_Give_Reads_Longer(t *testing.T) {
+	timeouts := ch360.DefaultTimeouts()
+
+	read, _ := http.NewRequest("PUT", "https://api.cloudhub360.com/documents/id/reads", nil)
+	upload, _ := http.NewRequest("POST", "https://api.cloudhub360.com/documents", nil)
+
+	assert.Equal(t, 10*time.Minute, timeouts.For(read))
+	assert.Equal(t, 2*time.Minute, timeouts.For(upload))
+}
+
+func TestWithTimeout_Replaces_Timeout_Of_Operation(t *testing.T) {
+	timeouts, ok := ch360.WithTimeout(ch360.DefaultTimeouts(), "read", time.Hour)
+	assert.True(t, ok)
+	timeouts, ok = ch360.WithTimeout(timeouts, ch360.DefaultOperation, time.Second)
+	assert.True(t, ok)
+
+	assert.Equal(t, net.Timeouts{
+		Default: time.Second,
+		Rules: []net.TimeoutRule{
+			{Path: "/documents/*/reads", Timeout: time.Hour},
+		},
+	}, timeouts)
+}
+
+func TestWithTimeout_Returns_False_For_Unknown_Operation(t *testing.T) {
+	timeouts, ok := ch360.WithTimeout(ch360.DefaultTimeouts(), "unknown", time.Hour)
+
+	assert.False(t, ok)
+	assert.Equal(t, ch360.DefaultTimeouts(), timeouts)
+}
+
+func TestOperationName_Names_Operation_Request_Performs(t *testing.T) {
+	fixtures := []struct {
+		method       string
+		path         string
+		expectedName string
+	}{
+		{"POST", "/documents", "upload"},
+		{"GET", "/documents", ch360.OtherOperation},
+		{"PUT", "/documents/id/reads", "read"},
+		{"GET", "/documents/id/reads", "read"},
+		{"POST", "/documents/id/classify/classifier", "classify"},
+		{"POST", "/documents/id/extract/extractor", "extract"},
+		{"POST", "/documents/id/redact", "redact"},
+		{"POST", "/oauth/token", ch360.OtherOperation},
+	}
+
+	for _, fixture := range fixtures {
+		request, _ := http.NewRequest(fixture.method, "https://api.cloudhub360.com"+fixture.path, nil)
+
+		assert.Equal(t, fixture.expectedName, ch360.OperationName(request), fixture.path)
+	}
+}
@@ -0,0 +1,361 @@
+package ch360
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/waives/surf/ch360/request"
+	"github.com/waives/surf/ch360/results"
+	"github.com/waives/surf/ch360/wvdoc"
+	"math"
+	"regexp"
+	"strings"
+)
+
+//go:generate mockery -name "RedactionRequestBuilder"
+
+// RedactionRequestBuilder builds the request used to redact a document which
+// has already been created in waives.
+type RedactionRequestBuilder interface {
+	BuildRedactionRequest(ctx context.Context, documentId string) (*request.RedactedPdfRequest, error)
+}
+
+// ExtractorRedaction redacts the fields found in a document by an extractor.
+type ExtractorRedaction struct {
+	extractor     DocumentExtractor
+	extractorName string
+	fields        FieldSelection
+	recordFields  bool
+}
+
+// NewExtractorRedaction constructs a new ExtractorRedaction.
+func NewExtractorRedaction(extractor DocumentExtractor, extractorName string) *ExtractorRedaction {
+	return &ExtractorRedaction{
+		extractor:     extractor,
+		extractorName: extractorName,
+	}
+}
+
+// BuildRedactionRequest performs extraction on the document, and returns the
+// results of the extraction as the redaction request.
+func (r *ExtractorRedaction) BuildRedactionRequest(ctx context.Context,
+	documentId string) (*request.RedactedPdfRequest, error) {
+	if !r.fields.IsEmpty() {
+		return r.buildSelectedFieldsRequest(ctx, documentId)
+	}
+
+	extractionResult, err := r.extractor.ExtractForRedaction(ctx, documentId, r.extractorName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	redactRequest := (*request.RedactedPdfRequest)(extractionResult)
+
+	if r.recordFields {
+		err = r.recordMarkFields(ctx, documentId, redactRequest.Marks)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return redactRequest, nil
+}
+
+// recordMarkFields records the field each of the marks returned by ExtractForRedaction
+// was made for, by finding the field result with the same area in the full extraction
+// results. The marks themselves are unchanged; any which can't be matched are left
+// without a field.
+func (r *ExtractorRedaction) recordMarkFields(ctx context.Context, documentId string,
+	marks []request.RedactionMark) error {
+	extractionResult, err := r.extractor.Extract(ctx, documentId, r.extractorName)
+
+	if err != nil {
+		return err
+	}
+
+	for i := range marks {
+		marks[i].Field = fieldWithArea(extractionResult, marks[i].Area)
+	}
+
+	return nil
+}
+
+// fieldWithArea returns the name of the first field with a result (or alternative
+// result) covering the area, or the empty string if there is none.
+func fieldWithArea(extractionResult *results.ExtractionResult, area request.RedactionArea) string {
+	for _, fieldResult := range extractionResult.FieldResults {
+		innerResults := append([]*results.InnerResult{fieldResult.Result},
+			fieldResult.AlternativeResults...)
+
+		for _, innerResult := range innerResults {
+			for _, resultArea := range redactionAreasOf(innerResult) {
+				if sameArea(area, resultArea) {
+					return fieldResult.FieldName
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// sameArea compares areas allowing for rounding, as the two forms of extraction
+// result aren't guaranteed to represent coordinates identically.
+func sameArea(a, b request.RedactionArea) bool {
+	const tolerance = 1e-4
+
+	return a.PageNumber == b.PageNumber &&
+		math.Abs(float64(a.Top-b.Top)) < tolerance &&
+		math.Abs(float64(a.Left-b.Left)) < tolerance &&
+		math.Abs(float64(a.Bottom-b.Bottom)) < tolerance &&
+		math.Abs(float64(a.Right-b.Right)) < tolerance
+}
+
+// redactionAreasOf returns the areas of an extraction result, if any.
+func redactionAreasOf(result *results.InnerResult) []request.RedactionArea {
+	if result == nil {
+		return nil
+	}
+
+	areas := make([]request.RedactionArea, len(result.Areas))
+	for i, area := range result.Areas {
+		areas[i] = request.RedactionArea{
+			Top:        float32(area.Top),
+			Left:       float32(area.Left),
+			Bottom:     float32(area.Bottom),
+			Right:      float32(area.Right),
+			PageNumber: float32(area.PageNumber),
+		}
+	}
+
+	return areas
+}
+
+// buildSelectedFieldsRequest builds a redaction request from the full extraction
+// results, as the marks returned by ExtractForRedaction don't identify the field
+// they belong to. Each selected field with a result is marked (recording the field
+// the mark was made for), and bookmarked with the name of the field.
+func (r *ExtractorRedaction) buildSelectedFieldsRequest(ctx context.Context,
+	documentId string) (*request.RedactedPdfRequest, error) {
+	extractionResult, err := r.extractor.Extract(ctx, documentId, r.extractorName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.checkSelectedFieldsExist(extractionResult)
+	if err != nil {
+		return nil, err
+	}
+
+	redactRequest := newRedactionRequest([]request.RedactionMark{})
+	for _, fieldResult := range extractionResult.FieldResults {
+		// rejected results are still redacted (as they are by ExtractForRedaction), as
+		// they may well be the sensitive text the field was looking for
+		if !r.fields.Selects(fieldResult.FieldName) || fieldResult.Result == nil {
+			continue
+		}
+
+		for i, area := range redactionAreasOf(fieldResult.Result) {
+			redactRequest.Marks = append(redactRequest.Marks, request.RedactionMark{
+				Area:  area,
+				Field: fieldResult.FieldName,
+			})
+
+			if i == 0 {
+				redactRequest.Bookmarks = append(redactRequest.Bookmarks, request.RedactionBookmark{
+					Text:       fieldResult.FieldName,
+					PageNumber: int(area.PageNumber),
+				})
+			}
+		}
+	}
+
+	return redactRequest, nil
+}
+
+func (r *ExtractorRedaction) checkSelectedFieldsExist(extractionResult *results.ExtractionResult) error {
+	fieldNames := make([]string, len(extractionResult.FieldResults))
+	for i, fieldResult := range extractionResult.FieldResults {
+		fieldNames[i] = fieldResult.FieldName
+	}
+
+	for _, fieldName := range append(r.fields.Include, r.fields.Exclude...) {
+		if !containsFold(fieldNames, fieldName) {
+			return errors.Errorf("The extractor '%s' has no field '%s'", r.extractorName, fieldName)
+		}
+	}
+
+	return nil
+}
+
+// AreaRedaction redacts the same, explicitly specified, areas of every document.
+type AreaRedaction []request.RedactionArea
+
+// BuildRedactionRequest returns a request redacting each of the areas.
+func (r AreaRedaction) BuildRedactionRequest(ctx context.Context,
+	documentId string) (*request.RedactedPdfRequest, error) {
+	marks := make([]request.RedactionMark, len(r))
+	for i, area := range r {
+		marks[i] = request.RedactionMark{Area: area}
+	}
+
+	return newRedactionRequest(marks), nil
+}
+
+// PatternRedaction redacts any text in a document which matches one of a set
+// of regular expressions.
+type PatternRedaction struct {
+	reader   DocumentReader
+	patterns []*regexp.Regexp
+}
+
+// NewPatternRedaction constructs a new PatternRedaction.
+func NewPatternRedaction(reader DocumentReader, patterns []*regexp.Regexp) *PatternRedaction {
+	return &PatternRedaction{
+		reader:   reader,
+		patterns: patterns,
+	}
+}
+
+// BuildRedactionRequest reads the document, and returns a request redacting
+// every word which makes up (or is part of) a match for one of the patterns.
+func (r *PatternRedaction) BuildRedactionRequest(ctx context.Context,
+	documentId string) (*request.RedactedPdfRequest, error) {
+	err := r.reader.Read(ctx, documentId)
+	if err != nil {
+		return nil, err
+	}
+
+	readResult, err := r.reader.ReadResult(ctx, documentId, ReadWvdoc)
+	if err != nil {
+		return nil, err
+	}
+	defer readResult.Close()
+
+	document, err := wvdoc.Read(readResult)
+	if err != nil {
+		return nil, err
+	}
+
+	marks := []request.RedactionMark{}
+	for _, pattern := range r.patterns {
+		for _, match := range document.FindAll(pattern) {
+			for _, word := range match.Words {
+				marks = append(marks, request.RedactionMark{
+					Area: request.RedactionArea{
+						Top:        float32(word.Top),
+						Left:       float32(word.Left),
+						Bottom:     float32(word.Bottom),
+						Right:      float32(word.Right),
+						PageNumber: float32(match.PageNumber),
+					},
+					Pattern: pattern.String(),
+				})
+			}
+		}
+	}
+
+	return newRedactionRequest(marks), nil
+}
+
+func newRedactionRequest(marks []request.RedactionMark) *request.RedactedPdfRequest {
+	return &request.RedactedPdfRequest{
+		Marks:      marks,
+		ApplyMarks: true,
+		Bookmarks:  []request.RedactionBookmark{},
+	}
+}
+
+// FieldSelection chooses which of the fields of an extractor are redacted.
+// Field names are compared case-insensitively.
+type FieldSelection struct {
+	// Include lists the only fields to redact. If empty, all fields are redacted.
+	Include []string
+	// Exclude lists fields which are not redacted.
+	Exclude []string
+}
+
+// IsEmpty returns true if the FieldSelection selects every field.
+func (s FieldSelection) IsEmpty() bool {
+	return len(s.Include) == 0 && len(s.Exclude) == 0
+}
+
+// Selects returns true if the named field should be redacted.
+func (s FieldSelection) Selects(fieldName string) bool {
+	if len(s.Include) > 0 && !containsFold(s.Include, fieldName) {
+		return false
+	}
+	return !containsFold(s.Exclude, fieldName)
+}
+
+// WithFields configures the ExtractorRedaction to only redact the fields
+// chosen by the provided FieldSelection.
+func (r *ExtractorRedaction) WithFields(fields FieldSelection) *ExtractorRedaction {
+	r.fields = fields
+	return r
+}
+
+// WithFieldRecording configures the ExtractorRedaction to record the field each
+// mark was made for, even when all fields are redacted. This doesn't change which
+// areas are redacted.
+func (r *ExtractorRedaction) WithFieldRecording() *ExtractorRedaction {
+	r.recordFields = true
+	return r
+}
+
+// RedactionOptions adjusts the requests built by a RedactionRequestBuilder.
+type RedactionOptions struct {
+	// Preview requests that areas are marked, but not redacted, so that the
+	// redaction can be reviewed.
+	Preview bool
+	// OmitBookmarks requests that the redacted PDF has no bookmarks.
+	OmitBookmarks bool
+}
+
+// Apply returns a RedactionRequestBuilder which adjusts the requests built by
+// builder according to the RedactionOptions.
+func (options RedactionOptions) Apply(builder RedactionRequestBuilder) RedactionRequestBuilder {
+	if options == (RedactionOptions{}) {
+		return builder
+	}
+
+	return &optionsRedaction{
+		builder: builder,
+		options: options,
+	}
+}
+
+type optionsRedaction struct {
+	builder RedactionRequestBuilder
+	options RedactionOptions
+}
+
+func (r *optionsRedaction) BuildRedactionRequest(ctx context.Context,
+	documentId string) (*request.RedactedPdfRequest, error) {
+	redactRequest, err := r.builder.BuildRedactionRequest(ctx, documentId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if r.options.Preview {
+		redactRequest.ApplyMarks = false
+	}
+
+	if r.options.OmitBookmarks {
+		redactRequest.Bookmarks = []request.RedactionBookmark{}
+	}
+
+	return redactRequest, nil
+}
+
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,272 @@
+package ch360_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/ch360/mocks"
+	"github.com/waives/surf/ch360/request"
+	"github.com/waives/surf/ch360/results"
+	"github.com/waives/surf/ch360/wvdoc"
+	"io/ioutil"
+	"regexp"
+	"testing"
+)
+
+func TestExtractorRedaction_Returns_Extraction_Result_As_Request(t *testing.T) {
+	extractor := new(mocks.DocumentExtractor)
+	extractionResult := &results.ExtractForRedactionResult{ApplyMarks: true}
+	extractor.On("ExtractForRedaction", mock.Anything, "document-id", "extractor").
+		Return(extractionResult, nil)
+
+	redactRequest, err := ch360.NewExtractorRedaction(extractor, "extractor").
+		BuildRedactionRequest(context.Background(), "document-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, (*request.RedactedPdfRequest)(extractionResult), redactRequest)
+}
+
+func TestAreaRedaction_Returns_A_Mark_For_Each_Area(t *testing.T) {
+	areas := []request.RedactionArea{
+		{Top: 1, Left: 2, Bottom: 3, Right: 4, PageNumber: 1},
+		{Top: 5, Left: 6, Bottom: 7, Right: 8, PageNumber: 2},
+	}
+
+	redactRequest, err := ch360.AreaRedaction(areas).
+		BuildRedactionRequest(context.Background(), "document-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &request.RedactedPdfRequest{
+		Marks:      []request.RedactionMark{{Area: areas[0]}, {Area: areas[1]}},
+		ApplyMarks: true,
+		Bookmarks:  []request.RedactionBookmark{},
+	}, redactRequest)
+}
+
+const redactionWvdocDocument = `{
+  "pages": [{
+    "page_number": 1,
+    "lines": [
+      {"words": [
+        {"text": "SSN", "left": 10, "top": 10, "right": 40, "bottom": 20},
+        {"text": "123-45-6789", "left": 45, "top": 10, "right": 120, "bottom": 20}
+      ]}
+    ]
+  }]
+}`
+
+func aWvdocReader(t *testing.T) *mocks.DocumentReader {
+	buf := &bytes.Buffer{}
+	archive := zip.NewWriter(buf)
+	entry, err := archive.Create(wvdoc.DocumentEntryName)
+	require.NoError(t, err)
+	_, err = entry.Write([]byte(redactionWvdocDocument))
+	require.NoError(t, err)
+	require.NoError(t, archive.Close())
+
+	reader := new(mocks.DocumentReader)
+	reader.On("Read", mock.Anything, mock.Anything).Return(nil)
+	reader.On("ReadResult", mock.Anything, mock.Anything, ch360.ReadWvdoc).
+		Return(ioutil.NopCloser(buf), nil)
+	return reader
+}
+
+func TestPatternRedaction_Returns_A_Mark_For_Each_Matching_Word(t *testing.T) {
+	reader := aWvdocReader(t)
+
+	redactRequest, err := ch360.NewPatternRedaction(reader,
+		[]*regexp.Regexp{regexp.MustCompile(`\d{3}-\d{2}-\d{4}`)}).
+		BuildRedactionRequest(context.Background(), "document-id")
+
+	assert.NoError(t, err)
+	reader.AssertCalled(t, "Read", mock.Anything, "document-id")
+	assert.Equal(t, &request.RedactedPdfRequest{
+		Marks: []request.RedactionMark{{
+			Area:    request.RedactionArea{Top: 10, Left: 45, Bottom: 20, Right: 120, PageNumber: 1},
+			Pattern: `\d{3}-\d{2}-\d{4}`,
+		}},
+		ApplyMarks: true,
+		Bookmarks:  []request.RedactionBookmark{},
+	}, redactRequest)
+}
+
+func TestPatternRedaction_Returns_No_Marks_If_Nothing_Matches(t *testing.T) {
+	redactRequest, err := ch360.NewPatternRedaction(aWvdocReader(t),
+		[]*regexp.Regexp{regexp.MustCompile(`@`)}).
+		BuildRedactionRequest(context.Background(), "document-id")
+
+	assert.NoError(t, err)
+	assert.Empty(t, redactRequest.Marks)
+}
+
+func TestPatternRedaction_Returns_Error_If_Read_Fails(t *testing.T) {
+	expectedErr := errors.New("simulated error")
+	reader := new(mocks.DocumentReader)
+	reader.On("Read", mock.Anything, mock.Anything).Return(expectedErr)
+
+	_, err := ch360.NewPatternRedaction(reader, nil).
+		BuildRedactionRequest(context.Background(), "document-id")
+
+	assert.Equal(t, expectedErr, err)
+}
+
+const redactionExtractionResult = `{
+  "field_results": [
+    {"field_name": "Name", "result": {"text": "Jo", "areas": [
+      {"top": 1, "left": 2, "bottom": 3, "right": 4, "page_number": 1},
+      {"top": 5, "left": 6, "bottom": 7, "right": 8, "page_number": 2}
+    ]}},
+    {"field_name": "Amount", "result": {"text": "10", "areas": [
+      {"top": 9, "left": 10, "bottom": 11, "right": 12, "page_number": 1}
+    ]}},
+    {"field_name": "Date", "result": null},
+    {"field_name": "Reference", "result": {"text": "X", "rejected": true, "areas": [
+      {"top": 13, "left": 14, "bottom": 15, "right": 16, "page_number": 1}
+    ]}}
+  ]
+}`
+
+func anExtractorForRedaction(t *testing.T) *mocks.DocumentExtractor {
+	extractionResult := &results.ExtractionResult{}
+	require.NoError(t, json.Unmarshal([]byte(redactionExtractionResult), extractionResult))
+
+	extractor := new(mocks.DocumentExtractor)
+	extractor.On("Extract", mock.Anything, "document-id", "extractor").Return(extractionResult, nil)
+	return extractor
+}
+
+func TestFieldSelection_Selects(t *testing.T) {
+	fixtures := []struct {
+		selection ch360.FieldSelection
+		field     string
+		expected  bool
+	}{
+		{ch360.FieldSelection{}, "Name", true},
+		{ch360.FieldSelection{Include: []string{"name"}}, "Name", true},
+		{ch360.FieldSelection{Include: []string{"name"}}, "Amount", false},
+		{ch360.FieldSelection{Exclude: []string{"NAME"}}, "Name", false},
+		{ch360.FieldSelection{Exclude: []string{"Name"}}, "Amount", true},
+		{ch360.FieldSelection{Include: []string{"Name"}, Exclude: []string{"Name"}}, "Name", false},
+	}
+
+	for _, fixture := range fixtures {
+		assert.Equal(t, fixture.expected, fixture.selection.Selects(fixture.field),
+			"%+v selects %s", fixture.selection, fixture.field)
+	}
+}
+
+func TestExtractorRedaction_WithFields_Marks_Selected_Fields(t *testing.T) {
+	extractor := anExtractorForRedaction(t)
+
+	redactRequest, err := ch360.NewExtractorRedaction(extractor, "extractor").
+		WithFields(ch360.FieldSelection{Exclude: []string{"amount"}}).
+		BuildRedactionRequest(context.Background(), "document-id")
+
+	assert.NoError(t, err)
+	extractor.AssertNotCalled(t, "ExtractForRedaction", mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, &request.RedactedPdfRequest{
+		Marks: []request.RedactionMark{
+			{Area: request.RedactionArea{Top: 1, Left: 2, Bottom: 3, Right: 4, PageNumber: 1}, Field: "Name"},
+			{Area: request.RedactionArea{Top: 5, Left: 6, Bottom: 7, Right: 8, PageNumber: 2}, Field: "Name"},
+			{Area: request.RedactionArea{Top: 13, Left: 14, Bottom: 15, Right: 16, PageNumber: 1}, Field: "Reference"},
+		},
+		ApplyMarks: true,
+		Bookmarks: []request.RedactionBookmark{
+			{Text: "Name", PageNumber: 1},
+			{Text: "Reference", PageNumber: 1},
+		},
+	}, redactRequest)
+}
+
+func TestExtractorRedaction_WithFields_Marks_Rejected_Results(t *testing.T) {
+	extractor := anExtractorForRedaction(t)
+
+	redactRequest, err := ch360.NewExtractorRedaction(extractor, "extractor").
+		WithFields(ch360.FieldSelection{Include: []string{"reference"}}).
+		BuildRedactionRequest(context.Background(), "document-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []request.RedactionMark{
+		{Area: request.RedactionArea{Top: 13, Left: 14, Bottom: 15, Right: 16, PageNumber: 1}, Field: "Reference"},
+	}, redactRequest.Marks)
+}
+
+func TestExtractorRedaction_WithFieldRecording_Records_Field_Of_Each_Mark(t *testing.T) {
+	extractor := anExtractorForRedaction(t)
+	extractor.On("ExtractForRedaction", mock.Anything, "document-id", "extractor").
+		Return(&results.ExtractForRedactionResult{
+			Marks: []request.RedactionMark{
+				{Area: request.RedactionArea{Top: 9, Left: 10, Bottom: 11, Right: 12, PageNumber: 1}},
+				{Area: request.RedactionArea{Top: 13, Left: 14, Bottom: 15, Right: 16, PageNumber: 1}},
+				{Area: request.RedactionArea{Top: 17, Left: 18, Bottom: 19, Right: 20, PageNumber: 1}},
+			},
+			ApplyMarks: true,
+			Bookmarks:  []request.RedactionBookmark{{Text: "Amount", PageNumber: 1}},
+		}, nil)
+
+	redactRequest, err := ch360.NewExtractorRedaction(extractor, "extractor").
+		WithFieldRecording().
+		BuildRedactionRequest(context.Background(), "document-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &request.RedactedPdfRequest{
+		Marks: []request.RedactionMark{
+			{Area: request.RedactionArea{Top: 9, Left: 10, Bottom: 11, Right: 12, PageNumber: 1}, Field: "Amount"},
+			{Area: request.RedactionArea{Top: 13, Left: 14, Bottom: 15, Right: 16, PageNumber: 1}, Field: "Reference"},
+			{Area: request.RedactionArea{Top: 17, Left: 18, Bottom: 19, Right: 20, PageNumber: 1}},
+		},
+		ApplyMarks: true,
+		Bookmarks:  []request.RedactionBookmark{{Text: "Amount", PageNumber: 1}},
+	}, redactRequest)
+}
+
+func TestExtractorRedaction_WithFields_Returns_Error_For_Unknown_Field(t *testing.T) {
+	_, err := ch360.NewExtractorRedaction(anExtractorForRedaction(t), "extractor").
+		WithFields(ch360.FieldSelection{Include: []string{"Name", "Address"}}).
+		BuildRedactionRequest(context.Background(), "document-id")
+
+	assert.EqualError(t, err, "The extractor 'extractor' has no field 'Address'")
+}
+
+func TestRedactionOptions_Apply(t *testing.T) {
+	bookmarks := []request.RedactionBookmark{{Text: "Name", PageNumber: 1}}
+	fixtures := []struct {
+		options           ch360.RedactionOptions
+		expectedApply     bool
+		expectedBookmarks []request.RedactionBookmark
+	}{
+		{ch360.RedactionOptions{}, true, bookmarks},
+		{ch360.RedactionOptions{Preview: true}, false, bookmarks},
+		{ch360.RedactionOptions{OmitBookmarks: true}, true, []request.RedactionBookmark{}},
+	}
+
+	for _, fixture := range fixtures {
+		builder := new(mocks.RedactionRequestBuilder)
+		builder.On("BuildRedactionRequest", mock.Anything, "document-id").Return(
+			&request.RedactedPdfRequest{ApplyMarks: true, Bookmarks: bookmarks}, nil)
+
+		redactRequest, err := fixture.options.Apply(builder).
+			BuildRedactionRequest(context.Background(), "document-id")
+
+		assert.NoError(t, err)
+		assert.Equal(t, fixture.expectedApply, redactRequest.ApplyMarks, "%+v", fixture.options)
+		assert.Equal(t, fixture.expectedBookmarks, redactRequest.Bookmarks, "%+v", fixture.options)
+	}
+}
+
+func TestRedactionOptions_Apply_Returns_Errors_From_Builder(t *testing.T) {
+	expectedErr := errors.New("simulated error")
+	builder := new(mocks.RedactionRequestBuilder)
+	builder.On("BuildRedactionRequest", mock.Anything, mock.Anything).Return(nil, expectedErr)
+
+	_, err := ch360.RedactionOptions{Preview: true}.Apply(builder).
+		BuildRedactionRequest(context.Background(), "document-id")
+
+	assert.Equal(t, expectedErr, err)
+}
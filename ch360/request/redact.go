@@ -14,6 +14,10 @@ type RedactionArea struct {
 }
 type RedactionMark struct {
 	Area RedactionArea `json:"area"`
+	// Field and Pattern record why the mark was made (the extractor field or
+	// regular expression it was made for, if known). They are not sent to waives.
+	Field   string `json:"-"`
+	Pattern string `json:"-"`
 }
 type RedactionBookmark struct {
 	Text       string `json:"text"`
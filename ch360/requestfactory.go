@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/waives/surf/net"
 	"io"
+	"io/ioutil"
 	"net/http"
 )
 
@@ -23,6 +24,12 @@ func newRequest(ctx context.Context, method string, url string, body io.Reader)
 		}
 	}
 
+	// stream bodies which can be rewound (such as files) rather than reading them
+	// into memory to retry them; if one cannot be (e.g. a pipe) it is sent as it is
+	if seeker, ok := body.(io.ReadSeeker); ok && request.GetBody == nil {
+		_ = net.SetRewindableBody(request, seeker)
+	}
+
 	request = request.WithContext(ctx)
 
 	return &requestBuilder{
@@ -53,3 +60,17 @@ func (b *requestBuilder) issue(doer net.HttpDoer) (*http.Response, error) {
 
 	return doer.Do(b.request)
 }
+
+// send issues the request, for when nothing is needed from the response but its success.
+// The response body is read and closed, so that the connection can be reused.
+func (b *requestBuilder) send(doer net.HttpDoer) error {
+	response, err := b.issue(doer)
+
+	if err != nil {
+		return err
+	}
+
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+
+	return response.Body.Close()
+}
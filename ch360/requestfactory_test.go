@@ -0,0 +1,91 @@
+package ch360_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/waives/surf/ch360"
+	"io"
+	"net/http"
+	"testing"
+)
+
+// closeCountingBody is a response body which counts the number of times it is closed.
+type closeCountingBody struct {
+	io.Reader
+	closes int
+}
+
+func (b *closeCountingBody) Close() error {
+	b.closes++
+	return nil
+}
+
+// respondingDoer returns a response with the body for every request.
+type respondingDoer struct {
+	body *closeCountingBody
+}
+
+func (d *respondingDoer) Do(request *http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: 200, Body: d.body}, nil
+}
+
+func TestClients_Close_Response_Bodies(t *testing.T) {
+	ctx := context.Background()
+	template := ch360.ExtractorTemplate{Modules: []ch360.ModuleTemplate{{ID: "waives.date"}}}
+	createdDocument := `{"id": "document-id", "_embedded": {"files": [{"file_type": "PDF"}]}}`
+
+	fixtures := []struct {
+		operation string
+		body      string
+		call      func(doer *respondingDoer) error
+	}{
+		{"delete document", "ignored", func(doer *respondingDoer) error {
+			return ch360.NewDocumentsClient(apiUrl, doer).Delete(ctx, "document-id")
+		}},
+		{"create document", createdDocument, func(doer *respondingDoer) error {
+			_, err := ch360.NewDocumentsClient(apiUrl, doer).Create(ctx, bytes.NewReader([]byte{1, 2}))
+			return err
+		}},
+		{"create classifier", "ignored", func(doer *respondingDoer) error {
+			return ch360.NewClassifiersClient(apiUrl, doer).Create(ctx, "classifier")
+		}},
+		{"upload classifier", "ignored", func(doer *respondingDoer) error {
+			return ch360.NewClassifiersClient(apiUrl, doer).Upload(ctx, "classifier", bytes.NewReader(nil))
+		}},
+		{"train classifier", "ignored", func(doer *respondingDoer) error {
+			return ch360.NewClassifiersClient(apiUrl, doer).Train(ctx, "classifier", bytes.NewReader(nil))
+		}},
+		{"delete classifier", "ignored", func(doer *respondingDoer) error {
+			return ch360.NewClassifiersClient(apiUrl, doer).Delete(ctx, "classifier")
+		}},
+		{"get classifiers", `{"classifiers": []}`, func(doer *respondingDoer) error {
+			_, err := ch360.NewClassifiersClient(apiUrl, doer).GetAll(ctx)
+			return err
+		}},
+		{"create extractor", "ignored", func(doer *respondingDoer) error {
+			return ch360.NewExtractorsClient(apiUrl, doer).Create(ctx, "extractor", bytes.NewReader(nil))
+		}},
+		{"create extractor from modules", "ignored", func(doer *respondingDoer) error {
+			return ch360.NewExtractorsClient(apiUrl, doer).CreateFromModules(ctx, "extractor", template)
+		}},
+		{"delete extractor", "ignored", func(doer *respondingDoer) error {
+			return ch360.NewExtractorsClient(apiUrl, doer).Delete(ctx, "extractor")
+		}},
+		{"get extractors", `{"extractors": []}`, func(doer *respondingDoer) error {
+			_, err := ch360.NewExtractorsClient(apiUrl, doer).GetAll(ctx)
+			return err
+		}},
+	}
+
+	for _, fixture := range fixtures {
+		body := &closeCountingBody{Reader: bytes.NewBufferString(fixture.body)}
+
+		err := fixture.call(&respondingDoer{body: body})
+
+		assert.NoError(t, err, fixture.operation)
+		assert.Equal(t, 1, body.closes, "%s closes the response body once", fixture.operation)
+		assert.Equal(t, 0, body.Reader.(*bytes.Buffer).Len(), "%s reads the response body",
+			fixture.operation)
+	}
+}
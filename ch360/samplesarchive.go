@@ -0,0 +1,126 @@
+package ch360
+
+import (
+	"archive/zip"
+	"fmt"
+	"github.com/waives/surf/fs"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SupportedSampleExtensions are the extensions of the file types which can be used
+// to train a classifier.
+var SupportedSampleExtensions = []string{
+	".pdf", ".tif", ".tiff", ".jpg", ".jpeg", ".png", ".bmp", ".gif",
+	".doc", ".docx", ".rtf", ".txt", ".htm", ".html", ".eml", ".msg",
+}
+
+// IsSupportedSample returns true if the file has one of the SupportedSampleExtensions.
+func IsSupportedSample(filename string) bool {
+	extension := strings.ToLower(filepath.Ext(filename))
+
+	for _, supported := range SupportedSampleExtensions {
+		if extension == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// SampleCounts holds the number of samples for each document type.
+type SampleCounts map[string]int
+
+// CountSamples returns the number of samples of each document type.
+func CountSamples(samples []fs.LabelledFile) SampleCounts {
+	counts := SampleCounts{}
+
+	for _, sample := range samples {
+		counts[sample.Label]++
+	}
+
+	return counts
+}
+
+// DocumentTypes returns the document types, in alphabetical order.
+func (c SampleCounts) DocumentTypes() []string {
+	var documentTypes []string
+
+	for documentType := range c {
+		documentTypes = append(documentTypes, documentType)
+	}
+	sort.Strings(documentTypes)
+
+	return documentTypes
+}
+
+// Underrepresented returns the document types which have fewer than the specified
+// proportion of the samples of the largest document type.
+func (c SampleCounts) Underrepresented(proportion float64) []string {
+	largest := 0
+	for _, count := range c {
+		if count > largest {
+			largest = count
+		}
+	}
+
+	var underrepresented []string
+	for _, documentType := range c.DocumentTypes() {
+		if float64(c[documentType]) < proportion*float64(largest) {
+			underrepresented = append(underrepresented, documentType)
+		}
+	}
+
+	return underrepresented
+}
+
+// WriteSamplesArchive writes a zip archive of the samples, in the form expected when
+// training a classifier: a folder for each document type, containing its samples.
+func WriteSamplesArchive(writer io.Writer, samples []fs.LabelledFile) error {
+	zipWriter := zip.NewWriter(writer)
+	usedNames := map[string]bool{}
+
+	for _, sample := range samples {
+		name := uniqueName(usedNames, path.Join(sample.Label, filepath.Base(sample.Path)))
+
+		if err := addToArchive(zipWriter, name, sample.Path); err != nil {
+			return err
+		}
+	}
+
+	return zipWriter.Close()
+}
+
+func addToArchive(zipWriter *zip.Writer, name, filename string) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	entry, err := zipWriter.Create(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(entry, file)
+	return err
+}
+
+// uniqueName returns name, or a numbered variant of it if it has already been used
+// (the samples for a document type may come from several subdirectories).
+func uniqueName(usedNames map[string]bool, name string) string {
+	extension := path.Ext(name)
+	base := strings.TrimSuffix(name, extension)
+
+	uniqueName := name
+	for i := 2; usedNames[strings.ToLower(uniqueName)]; i++ {
+		uniqueName = fmt.Sprintf("%s (%d)%s", base, i, extension)
+	}
+	usedNames[strings.ToLower(uniqueName)] = true
+
+	return uniqueName
+}
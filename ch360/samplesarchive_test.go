@@ -0,0 +1,67 @@
+package ch360_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsSupportedSample(t *testing.T) {
+	assert.True(t, ch360.IsSupportedSample("invoice.pdf"))
+	assert.True(t, ch360.IsSupportedSample("scan.TIFF"))
+	assert.False(t, ch360.IsSupportedSample("notes.xyz"))
+	assert.False(t, ch360.IsSupportedSample("no-extension"))
+}
+
+func TestSampleCounts_Underrepresented_Returns_Document_Types_With_Few_Samples(t *testing.T) {
+	counts := ch360.SampleCounts{"invoice": 10, "receipt": 4, "letter": 6}
+
+	assert.Equal(t, []string{"invoice", "letter", "receipt"}, counts.DocumentTypes())
+	assert.Equal(t, []string{"receipt"}, counts.Underrepresented(0.5))
+}
+
+func TestCountSamples_Counts_Samples_By_Label(t *testing.T) {
+	counts := ch360.CountSamples([]fs.LabelledFile{
+		{Label: "invoice", Path: "a.pdf"},
+		{Label: "invoice", Path: "b.pdf"},
+		{Label: "receipt", Path: "c.pdf"},
+	})
+
+	assert.Equal(t, ch360.SampleCounts{"invoice": 2, "receipt": 1}, counts)
+}
+
+func TestWriteSamplesArchive_Writes_A_Folder_Per_Document_Type(t *testing.T) {
+	dir, err := ioutil.TempDir("", "samples")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"a.pdf", "b.pdf"} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(name), 0600))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "nested"), 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "nested", "a.pdf"), []byte("nested"), 0600))
+
+	archive := &bytes.Buffer{}
+	err = ch360.WriteSamplesArchive(archive, []fs.LabelledFile{
+		{Label: "invoice", Path: filepath.Join(dir, "a.pdf")},
+		{Label: "invoice", Path: filepath.Join(dir, "nested", "a.pdf")},
+		{Label: "receipt", Path: filepath.Join(dir, "b.pdf")},
+	})
+	require.NoError(t, err)
+
+	zipReader, err := zip.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+	require.NoError(t, err)
+
+	var names []string
+	for _, file := range zipReader.File {
+		names = append(names, file.Name)
+	}
+	assert.Equal(t, []string{"invoice/a.pdf", "invoice/a (2).pdf", "receipt/b.pdf"}, names)
+}
@@ -0,0 +1,158 @@
+package ch360
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TemplateError is a problem with a module in an extractor template. It has the same
+// form as the detailed errors returned by waives when an extractor cannot be created.
+type TemplateError struct {
+	ModuleID      string   `json:"module_id" mapstructure:"module_id"`
+	Messages      []string `json:"messages" mapstructure:"messages"`
+	Path          string   `json:"path" mapstructure:"path"`
+	ArgumentName  string   `json:"argument_name" mapstructure:"argument_name"`
+	ArgumentValue string   `json:"argument_value" mapstructure:"argument_value"`
+}
+
+// ValidateExtractorTemplate checks an extractor template against the modules available
+// in waives, returning any problems which would prevent an extractor being created
+// from it.
+func ValidateExtractorTemplate(template ExtractorTemplate, modules ModuleList) []TemplateError {
+	var (
+		templateErrors []TemplateError
+		aliases        = map[string]bool{}
+	)
+
+	for i, moduleTemplate := range template.Modules {
+		path := fmt.Sprintf("modules[%d]", i)
+
+		module := modules.Find(moduleTemplate.ID)
+		if module == nil {
+			templateErrors = append(templateErrors, TemplateError{
+				Messages: []string{fmt.Sprintf("The module %s does not exist.", moduleTemplate.ID)},
+				Path:     path,
+			})
+			continue
+		}
+
+		templateErrors = append(templateErrors, validateArguments(moduleTemplate, *module, path)...)
+
+		for j, fieldAlias := range moduleTemplate.FieldAliases {
+			aliasPath := fmt.Sprintf("%s.field_aliases[%d]", path, j)
+
+			if !module.hasField(fieldAlias.Field) {
+				templateErrors = append(templateErrors, TemplateError{
+					ModuleID: moduleTemplate.ID,
+					Messages: []string{fmt.Sprintf("The alias '%s' refers to the field '%s', "+
+						"which the module does not have.", fieldAlias.Alias, fieldAlias.Field)},
+					Path: aliasPath,
+				})
+			}
+
+			if aliases[strings.ToLower(fieldAlias.Alias)] {
+				templateErrors = append(templateErrors, TemplateError{
+					ModuleID: moduleTemplate.ID,
+					Messages: []string{fmt.Sprintf("The alias '%s' is used more than once.",
+						fieldAlias.Alias)},
+					Path: aliasPath,
+				})
+			}
+			aliases[strings.ToLower(fieldAlias.Alias)] = true
+		}
+	}
+
+	return templateErrors
+}
+
+func validateArguments(moduleTemplate ModuleTemplate, module Module, path string) []TemplateError {
+	var templateErrors []TemplateError
+
+	newError := func(argumentName string, argumentValue interface{}, message string) TemplateError {
+		templateError := TemplateError{
+			ModuleID:     moduleTemplate.ID,
+			Messages:     []string{message},
+			Path:         fmt.Sprintf("%s.arguments.%s", path, argumentName),
+			ArgumentName: argumentName,
+		}
+		if argumentValue != nil {
+			templateError.ArgumentValue = fmt.Sprint(argumentValue)
+		}
+		return templateError
+	}
+
+	parameters := map[string]ModuleParameter{}
+	for _, parameter := range module.Parameters {
+		parameters[parameter.ID] = parameter
+
+		value, found := moduleTemplate.Arguments[parameter.ID]
+		if !found || isEmptyArgument(value) {
+			if parameter.Required {
+				templateErrors = append(templateErrors,
+					newError(parameter.ID, value, "No argument was specified"))
+			}
+			continue
+		}
+
+		if !argumentHasType(value, parameter.Type) {
+			templateErrors = append(templateErrors, newError(parameter.ID, value,
+				fmt.Sprintf("The argument must be of type '%s'", parameter.Type)))
+		}
+	}
+
+	var argumentNames []string
+	for argumentName := range moduleTemplate.Arguments {
+		argumentNames = append(argumentNames, argumentName)
+	}
+	sort.Strings(argumentNames)
+
+	for _, argumentName := range argumentNames {
+		if _, found := parameters[argumentName]; !found {
+			templateErrors = append(templateErrors, newError(argumentName,
+				moduleTemplate.Arguments[argumentName], "The module has no such parameter"))
+		}
+	}
+
+	return templateErrors
+}
+
+func (module Module) hasField(name string) bool {
+	for _, field := range module.Fields {
+		if strings.EqualFold(field.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func isEmptyArgument(value interface{}) bool {
+	return value == nil || value == ""
+}
+
+// argumentHasType checks a (json-decoded) argument against a module parameter type.
+// Types which aren't recognised are not checked.
+func argumentHasType(value interface{}, parameterType string) bool {
+	switch strings.ToLower(parameterType) {
+	case "string", "text", "regex":
+		_, ok := value.(string)
+		return ok
+	case "integer", "int":
+		number, ok := value.(float64)
+		return ok && number == float64(int64(number))
+	case "number", "decimal", "float", "double":
+		_, ok := value.(float64)
+		return ok
+	case "boolean", "bool":
+		_, ok := value.(bool)
+		return ok
+	case "array", "list":
+		_, ok := value.([]interface{})
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	}
+
+	return true
+}
@@ -0,0 +1,121 @@
+package ch360_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/waives/surf/ch360"
+	"testing"
+)
+
+func aModuleList() ch360.ModuleList {
+	return ch360.ModuleList{
+		{
+			ID:     "waives.reference_number",
+			Fields: []ch360.ModuleField{{Name: "Reference Number"}},
+			Parameters: []ch360.ModuleParameter{
+				{ID: "format", Type: "string", Required: true},
+				{ID: "max_length", Type: "integer"},
+			},
+		},
+		{
+			ID:     "waives.date",
+			Fields: []ch360.ModuleField{{Name: "Date"}},
+		},
+	}
+}
+
+func TestValidateExtractorTemplate_Accepts_Valid_Template(t *testing.T) {
+	template := ch360.ExtractorTemplate{
+		Modules: []ch360.ModuleTemplate{
+			{
+				ID:           "waives.reference_number",
+				Arguments:    map[string]interface{}{"format": "[A-Z]", "max_length": 10.0},
+				FieldAliases: []ch360.FieldAliasTemplate{{Field: "Reference Number", Alias: "Code"}},
+			},
+			{ID: "WAIVES.DATE"},
+		},
+	}
+
+	templateErrs := ch360.ValidateExtractorTemplate(template, aModuleList())
+
+	assert.Empty(t, templateErrs)
+}
+
+func TestValidateExtractorTemplate_Reports_Unknown_Module(t *testing.T) {
+	template := ch360.ExtractorTemplate{
+		Modules: []ch360.ModuleTemplate{{ID: "waives.unknown"}},
+	}
+
+	templateErrs := ch360.ValidateExtractorTemplate(template, aModuleList())
+
+	assert.Equal(t, []ch360.TemplateError{{
+		Messages: []string{"The module waives.unknown does not exist."},
+		Path:     "modules[0]",
+	}}, templateErrs)
+}
+
+func TestValidateExtractorTemplate_Reports_Argument_Problems(t *testing.T) {
+	template := ch360.ExtractorTemplate{
+		Modules: []ch360.ModuleTemplate{{
+			ID:        "waives.reference_number",
+			Arguments: map[string]interface{}{"format": "", "max_length": 2.5, "other": true},
+		}},
+	}
+
+	templateErrs := ch360.ValidateExtractorTemplate(template, aModuleList())
+
+	assert.Equal(t, []ch360.TemplateError{
+		{
+			ModuleID:     "waives.reference_number",
+			Messages:     []string{"No argument was specified"},
+			Path:         "modules[0].arguments.format",
+			ArgumentName: "format",
+		},
+		{
+			ModuleID:      "waives.reference_number",
+			Messages:      []string{"The argument must be of type 'integer'"},
+			Path:          "modules[0].arguments.max_length",
+			ArgumentName:  "max_length",
+			ArgumentValue: "2.5",
+		},
+		{
+			ModuleID:      "waives.reference_number",
+			Messages:      []string{"The module has no such parameter"},
+			Path:          "modules[0].arguments.other",
+			ArgumentName:  "other",
+			ArgumentValue: "true",
+		},
+	}, templateErrs)
+}
+
+func TestValidateExtractorTemplate_Reports_Field_Alias_Problems(t *testing.T) {
+	template := ch360.ExtractorTemplate{
+		Modules: []ch360.ModuleTemplate{
+			{
+				ID:           "waives.date",
+				FieldAliases: []ch360.FieldAliasTemplate{{Field: "Date", Alias: "Code"}},
+			},
+			{
+				ID:        "waives.reference_number",
+				Arguments: map[string]interface{}{"format": "[A-Z]"},
+				FieldAliases: []ch360.FieldAliasTemplate{
+					{Field: "Missing", Alias: "code"},
+				},
+			},
+		},
+	}
+
+	templateErrs := ch360.ValidateExtractorTemplate(template, aModuleList())
+
+	assert.Equal(t, []ch360.TemplateError{
+		{
+			ModuleID: "waives.reference_number",
+			Messages: []string{"The alias 'code' refers to the field 'Missing', which the module does not have."},
+			Path:     "modules[1].field_aliases[0]",
+		},
+		{
+			ModuleID: "waives.reference_number",
+			Messages: []string{"The alias 'code' is used more than once."},
+			Path:     "modules[1].field_aliases[0]",
+		},
+	}, templateErrs)
+}
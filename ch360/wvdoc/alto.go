@@ -0,0 +1,141 @@
+package wvdoc
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+const altoNamespace = "http://www.loc.gov/standards/alto/ns-v4#"
+
+type altoDocument struct {
+	XMLName     xml.Name        `xml:"alto"`
+	Namespace   string          `xml:"xmlns,attr"`
+	Description altoDescription `xml:"Description"`
+	Pages       []altoPage      `xml:"Layout>Page"`
+}
+
+type altoDescription struct {
+	MeasurementUnit string `xml:"MeasurementUnit"`
+	FileName        string `xml:"sourceImageInformation>fileName"`
+}
+
+type altoPage struct {
+	ID             string         `xml:"ID,attr"`
+	PhysicalNumber int            `xml:"PHYSICAL_IMG_NR,attr"`
+	Width          float64        `xml:"WIDTH,attr"`
+	Height         float64        `xml:"HEIGHT,attr"`
+	PrintSpace     altoPrintSpace `xml:"PrintSpace"`
+}
+
+type altoPrintSpace struct {
+	altoBox
+	TextBlocks []altoTextBlock `xml:"TextBlock"`
+}
+
+type altoTextBlock struct {
+	ID string `xml:"ID,attr"`
+	altoBox
+	Lines []altoTextLine `xml:"TextLine"`
+}
+
+type altoTextLine struct {
+	ID string `xml:"ID,attr"`
+	altoBox
+	Contents []interface{}
+}
+
+type altoString struct {
+	XMLName xml.Name `xml:"String"`
+	ID      string   `xml:"ID,attr"`
+	Content string   `xml:"CONTENT,attr"`
+	altoBox
+	Confidence float64 `xml:"WC,attr"`
+}
+
+type altoSpace struct {
+	XMLName xml.Name `xml:"SP"`
+}
+
+type altoBox struct {
+	HPos   float64 `xml:"HPOS,attr"`
+	VPos   float64 `xml:"VPOS,attr"`
+	Width  float64 `xml:"WIDTH,attr"`
+	Height float64 `xml:"HEIGHT,attr"`
+}
+
+func newAltoBox(bounds Bounds) altoBox {
+	return altoBox{
+		HPos:   bounds.Left,
+		VPos:   bounds.Top,
+		Width:  bounds.Width(),
+		Height: bounds.Height(),
+	}
+}
+
+// WriteALTO writes the Document in the ALTO v4 format (https://www.loc.gov/standards/alto/).
+// Each page holds a single text block containing all of its lines. The fileName is
+// recorded as the source of the document.
+//
+// The coordinates in the Document are written as they are, with a MeasurementUnit
+// of "pixel".
+func (document *Document) WriteALTO(writer io.Writer, fileName string) error {
+	alto := altoDocument{
+		Namespace: altoNamespace,
+		Description: altoDescription{
+			MeasurementUnit: "pixel",
+			FileName:        fileName,
+		},
+	}
+
+	for pageIndex, page := range document.Pages {
+		pageId := pageIndex + 1
+		pageBox := altoBox{Width: page.Width, Height: page.Height}
+		block := altoTextBlock{ID: fmt.Sprintf("block_%d", pageId), altoBox: pageBox}
+
+		for lineIndex, line := range page.Lines {
+			lineId := fmt.Sprintf("%d_%d", pageId, lineIndex+1)
+			textLine := altoTextLine{ID: "line_" + lineId, altoBox: newAltoBox(line.Bounds())}
+
+			for wordIndex, word := range line.Words {
+				if wordIndex > 0 {
+					textLine.Contents = append(textLine.Contents, altoSpace{})
+				}
+				textLine.Contents = append(textLine.Contents, altoString{
+					ID:         fmt.Sprintf("word_%s_%d", lineId, wordIndex+1),
+					Content:    word.Text,
+					altoBox:    newAltoBox(word.Bounds()),
+					Confidence: word.Confidence,
+				})
+			}
+
+			block.Lines = append(block.Lines, textLine)
+		}
+
+		alto.Pages = append(alto.Pages, altoPage{
+			ID:             fmt.Sprintf("page_%d", pageId),
+			PhysicalNumber: pageId,
+			Width:          page.Width,
+			Height:         page.Height,
+			PrintSpace: altoPrintSpace{
+				altoBox:    pageBox,
+				TextBlocks: []altoTextBlock{block},
+			},
+		})
+	}
+
+	_, err := io.WriteString(writer, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", " ")
+	err = encoder.Encode(&alto)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(writer, "\n")
+	return err
+}
@@ -0,0 +1,37 @@
+package wvdoc_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/waives/surf/ch360/wvdoc"
+	"testing"
+)
+
+func TestWriteALTO_Writes_Pages_Lines_And_Words(t *testing.T) {
+	document := &wvdoc.Document{Pages: []wvdoc.Page{{
+		PageNumber: 1,
+		Width:      612,
+		Height:     792,
+		Lines: []wvdoc.Line{{Words: []wvdoc.Word{
+			{Text: "Fish", Left: 10, Top: 10, Right: 40, Bottom: 20, Confidence: 0.9},
+			{Text: "&", Left: 45, Top: 10, Right: 50, Bottom: 20, Confidence: 0.5},
+		}}},
+	}}}
+	output := &bytes.Buffer{}
+
+	err := document.WriteALTO(output, "a.pdf")
+
+	require.NoError(t, err)
+	assert.NoError(t, xml.Unmarshal(output.Bytes(), new(interface{})))
+	assert.Contains(t, output.String(), `<alto xmlns="http://www.loc.gov/standards/alto/ns-v4#">`)
+	assert.Contains(t, output.String(), `<fileName>a.pdf</fileName>`)
+	assert.Contains(t, output.String(), `<Page ID="page_1" PHYSICAL_IMG_NR="1" WIDTH="612" HEIGHT="792">`)
+	assert.Contains(t, output.String(), `<TextLine ID="line_1_1" HPOS="10" VPOS="10" WIDTH="40" HEIGHT="10">`)
+	assert.Contains(t, output.String(),
+		`<String ID="word_1_1_1" CONTENT="Fish" HPOS="10" VPOS="10" WIDTH="30" HEIGHT="10" WC="0.9"></String>`)
+	assert.Contains(t, output.String(), `<SP></SP>`)
+	assert.Contains(t, output.String(),
+		`<String ID="word_1_1_2" CONTENT="&amp;" HPOS="45" VPOS="10" WIDTH="5" HEIGHT="10" WC="0.5"></String>`)
+}
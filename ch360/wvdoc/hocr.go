@@ -0,0 +1,74 @@
+package wvdoc
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"math"
+)
+
+const hocrHeader = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML 1.0 Transitional//EN" "http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd">
+<html xmlns="http://www.w3.org/1999/xhtml" xml:lang="en" lang="en">
+ <head>
+  <title>%s</title>
+  <meta http-equiv="Content-Type" content="text/html;charset=utf-8"/>
+  <meta name="ocr-system" content="waives"/>
+  <meta name="ocr-capabilities" content="ocr_page ocr_line ocrx_word"/>
+ </head>
+ <body>
+`
+
+const hocrFooter = ` </body>
+</html>
+`
+
+// WriteHOCR writes the Document in the hOCR format (http://kba.cloud/hocr-spec/1.2/),
+// with a page, line and word element for each of those in the Document. The title
+// is used as the title of the html document.
+//
+// hOCR bounding boxes are in whole units, so coordinates are rounded.
+func (document *Document) WriteHOCR(writer io.Writer, title string) error {
+	w := bufio.NewWriter(writer)
+
+	fmt.Fprintf(w, hocrHeader, html.EscapeString(title))
+
+	for pageIndex, page := range document.Pages {
+		pageId := pageIndex + 1
+		fmt.Fprintf(w, "  <div class='ocr_page' id='page_%d' title='bbox 0 0 %d %d; ppageno %d'>\n",
+			pageId, round(page.Width), round(page.Height), pageIndex)
+
+		for lineIndex, line := range page.Lines {
+			lineId := fmt.Sprintf("%d_%d", pageId, lineIndex+1)
+			fmt.Fprintf(w, "   <span class='ocr_line' id='line_%s' title='%s'>", lineId,
+				hocrBbox(line.Bounds()))
+
+			for wordIndex, word := range line.Words {
+				if wordIndex > 0 {
+					fmt.Fprint(w, " ")
+				}
+				fmt.Fprintf(w, "<span class='ocrx_word' id='word_%s_%d' title='%s; x_wconf %d'>%s</span>",
+					lineId, wordIndex+1, hocrBbox(word.Bounds()), round(word.Confidence*100),
+					html.EscapeString(word.Text))
+			}
+
+			fmt.Fprint(w, "</span>\n")
+		}
+
+		fmt.Fprint(w, "  </div>\n")
+	}
+
+	fmt.Fprint(w, hocrFooter)
+
+	return w.Flush()
+}
+
+func hocrBbox(bounds Bounds) string {
+	return fmt.Sprintf("bbox %d %d %d %d", round(bounds.Left), round(bounds.Top),
+		round(bounds.Right), round(bounds.Bottom))
+}
+
+func round(value float64) int {
+	return int(math.Round(value))
+}
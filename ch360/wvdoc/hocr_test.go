@@ -0,0 +1,34 @@
+package wvdoc_test
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/waives/surf/ch360/wvdoc"
+	"strings"
+	"testing"
+)
+
+func TestWriteHOCR_Writes_Pages_Lines_And_Words(t *testing.T) {
+	document := &wvdoc.Document{Pages: []wvdoc.Page{{
+		PageNumber: 1,
+		Width:      612,
+		Height:     792,
+		Lines: []wvdoc.Line{{Words: []wvdoc.Word{
+			{Text: "Fish", Left: 10, Top: 10, Right: 40, Bottom: 20, Confidence: 0.9},
+			{Text: "&", Left: 45, Top: 9.6, Right: 50.4, Bottom: 20, Confidence: 0.456},
+		}}},
+	}}}
+	output := &bytes.Buffer{}
+
+	err := document.WriteHOCR(output, "a<b>.pdf")
+
+	require.NoError(t, err)
+	assert.Contains(t, output.String(), "<title>a&lt;b&gt;.pdf</title>")
+	assert.Contains(t, output.String(),
+		"<div class='ocr_page' id='page_1' title='bbox 0 0 612 792; ppageno 0'>")
+	assert.Contains(t, output.String(), "<span class='ocr_line' id='line_1_1' title='bbox 10 10 50 20'>"+
+		"<span class='ocrx_word' id='word_1_1_1' title='bbox 10 10 40 20; x_wconf 90'>Fish</span> "+
+		"<span class='ocrx_word' id='word_1_1_2' title='bbox 45 10 50 20; x_wconf 46'>&amp;</span></span>")
+	assert.True(t, strings.HasSuffix(output.String(), "</html>\n"))
+}
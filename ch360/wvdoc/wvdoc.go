@@ -0,0 +1,218 @@
+// Package wvdoc reads the "wvdoc" results format produced by waives when a
+// document is read (application/vnd.waives.resultformats.read+zip).
+//
+// A wvdoc is a zip archive. This package only relies on its DocumentEntryName
+// entry, a json description of the pages of the document and the position of
+// each word on them; any other entries in the archive are ignored.
+package wvdoc
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// DocumentEntryName is the name of the archive entry which holds the text
+// and layout of the document.
+const DocumentEntryName = "document.json"
+
+// Document is the text and layout of a document which has been read.
+type Document struct {
+	Pages []Page `json:"pages"`
+}
+
+// Page is a single page of a Document. Coordinates on the page are measured
+// from its top left corner, in the same units as its Width and Height.
+type Page struct {
+	PageNumber int     `json:"page_number"`
+	Width      float64 `json:"width"`
+	Height     float64 `json:"height"`
+	Lines      []Line  `json:"lines"`
+}
+
+// Line is a line of text on a Page.
+type Line struct {
+	Words []Word `json:"words"`
+}
+
+// Word is a single word on a Page, along with its bounding box.
+type Word struct {
+	Text   string  `json:"text"`
+	Left   float64 `json:"left"`
+	Top    float64 `json:"top"`
+	Right  float64 `json:"right"`
+	Bottom float64 `json:"bottom"`
+	// Confidence is how confident the OCR engine is that the text of the word
+	// is correct, between 0 and 1.
+	Confidence float64 `json:"confidence"`
+}
+
+// Bounds is a bounding box on a Page.
+type Bounds struct {
+	Left, Top, Right, Bottom float64
+}
+
+// Width returns the width of the Bounds.
+func (b Bounds) Width() float64 {
+	return b.Right - b.Left
+}
+
+// Height returns the height of the Bounds.
+func (b Bounds) Height() float64 {
+	return b.Bottom - b.Top
+}
+
+// Bounds returns the bounding box of the Word.
+func (word Word) Bounds() Bounds {
+	return Bounds{Left: word.Left, Top: word.Top, Right: word.Right, Bottom: word.Bottom}
+}
+
+// Bounds returns the smallest bounding box containing all of the words in the Line.
+func (line Line) Bounds() Bounds {
+	if len(line.Words) == 0 {
+		return Bounds{}
+	}
+
+	bounds := line.Words[0].Bounds()
+	for _, word := range line.Words[1:] {
+		bounds.Left = math.Min(bounds.Left, word.Left)
+		bounds.Top = math.Min(bounds.Top, word.Top)
+		bounds.Right = math.Max(bounds.Right, word.Right)
+		bounds.Bottom = math.Max(bounds.Bottom, word.Bottom)
+	}
+	return bounds
+}
+
+// Match is an occurrence of a pattern in the text of a Document.
+type Match struct {
+	Text       string
+	PageNumber int
+	// Words are the words which (wholly or partly) make up the match.
+	Words []Word
+}
+
+// Read reads a Document from the contents of a wvdoc archive.
+func Read(r io.Reader) (*Document, error) {
+	contents, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(contents), int64(len(contents)))
+	if err != nil {
+		return nil, errors.Wrap(err, "The read result is not a valid wvdoc")
+	}
+
+	for _, entry := range archive.File {
+		if entry.Name != DocumentEntryName {
+			continue
+		}
+
+		entryReader, err := entry.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer entryReader.Close()
+
+		document := &Document{}
+		if err = json.NewDecoder(entryReader).Decode(document); err != nil {
+			return nil, errors.Wrapf(err, "The wvdoc entry '%s' is not valid", DocumentEntryName)
+		}
+		return document, nil
+	}
+
+	return nil, errors.Errorf("The wvdoc does not contain a '%s' entry", DocumentEntryName)
+}
+
+// Text returns the text of the Line, with its words separated by spaces.
+func (line Line) Text() string {
+	words := make([]string, len(line.Words))
+	for i, word := range line.Words {
+		words[i] = word.Text
+	}
+	return strings.Join(words, " ")
+}
+
+// Text returns the text of the Page, with its lines separated by newlines.
+func (page Page) Text() string {
+	lines := make([]string, len(page.Lines))
+	for i, line := range page.Lines {
+		lines[i] = line.Text()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SelectPages returns a Document containing only the pages for which include
+// returns true.
+func (document *Document) SelectPages(include func(pageNumber int) bool) *Document {
+	selected := &Document{}
+	for _, page := range document.Pages {
+		if include(page.PageNumber) {
+			selected.Pages = append(selected.Pages, page)
+		}
+	}
+	return selected
+}
+
+// FindAll returns every match of pattern in the text of the Document. The text
+// of each page is searched separately, so matches never span pages.
+func (document *Document) FindAll(pattern *regexp.Regexp) []Match {
+	var matches []Match
+
+	for _, page := range document.Pages {
+		text, offsets := page.textWithOffsets()
+
+		for _, indices := range pattern.FindAllStringIndex(text, -1) {
+			start, end := indices[0], indices[1]
+			if start == end {
+				continue
+			}
+
+			match := Match{Text: text[start:end], PageNumber: page.PageNumber}
+			for _, offset := range offsets {
+				if offset.start < end && offset.end > start {
+					match.Words = append(match.Words, offset.word)
+				}
+			}
+			matches = append(matches, match)
+		}
+	}
+
+	return matches
+}
+
+type wordOffset struct {
+	word       Word
+	start, end int
+}
+
+// textWithOffsets returns the same text as Text, along with the position of
+// each word within it.
+func (page Page) textWithOffsets() (string, []wordOffset) {
+	var (
+		text    strings.Builder
+		offsets []wordOffset
+	)
+
+	for lineIndex, line := range page.Lines {
+		if lineIndex > 0 {
+			text.WriteString("\n")
+		}
+		for wordIndex, word := range line.Words {
+			if wordIndex > 0 {
+				text.WriteString(" ")
+			}
+			start := text.Len()
+			text.WriteString(word.Text)
+			offsets = append(offsets, wordOffset{word: word, start: start, end: text.Len()})
+		}
+	}
+
+	return text.String(), offsets
+}
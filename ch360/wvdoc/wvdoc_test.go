@@ -0,0 +1,126 @@
+package wvdoc_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/waives/surf/ch360/wvdoc"
+	"regexp"
+	"testing"
+)
+
+const exampleDocument = `{
+  "pages": [
+    {
+      "page_number": 1,
+      "width": 612,
+      "height": 792,
+      "lines": [
+        {"words": [
+          {"text": "SSN:", "left": 10, "top": 10, "right": 40, "bottom": 20, "confidence": 0.9},
+          {"text": "123-45-6789", "left": 45, "top": 8, "right": 120, "bottom": 20.6, "confidence": 0.75}
+        ]},
+        {"words": [
+          {"text": "Email", "left": 10, "top": 30, "right": 40, "bottom": 40},
+          {"text": "jo@example.com", "left": 45, "top": 30, "right": 150, "bottom": 40}
+        ]}
+      ]
+    },
+    {
+      "page_number": 2,
+      "width": 612,
+      "height": 792,
+      "lines": [
+        {"words": [
+          {"text": "123", "left": 10, "top": 10, "right": 30, "bottom": 20},
+          {"text": "45", "left": 35, "top": 10, "right": 50, "bottom": 20},
+          {"text": "6789", "left": 55, "top": 10, "right": 85, "bottom": 20}
+        ]}
+      ]
+    }
+  ]
+}`
+
+func aWvdoc(t *testing.T, entries map[string]string) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	archive := zip.NewWriter(buf)
+	for name, contents := range entries {
+		entry, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = entry.Write([]byte(contents))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	return buf
+}
+
+func TestRead_Reads_Document_Entry(t *testing.T) {
+	document, err := wvdoc.Read(aWvdoc(t, map[string]string{
+		"page-1.png":            "not json",
+		wvdoc.DocumentEntryName: exampleDocument,
+	}))
+
+	require.NoError(t, err)
+	require.Len(t, document.Pages, 2)
+	assert.Equal(t, 1, document.Pages[0].PageNumber)
+	assert.Equal(t, "SSN: 123-45-6789\nEmail jo@example.com", document.Pages[0].Text())
+}
+
+func TestRead_Returns_Error_If_Not_A_Zip(t *testing.T) {
+	_, err := wvdoc.Read(bytes.NewBufferString("not a zip"))
+
+	assert.Error(t, err)
+}
+
+func TestRead_Returns_Error_If_Document_Entry_Missing(t *testing.T) {
+	_, err := wvdoc.Read(aWvdoc(t, map[string]string{"other.json": "{}"}))
+
+	assert.EqualError(t, err, "The wvdoc does not contain a 'document.json' entry")
+}
+
+func TestFindAll_Returns_Words_Making_Up_Each_Match(t *testing.T) {
+	document, err := wvdoc.Read(aWvdoc(t, map[string]string{wvdoc.DocumentEntryName: exampleDocument}))
+	require.NoError(t, err)
+
+	matches := document.FindAll(regexp.MustCompile(`\d{3}[- ]\d{2}[- ]\d{4}`))
+
+	require.Len(t, matches, 2)
+	assert.Equal(t, "123-45-6789", matches[0].Text)
+	assert.Equal(t, 1, matches[0].PageNumber)
+	assert.Equal(t, []wvdoc.Word{document.Pages[0].Lines[0].Words[1]}, matches[0].Words)
+	assert.Equal(t, "123 45 6789", matches[1].Text)
+	assert.Equal(t, 2, matches[1].PageNumber)
+	assert.Equal(t, document.Pages[1].Lines[0].Words, matches[1].Words)
+}
+
+func TestFindAll_Includes_Partially_Matched_Words(t *testing.T) {
+	document, err := wvdoc.Read(aWvdoc(t, map[string]string{wvdoc.DocumentEntryName: exampleDocument}))
+	require.NoError(t, err)
+
+	matches := document.FindAll(regexp.MustCompile(`example`))
+
+	require.Len(t, matches, 1)
+	assert.Equal(t, []wvdoc.Word{document.Pages[0].Lines[1].Words[1]}, matches[0].Words)
+}
+
+func TestLine_Bounds_Contains_All_Words(t *testing.T) {
+	document, err := wvdoc.Read(aWvdoc(t, map[string]string{wvdoc.DocumentEntryName: exampleDocument}))
+	require.NoError(t, err)
+
+	bounds := document.Pages[0].Lines[0].Bounds()
+
+	assert.Equal(t, wvdoc.Bounds{Left: 10, Top: 8, Right: 120, Bottom: 20.6}, bounds)
+	assert.Equal(t, 0.75, document.Pages[0].Lines[0].Words[1].Confidence)
+}
+
+func TestSelectPages_Returns_Included_Pages(t *testing.T) {
+	document, err := wvdoc.Read(aWvdoc(t, map[string]string{wvdoc.DocumentEntryName: exampleDocument}))
+	require.NoError(t, err)
+
+	selected := document.SelectPages(func(pageNumber int) bool { return pageNumber == 2 })
+
+	require.Len(t, selected.Pages, 1)
+	assert.Equal(t, 2, selected.Pages[0].PageNumber)
+	assert.Len(t, document.Pages, 2)
+}
@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/config"
+	"github.com/waives/surf/net"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"io"
+	"os"
+)
+
+//go:generate mockery -name "ExtractorApplier"
+type ExtractorApplier interface {
+	ExtractorGetter
+	ExtractorTemplateGetter
+	ExtractorDeleter
+	ExtractorCreator
+}
+
+// ApplyExtractorCmd makes the named extractor in waives match a template: it is
+// created if it does not exist, and replaced if its definition differs. Applying the
+// same template again has no effect.
+type ApplyExtractorCmd struct {
+	Client        ExtractorApplier
+	ExtractorName string
+	Template      *ch360.ExtractorTemplate
+	Output        io.Writer
+}
+
+type applyExtractorArgs struct {
+	extractorName    string
+	templateFilename string
+}
+
+// ConfigureApplyExtractorCmd configures kingpin with the 'apply extractor' command.
+func ConfigureApplyExtractorCmd(ctx context.Context, applyCmd *kingpin.CmdClause,
+	flags *config.GlobalFlags) {
+	args := &applyExtractorArgs{}
+	applyExtractorCmd := &ApplyExtractorCmd{}
+
+	applyExtractorCli := applyCmd.Command("extractor",
+		"Create or replace a waives extractor from an extractor template.").
+		Action(func(parseContext *kingpin.ParseContext) error {
+			err := applyExtractorCmd.initFromArgs(args, flags)
+
+			if err != nil {
+				return err
+			}
+
+			return applyExtractorCmd.Execute(ctx)
+		})
+
+	applyExtractorCli.
+		Arg("name", "The name of the extractor.").
+		Required().
+		StringVar(&args.extractorName)
+
+	applyExtractorCli.
+		Arg("template-file", "The extraction template file (json).").
+		Required().
+		StringVar(&args.templateFilename)
+}
+
+// Execute runs the 'apply extractor' command.
+func (cmd *ApplyExtractorCmd) Execute(ctx context.Context) error {
+	extractors, err := cmd.Client.GetAll(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if !extractors.Contains(cmd.ExtractorName) {
+		if err = cmd.create(ctx, *cmd.Template); err != nil {
+			return err
+		}
+		return cmd.report("created")
+	}
+
+	existingTemplate, err := cmd.Client.GetTemplate(ctx, cmd.ExtractorName)
+
+	if err != nil {
+		return errors.WithMessagef(err, "failed to get extractor '%s'", cmd.ExtractorName)
+	}
+
+	if existingTemplate.Equals(*cmd.Template) {
+		return cmd.report("unchanged")
+	}
+
+	err = ch360.ReplaceExtractor(ctx, cmd.Client, cmd.ExtractorName, *existingTemplate,
+		*cmd.Template)
+
+	if err != nil {
+		return describeTemplateErrors(err)
+	}
+
+	return cmd.report("replaced")
+}
+
+func (cmd *ApplyExtractorCmd) create(ctx context.Context, template ch360.ExtractorTemplate) error {
+	return describeTemplateErrors(cmd.Client.CreateFromModules(ctx, cmd.ExtractorName, template))
+}
+
+// describeTemplateErrors replaces an error response listing the problems with a template
+// with a message describing them.
+func describeTemplateErrors(err error) error {
+	if detailedResponse, ok := err.(*net.DetailedErrorResponse); ok {
+		return buildDetailedErrorMessage(*detailedResponse)
+	}
+
+	return err
+}
+
+func (cmd *ApplyExtractorCmd) report(outcome string) error {
+	_, err := fmt.Fprintf(cmd.Output, "Extractor '%s' %s.\n", cmd.ExtractorName, outcome)
+	return err
+}
+
+func (cmd *ApplyExtractorCmd) initFromArgs(args *applyExtractorArgs, flags *config.GlobalFlags) error {
+	var err error
+
+	cmd.ExtractorName = args.extractorName
+	cmd.Template, err = ch360.NewModulesTemplateFromFile(args.templateFilename)
+
+	if err != nil {
+		return err
+	}
+
+	client, err := initApiClient(flags)
+
+	if err != nil {
+		return err
+	}
+
+	cmd.Client = client.Extractors
+	cmd.Output = os.Stderr
+	return nil
+}
@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/alecthomas/units"
+	"github.com/waives/surf/cache"
+	"github.com/waives/surf/config"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+//go:generate mockery -name "ResultCacheManager"
+
+type ResultCacheManager interface {
+	Stats() (cache.Stats, error)
+	Prune(unusedFor time.Duration) (int, error)
+}
+
+// CacheStatsCmd reports on the contents of the local results cache.
+type CacheStatsCmd struct {
+	Cache  ResultCacheManager
+	Output io.Writer
+}
+
+// CachePruneCmd removes results from the local results cache.
+type CachePruneCmd struct {
+	Cache     ResultCacheManager
+	UnusedFor time.Duration
+	Output    io.Writer
+}
+
+// ConfigureCacheCommand configures kingpin with the 'cache' commands.
+func ConfigureCacheCommand(ctx context.Context, app *kingpin.Application,
+	globalFlags *config.GlobalFlags) {
+	cacheStatsCmd := &CacheStatsCmd{}
+	cachePruneCmd := &CachePruneCmd{}
+
+	cacheCli := app.Command("cache", "Manage the local results cache.")
+
+	cacheCli.Command("stats", "Show the number and size of the cached results.").
+		Action(func(parseContext *kingpin.ParseContext) error {
+			err := cacheStatsCmd.initFromArgs()
+			if err != nil {
+				return err
+			}
+			return cacheStatsCmd.Execute(ctx)
+		})
+
+	cachePruneCli := cacheCli.Command("prune", "Remove results from the cache.").
+		Action(func(parseContext *kingpin.ParseContext) error {
+			err := cachePruneCmd.initFromArgs()
+			if err != nil {
+				return err
+			}
+			return cachePruneCmd.Execute(ctx)
+		})
+
+	cachePruneCli.Flag("unused-for", "Only remove results which have not been used for the"+
+		" specified duration (e.g. 72h). By default, all results are removed.").
+		PlaceHolder("duration").
+		DurationVar(&cachePruneCmd.UnusedFor)
+}
+
+// Execute runs the 'cache stats' command.
+func (cmd *CacheStatsCmd) Execute(ctx context.Context) error {
+	stats, err := cmd.Cache.Stats()
+	if err != nil {
+		return err
+	}
+
+	if len(stats) == 0 {
+		_, err = fmt.Fprintln(cmd.Output, "The cache is empty.")
+		return err
+	}
+
+	var (
+		totalEntries int
+		totalSize    int64
+	)
+
+	table := NewTable(cmd.Output, []string{"Operation", "Results", "Size"})
+	for _, operationStats := range stats {
+		table.Append([]string{
+			operationStats.Operation,
+			strconv.Itoa(operationStats.Entries),
+			units.Base2Bytes(operationStats.Size).String(),
+		})
+		totalEntries += operationStats.Entries
+		totalSize += operationStats.Size
+	}
+	table.SetFooter([]string{"Total", strconv.Itoa(totalEntries),
+		units.Base2Bytes(totalSize).String()})
+	table.Render()
+
+	return nil
+}
+
+func (cmd *CacheStatsCmd) initFromArgs() error {
+	var err error
+	cmd.Cache, err = newResultCache()
+	cmd.Output = os.Stdout
+
+	return err
+}
+
+// Execute runs the 'cache prune' command.
+func (cmd *CachePruneCmd) Execute(ctx context.Context) error {
+	removed, err := cmd.Cache.Prune(cmd.UnusedFor)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(cmd.Output, "Removed %d cached results.\n", removed)
+	return err
+}
+
+func (cmd *CachePruneCmd) initFromArgs() error {
+	var err error
+	cmd.Cache, err = newResultCache()
+	cmd.Output = os.Stdout
+
+	return err
+}
+
+func newResultCache() (*cache.ResultCache, error) {
+	appDir, err := config.NewAppDirectory()
+	if err != nil {
+		return nil, err
+	}
+
+	return cache.NewResultCache(appDir.CacheDirectory()), nil
+}
+
+func isCacheEnabled(flags *config.GlobalFlags) bool {
+	return flags.UseCache || flags.RefreshCache
+}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/fs"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// document types with fewer than this proportion of the samples of the largest are
+// reported, as they may lead to a classifier biased against them
+const minimumSampleProportion = 0.5
+
+// createSamplesArchive builds a classifier samples archive from a directory tree in
+// which each subdirectory holds the samples of one document type. The archive is
+// written to a temporary file, which the caller is responsible for removing. The
+// number of samples of each document type is reported to out.
+func createSamplesArchive(samplesDirectory string, out io.Writer) (string, error) {
+	samples, err := fs.ReadLabelledTree(samplesDirectory)
+	if err != nil {
+		return "", errors.WithMessagef(err, "failed to read samples directory '%s'", samplesDirectory)
+	}
+
+	if err = validateSamples(samplesDirectory, samples); err != nil {
+		return "", err
+	}
+
+	reportSampleCounts(out, ch360.CountSamples(samples))
+
+	archive, err := ioutil.TempFile("", "surf-samples-*.zip")
+	if err != nil {
+		return "", err
+	}
+
+	err = ch360.WriteSamplesArchive(archive, samples)
+	if closeErr := archive.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(archive.Name())
+		return "", errors.WithMessage(err, "failed to create samples archive")
+	}
+
+	return archive.Name(), nil
+}
+
+func validateSamples(samplesDirectory string, samples []fs.LabelledFile) error {
+	var unsupported []string
+	for _, sample := range samples {
+		if !ch360.IsSupportedSample(sample.Path) {
+			unsupported = append(unsupported, sample.Path)
+		}
+	}
+
+	if len(unsupported) > 0 {
+		return errors.Errorf("the following files are not of a supported type: %s",
+			strings.Join(unsupported, ", "))
+	}
+
+	if len(ch360.CountSamples(samples)) < 2 {
+		return errors.Errorf("samples of at least two document types are required, in "+
+			"subdirectories of '%s' named after each document type", samplesDirectory)
+	}
+
+	return nil
+}
+
+func reportSampleCounts(out io.Writer, counts ch360.SampleCounts) {
+	table := NewTable(out, []string{"Document Type", "Samples"})
+	for _, documentType := range counts.DocumentTypes() {
+		table.Append([]string{documentType, strconv.Itoa(counts[documentType])})
+	}
+	table.Render()
+
+	for _, documentType := range counts.Underrepresented(minimumSampleProportion) {
+		_, _ = fmt.Fprintf(out, "Warning: document type '%s' has comparatively few samples (%d).\n",
+			documentType, counts[documentType])
+	}
+}
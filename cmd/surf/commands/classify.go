@@ -9,6 +9,7 @@ import (
 	"github.com/waives/surf/output/progress"
 	"github.com/waives/surf/output/resultsWriters"
 	"gopkg.in/alecthomas/kingpin.v2"
+	"io"
 	"os"
 )
 
@@ -27,6 +28,8 @@ type ClassifyCmd struct {
 	ClassificationService ClassificationService
 	FilePaths             []string
 	ClassifierName        string
+	// inputs are the files to process, which are closed once they have been processed
+	inputs io.Closer
 }
 
 func ConfigureClassifyCommand(ctx context.Context,
@@ -38,10 +41,11 @@ func ConfigureClassifyCommand(ctx context.Context,
 	classifyCli := app.
 		Command("classify", "Perform content classification on a file or set of files.").
 		Action(func(parseContext *kingpin.ParseContext) error {
-			err := classifyCmd.initWithArgs(classifyArgs, globalFlags)
+			err := classifyCmd.initWithArgs(ctx, classifyArgs, globalFlags)
 			if err != nil {
 				return err
 			}
+			defer classifyCmd.inputs.Close()
 
 			return classifyCmd.Execute(ctx)
 		})
@@ -55,10 +59,11 @@ func ConfigureClassifyCommand(ctx context.Context,
 		Required().
 		StringVar(&classifyArgs.classifierName)
 
-	classifyCli.Arg("files", "The files to read.").
-		Required().
+	classifyCli.Arg("files", "The files to read, or '-' to read from stdin. "+
+		"URLs, and zip and tar archives, are also accepted.").
 		StringsVar(&classifyArgs.filePatterns)
 
+	addInputFlagsTo(globalFlags, classifyCli)
 	addFileHandlingFlagsTo(globalFlags, classifyCli)
 }
 
@@ -68,9 +73,9 @@ func (cmd *ClassifyCmd) Execute(ctx context.Context) error {
 	return errors.Wrap(err, "classification failed")
 }
 
-func (cmd *ClassifyCmd) initWithArgs(args *classifyArgs, flags *config.GlobalFlags) error {
-	resultsWriter, err := resultsWriters.NewClassificationResultsWriter(flags.MultiFileOut,
-		flags.OutputFile,
+func (cmd *ClassifyCmd) initWithArgs(ctx context.Context, args *classifyArgs,
+	flags *config.GlobalFlags) error {
+	resultsWriter, err := resultsWriters.NewClassificationResultsWriter(outputDestination(flags),
 		args.outputFormat)
 
 	if err != nil {
@@ -80,15 +85,16 @@ func (cmd *ClassifyCmd) initWithArgs(args *classifyArgs, flags *config.GlobalFla
 	progressHandler := progress.NewProgressHandler(resultsWriter,
 		flags.ShowProgress, os.Stderr)
 
-	cmd.FilePaths, err = GlobMany(args.filePatterns)
+	files, err := resolveInputs(ctx, args.filePatterns, flags)
 
 	if err != nil {
 		return err
 	}
 
-	client, err := initApiClient(flags.ClientId,
-		flags.ClientSecret,
-		flags.LogHttp)
+	cmd.FilePaths = files.Names()
+	cmd.inputs = files
+
+	client, err := initApiClient(flags)
 
 	if err != nil {
 		return err
@@ -98,7 +104,9 @@ func (cmd *ClassifyCmd) initWithArgs(args *classifyArgs, flags *config.GlobalFla
 
 	cmd.ClassificationService = services.NewParallelClassificationService(fileClassifier,
 		client.Documents,
-		progressHandler)
+		progressHandler).
+		WithFileOpener(files.Open).
+		WithRecorder(flags.Telemetry)
 	cmd.ClassifierName = args.classifierName
 
 	return nil
@@ -3,6 +3,8 @@ package commands
 import (
 	"github.com/pkg/errors"
 	"github.com/waives/surf/config"
+	"github.com/waives/surf/output/resultsWriters"
+	"github.com/waives/surf/output/sinks"
 	"gopkg.in/alecthomas/kingpin.v2"
 )
 
@@ -16,11 +18,25 @@ func addFileHandlingFlagsTo(globalFlags *config.GlobalFlags, cmdClause *kingpin.
 		Short('o').
 		PlaceHolder("file").
 		StringVar(&globalFlags.OutputFile)
+	cmdClause.Flag("output-dir", "Write results output to multiple files (as -m does) under the "+
+		"specified directory, recreating the directory structure of the input files.").
+		PlaceHolder("dir").
+		StringVar(&globalFlags.OutputDir)
+	cmdClause.Flag("overwrite", "Overwrite any existing output files (the default).").
+		BoolVar(&globalFlags.Overwrite)
+	cmdClause.Flag("skip-existing", "Leave any existing output files unchanged.").
+		BoolVar(&globalFlags.SkipExisting)
+	cmdClause.Flag("fail-if-exists", "Stop with an error if an output file already exists.").
+		BoolVar(&globalFlags.FailIfExists)
 	cmdClause.Flag("progress", "Show a progress bar (only for use with -o or -m).").
 		Short('p').
 		BoolVar(&globalFlags.ShowProgress)
 
 	cmdClause.Validate(func(clause *kingpin.CmdClause) error {
+		if err := validateOutputDirFlags(globalFlags); err != nil {
+			return err
+		}
+
 		// Only show the progress bar if stdout is redirected, or -o or -m are used
 		if globalFlags.ShowProgress && !globalFlags.CanShowProgressBar() {
 			return errors.New("The --progress / -p option can only be used when " +
@@ -29,3 +45,80 @@ func addFileHandlingFlagsTo(globalFlags *config.GlobalFlags, cmdClause *kingpin.
 		return nil
 	})
 }
+
+// validateOutputDirFlags checks the --output-dir and existing file options, and
+// enables multiple file output when --output-dir is used.
+func validateOutputDirFlags(globalFlags *config.GlobalFlags) error {
+	policies := 0
+	for _, set := range []bool{globalFlags.Overwrite, globalFlags.SkipExisting, globalFlags.FailIfExists} {
+		if set {
+			policies++
+		}
+	}
+
+	if policies > 1 {
+		return errors.New("Only one of the --overwrite, --skip-existing and --fail-if-exists " +
+			"options can be used.")
+	}
+
+	if globalFlags.OutputDir != "" {
+		if globalFlags.OutputFile != "" {
+			return errors.New("The --output-dir option cannot be used in combination with -o.")
+		}
+		globalFlags.MultiFileOut = true
+	}
+
+	if policies > 0 && !globalFlags.MultiFileOut {
+		return errors.New("The --overwrite, --skip-existing and --fail-if-exists options can only " +
+			"be used in combination with -m or --output-dir.")
+	}
+
+	return nil
+}
+
+// outputDestination returns where results are written, according to the flags.
+func outputDestination(globalFlags *config.GlobalFlags) resultsWriters.Destination {
+	destination := resultsWriters.Destination{
+		MultiFileOut: globalFlags.MultiFileOut,
+		OutputFile:   globalFlags.OutputFile,
+		Location: sinks.OutputLocation{
+			OutputDir: globalFlags.OutputDir,
+		},
+	}
+
+	switch {
+	case globalFlags.SkipExisting:
+		destination.Location.ExistingFiles = sinks.SkipExisting
+	case globalFlags.FailIfExists:
+		destination.Location.ExistingFiles = sinks.FailIfExists
+	}
+
+	return destination
+}
+
+func addDeduplicationFlagTo(globalFlags *config.GlobalFlags, cmdClause *kingpin.CmdClause) {
+	cmdClause.Flag("dedupe",
+		"Process files with identical contents only once, and reuse any existing documents "+
+			"with the same contents instead of uploading them again.").
+		BoolVar(&globalFlags.Deduplicate)
+}
+
+func addCacheFlagsTo(globalFlags *config.GlobalFlags, cmdClause *kingpin.CmdClause) {
+	cmdClause.Flag("cache",
+		"Store results in, and reuse results from, the local cache (~/.surf/cache).").
+		BoolVar(&globalFlags.UseCache)
+	cmdClause.Flag("refresh",
+		"Ignore any results in the local cache, replacing them with new results (implies --cache).").
+		BoolVar(&globalFlags.RefreshCache)
+}
+
+func addInputFlagsTo(globalFlags *config.GlobalFlags, cmdClause *kingpin.CmdClause) {
+	cmdClause.Flag("files-from", "Also process the files listed in the specified file, one per "+
+		"line ('-' reads the list from stdin).").
+		PlaceHolder("file").
+		StringVar(&globalFlags.FilesFrom)
+	cmdClause.Flag("null", "The files listed by --files-from are separated by NUL characters "+
+		"rather than new lines (e.g. the output of 'find -print0').").
+		Short('0').
+		BoolVar(&globalFlags.NullSeparated)
+}
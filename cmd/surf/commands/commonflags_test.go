@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/waives/surf/config"
+	"github.com/waives/surf/output/sinks"
+	"testing"
+)
+
+func TestValidateOutputDirFlags_Enables_Multiple_File_Output(t *testing.T) {
+	flags := &config.GlobalFlags{OutputDir: "results", SkipExisting: true}
+
+	err := validateOutputDirFlags(flags)
+
+	assert.NoError(t, err)
+	assert.True(t, flags.MultiFileOut)
+}
+
+func TestValidateOutputDirFlags_Rejects_Invalid_Combinations(t *testing.T) {
+	fixtures := []*config.GlobalFlags{
+		{OutputDir: "results", OutputFile: "results.txt"},
+		{MultiFileOut: true, Overwrite: true, SkipExisting: true},
+		{OutputDir: "results", SkipExisting: true, FailIfExists: true},
+		{FailIfExists: true},
+		{OutputFile: "results.txt", SkipExisting: true},
+	}
+
+	for _, flags := range fixtures {
+		assert.Error(t, validateOutputDirFlags(flags), "%+v", *flags)
+	}
+}
+
+func TestOutputDestination(t *testing.T) {
+	destination := outputDestination(&config.GlobalFlags{
+		MultiFileOut: true,
+		OutputDir:    "results",
+		FailIfExists: true,
+	})
+
+	assert.True(t, destination.MultiFileOut)
+	assert.Equal(t, sinks.OutputLocation{OutputDir: "results", ExistingFiles: sinks.FailIfExists},
+		destination.Location)
+}
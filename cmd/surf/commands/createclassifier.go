@@ -26,11 +26,17 @@ type CreateClassifierCmd struct {
 	Trainer        ClassifierTrainer
 	ClassifierName string
 	SamplesArchive *os.File
+	// SamplesDirectory, if set, is a directory with a subdirectory of samples for each
+	// document type, from which the samples archive is created in place of SamplesArchive.
+	// The number of samples of each document type is reported to Output.
+	SamplesDirectory string
+	Output           io.Writer
 }
 
 type createClassifierArgs struct {
 	classifierName         string
 	samplesArchiveFilename string
+	samplesDirectory       string
 }
 
 func ConfigureCreateClassifierCmd(ctx context.Context, createCmd *kingpin.CmdClause,
@@ -40,17 +46,16 @@ func ConfigureCreateClassifierCmd(ctx context.Context, createCmd *kingpin.CmdCla
 
 	createClassifierCli := createCmd.Command("classifier", "Create waives classifier from a set of samples.").
 		Action(func(parseContext *kingpin.ParseContext) error {
-			return ExecuteWithMessage(fmt.Sprintf("Creating classifier '%s'... ",
-				args.classifierName),
-				func() error {
-					err := createClassifierCmd.initFromArgs(args, flags)
+			if (args.samplesArchiveFilename == "") == (args.samplesDirectory == "") {
+				return errors.New("either a samples zip file or --from-dir must be specified")
+			}
 
-					if err != nil {
-						return err
-					}
+			err := createClassifierCmd.initFromArgs(args, flags)
+			if err != nil {
+				return err
+			}
 
-					return createClassifierCmd.Execute(ctx)
-				})
+			return createClassifierCmd.Execute(ctx)
 		})
 
 	createClassifierCli.
@@ -60,39 +65,65 @@ func ConfigureCreateClassifierCmd(ctx context.Context, createCmd *kingpin.CmdCla
 
 	createClassifierCli.
 		Arg("samples-zip", "The zip file containing training samples.").
-		Required().
 		StringVar(&args.samplesArchiveFilename)
+
+	createClassifierCli.
+		Flag("from-dir", "Create the classifier from the samples in a directory, which has a "+
+			"subdirectory for each document type.").
+		PlaceHolder("dir").
+		StringVar(&args.samplesDirectory)
 }
 
 func (cmd *CreateClassifierCmd) Execute(ctx context.Context) error {
+	if cmd.SamplesDirectory != "" {
+		// the samples are checked (and counted) before the classifier is created
+		archiveFilename, err := createSamplesArchive(cmd.SamplesDirectory, cmd.Output)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(archiveFilename)
+
+		cmd.SamplesArchive, err = os.Open(archiveFilename)
+		if err != nil {
+			return err
+		}
+	}
 	defer cmd.SamplesArchive.Close()
 
-	err := cmd.Creator.Create(ctx, cmd.ClassifierName)
-	if err != nil {
-		return err
-	}
+	return ExecuteWithMessage(fmt.Sprintf("Creating classifier '%s'... ", cmd.ClassifierName),
+		func() error {
+			err := cmd.Creator.Create(ctx, cmd.ClassifierName)
+			if err != nil {
+				return err
+			}
 
-	err = cmd.Trainer.Train(ctx, cmd.ClassifierName, cmd.SamplesArchive)
+			err = cmd.Trainer.Train(ctx, cmd.ClassifierName, cmd.SamplesArchive)
 
-	if err != nil {
-		_ = cmd.Deleter.Delete(ctx, cmd.ClassifierName)
-		return err
-	}
+			if err != nil {
+				_ = cmd.Deleter.Delete(ctx, cmd.ClassifierName)
+				return err
+			}
 
-	return nil
+			return nil
+		})
 }
 
 func (cmd *CreateClassifierCmd) initFromArgs(args *createClassifierArgs, flags *config.GlobalFlags) error {
-	var err error
-	cmd.SamplesArchive, err = os.Open(args.samplesArchiveFilename)
-	if err != nil {
-		// err is guaranteed to be os.PathError
-		pathErr := err.(*os.PathError)
-		return errors.Errorf("failed to open samples archive '%s': %v",
-			args.samplesArchiveFilename, pathErr.Err.Error())
+	cmd.SamplesDirectory = args.samplesDirectory
+	cmd.Output = os.Stderr
+
+	if cmd.SamplesDirectory == "" {
+		var err error
+		cmd.SamplesArchive, err = os.Open(args.samplesArchiveFilename)
+		if err != nil {
+			// err is guaranteed to be os.PathError
+			pathErr := err.(*os.PathError)
+			return errors.Errorf("failed to open samples archive '%s': %v",
+				args.samplesArchiveFilename, pathErr.Err.Error())
+		}
 	}
 
-	client, err := initApiClient(flags.ClientId, flags.ClientSecret, flags.LogHttp)
+	client, err := initApiClient(flags)
 
 	if err != nil {
 		return err
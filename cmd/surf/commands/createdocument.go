@@ -87,7 +87,7 @@ func (cmd *CreateDocumentCmd) createFromFile(ctx context.Context,
 
 func (cmd *CreateDocumentCmd) initFromArgs(args *createDocumentArgs, flags *config.GlobalFlags) error {
 
-	client, err := initApiClient(flags.ClientId, flags.ClientSecret, flags.LogHttp)
+	client, err := initApiClient(flags)
 
 	if err != nil {
 		return err
@@ -9,7 +9,6 @@ import (
 	"github.com/waives/surf/config"
 	"github.com/waives/surf/net"
 	"gopkg.in/alecthomas/kingpin.v2"
-	"os"
 	"strings"
 )
 
@@ -110,27 +109,18 @@ func (cmd *CreateExtractorCmd) initFromModuleIdArgs(args *createExtractorArgs, f
 }
 
 func (cmd *CreateExtractorCmd) initFromTemplateArgs(args *createExtractorArgs, flags *config.GlobalFlags) error {
-	templateFile, err := os.Open(args.templateFilename)
+	var err error
+	cmd.Template, err = ch360.NewModulesTemplateFromFile(args.templateFilename)
 
 	if err != nil {
-		// err is guaranteed to be os.PathError
-		pathErr := err.(*os.PathError)
-		return errors.Errorf("failed to open template file '%s': %v", args.templateFilename,
-			pathErr.Err.Error())
-	}
-
-	cmd.Template, err = ch360.NewModulesTemplateFromJson(templateFile)
-
-	if err != nil {
-		return errors.WithMessagef(err, "failed to read json template '%s'",
-			args.templateFilename)
+		return err
 	}
 
 	return cmd.initFromArgs(args, flags)
 }
 
 func (cmd *CreateExtractorCmd) initFromArgs(args *createExtractorArgs, flags *config.GlobalFlags) error {
-	client, err := initApiClient(flags.ClientId, flags.ClientSecret, flags.LogHttp)
+	client, err := initApiClient(flags)
 
 	if err != nil {
 		return err
@@ -142,52 +132,55 @@ func (cmd *CreateExtractorCmd) initFromArgs(args *createExtractorArgs, flags *co
 }
 
 func buildDetailedErrorMessage(errorResponse net.DetailedErrorResponse) error {
-	//noinspection ALL odd names to match json
-	type detailedError struct {
-		Module_ID      string
-		Messages       []string
-		Path           string
-		Argument_Name  string
-		Argument_Value string
-	}
-
-	var detailedErrs []detailedError
+	var detailedErrs []ch360.TemplateError
 	err := mapstructure.Decode(errorResponse.Errors, &detailedErrs)
 
 	if err != nil {
 		return errors.WithMessage(&errorResponse, "could not deserialise response from server")
 	}
 
+	return buildTemplateErrorMessage(
+		fmt.Sprintf("Extractor creation failed with the following error: %s\n", errorResponse.Error()),
+		detailedErrs)
+}
+
+// buildTemplateErrorMessage builds an error describing problems with an extractor
+// template, grouped by module.
+func buildTemplateErrorMessage(header string, templateErrs []ch360.TemplateError) error {
 	sb := strings.Builder{}
-	sb.WriteString("Extractor creation failed with the following error: ")
-	sb.WriteString(fmt.Sprintf("%s\n", errorResponse.Error()))
-
-	// group error info by module
-	errorsByModule := map[string][]detailedError{}
-	for _, detailedErr := range detailedErrs {
-		moduleId := detailedErr.Module_ID
-		errorsByModule[moduleId] = append(errorsByModule[moduleId], detailedErr)
+	sb.WriteString(header)
+
+	// group error info by module, in the order the modules are first mentioned
+	var moduleIds []string
+	errorsByModule := map[string][]ch360.TemplateError{}
+	for _, templateErr := range templateErrs {
+		moduleId := templateErr.ModuleID
+		if _, found := errorsByModule[moduleId]; !found {
+			moduleIds = append(moduleIds, moduleId)
+		}
+		errorsByModule[moduleId] = append(errorsByModule[moduleId], templateErr)
 	}
 
-	for moduleId, detailedErrs := range errorsByModule {
+	for _, moduleId := range moduleIds {
+		templateErrs := errorsByModule[moduleId]
 		if moduleId == "" {
 			moduleId = "(not found)"
 		}
 
 		sb.WriteString(fmt.Sprintf("\nModule %s:\n", moduleId))
-		for _, detailedErr := range detailedErrs {
+		for _, templateErr := range templateErrs {
 
-			if detailedErr.Argument_Name != "" {
+			if templateErr.ArgumentName != "" {
 				// param err
-				for _, message := range detailedErr.Messages {
+				for _, message := range templateErr.Messages {
 					sb.WriteString(fmt.Sprintf("  Parameter \"%s\": %s (specified \"%s\")\n",
-						detailedErr.Argument_Name,
+						templateErr.ArgumentName,
 						message,
-						detailedErr.Argument_Value))
+						templateErr.ArgumentValue))
 				}
 			} else {
 				// module err
-				sb.WriteString(fmt.Sprintf("  %s\n", strings.Join(detailedErr.Messages, ", ")))
+				sb.WriteString(fmt.Sprintf("  %s\n", strings.Join(templateErr.Messages, ", ")))
 			}
 		}
 	}
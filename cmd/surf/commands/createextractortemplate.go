@@ -18,7 +18,10 @@ import (
 type CreateExtractorTemplateCmd struct {
 	Client    ModuleGetter
 	ModuleIds []string
-	Output    io.Writer
+	// OutputFile, if set, is the file to which the template is written. It is only replaced
+	// once the template has been written. Otherwise, the template is written to Output.
+	OutputFile string
+	Output     io.Writer
 }
 
 type createExtractorTemplateArgs struct {
@@ -85,9 +88,10 @@ func (cmd CreateExtractorTemplateCmd) Execute(ctx context.Context) error {
 		return errors.WithMessage(err, "unable to create template")
 	}
 
-	_, err = cmd.Output.Write(jsonData)
-
-	return err
+	return writeOutput(cmd.OutputFile, cmd.Output, func(out io.Writer) error {
+		_, err := out.Write(jsonData)
+		return err
+	})
 }
 
 func (cmd CreateExtractorTemplateCmd) getSpecifiedModules(existingModules ch360.ModuleList) (ch360.ModuleList, error) {
@@ -142,22 +146,15 @@ func (cmd *CreateExtractorTemplateCmd) initFromArgs(args *createExtractorTemplat
 	flags *config.GlobalFlags) error {
 	cmd.ModuleIds = args.moduleIds
 
-	client, err := initApiClient(flags.ClientId, flags.ClientSecret, flags.LogHttp)
+	client, err := initApiClient(flags)
 
 	if err != nil {
 		return err
 	}
 
 	cmd.Client = client.Modules
+	cmd.OutputFile = args.outputFile
 	cmd.Output = os.Stdout
 
-	if args.outputFile != "" {
-		cmd.Output, err = os.Create(args.outputFile)
-
-		if err != nil {
-			return err
-		}
-	}
-
 	return nil
 }
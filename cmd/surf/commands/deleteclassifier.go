@@ -75,7 +75,7 @@ func (cmd *DeleteClassifierCmd) initFromArgs(args *deleteClassifierArgs,
 	flags *config.GlobalFlags) error {
 	cmd.ClassifierName = args.classifierName
 
-	client, err := initApiClient(flags.ClientId, flags.ClientSecret, flags.LogHttp)
+	client, err := initApiClient(flags)
 
 	if err != nil {
 		return err
@@ -19,12 +19,16 @@ type DocumentDeleterGetter interface {
 type deleteDocumentArgs struct {
 	documentIds []string
 	deleteAll   bool
+	filterArgs  documentFilterArgs
 }
 
+// DeleteDocumentCmd deletes the specified documents, or all documents if DeleteAll is set.
+// If Filter is set, only the documents matching it are deleted.
 type DeleteDocumentCmd struct {
 	Client      DocumentDeleterGetter
 	DocumentIDs []string
 	DeleteAll   bool
+	Filter      ch360.DocumentFilter
 }
 
 // ConfigureDeleteDocumentCmd configures kingpin with the 'delete document' command.
@@ -40,6 +44,9 @@ func ConfigureDeleteDocumentCmd(ctx context.Context, deleteCmd *kingpin.CmdClaus
 			if args.deleteAll {
 				msg = "Deleting all documents... "
 			}
+			if !args.filterArgs.filter().IsEmpty() {
+				msg = "Deleting matching documents... "
+			}
 			return ExecuteWithMessage(msg,
 				func() error {
 					err := deleteDocumentCmd.initFromArgs(args, flags)
@@ -58,9 +65,14 @@ func ConfigureDeleteDocumentCmd(ctx context.Context, deleteCmd *kingpin.CmdClaus
 		Flag("all", "Delete all documents.").
 		BoolVar(&args.deleteAll)
 
+	addDocumentFilterFlagsTo(&args.filterArgs, deleteDocumentCli)
+
 	deleteDocumentCli.PreAction(func(parseContext *kingpin.ParseContext) error {
-		if !args.deleteAll && len(args.documentIds) == 0 {
-			return errors.New("Please specify either --all or the document IDs to delete.")
+		filterSpecified := !args.filterArgs.filter().IsEmpty()
+
+		if !args.deleteAll && len(args.documentIds) == 0 && !filterSpecified {
+			return errors.New("Please specify either --all, a filter (e.g. --type) or the" +
+				" document IDs to delete.")
 		}
 
 		if args.deleteAll && len(args.documentIds) > 0 {
@@ -74,21 +86,28 @@ func ConfigureDeleteDocumentCmd(ctx context.Context, deleteCmd *kingpin.CmdClaus
 
 // Execute is the entry point of the 'delete documents' command.
 func (cmd *DeleteDocumentCmd) Execute(ctx context.Context) error {
-	allDocIds, err := cmd.retrieveAllDocumentIds(ctx)
+	allDocs, err := cmd.Client.GetAll(ctx)
 	if err != nil {
 		return err
 	}
 
-	if cmd.DeleteAll {
-		cmd.DocumentIDs = allDocIds
+	allDocIds := documentIds(allDocs)
+
+	var docIdsToDelete []string
+
+	if cmd.DeleteAll || (len(cmd.DocumentIDs) == 0 && !cmd.Filter.IsEmpty()) {
+		docIdsToDelete = allDocIds
 	} else {
 		err = cmd.checkProvidedDocuments(allDocIds)
 		if err != nil {
 			return err
 		}
+		docIdsToDelete = cmd.DocumentIDs
 	}
 
-	for _, docId := range cmd.DocumentIDs {
+	docIdsToDelete = cmd.applyFilter(allDocs, docIdsToDelete)
+
+	for _, docId := range docIdsToDelete {
 		err := cmd.Client.Delete(ctx, docId)
 
 		if err != nil {
@@ -99,23 +118,40 @@ func (cmd *DeleteDocumentCmd) Execute(ctx context.Context) error {
 	return nil
 }
 
-func (cmd *DeleteDocumentCmd) retrieveAllDocumentIds(ctx context.Context) ([]string, error) {
-	allDocuments, err := cmd.Client.GetAll(ctx)
-	if err != nil {
-		return nil, err
+// applyFilter returns those of the provided document ids whose documents match cmd.Filter.
+func (cmd *DeleteDocumentCmd) applyFilter(allDocs ch360.DocumentList, docIds []string) []string {
+	if cmd.Filter.IsEmpty() {
+		return docIds
 	}
+
+	matchingDocIds := map[string]bool{}
+	for _, doc := range allDocs.Filter(cmd.Filter) {
+		matchingDocIds[doc.Id] = true
+	}
+
+	var filtered []string
+	for _, docId := range docIds {
+		if matchingDocIds[docId] {
+			filtered = append(filtered, docId)
+		}
+	}
+	return filtered
+}
+
+func documentIds(documents ch360.DocumentList) []string {
 	var docIds []string
-	for _, doc := range allDocuments {
+	for _, doc := range documents {
 		docIds = append(docIds, doc.Id)
 	}
-	return docIds, nil
+	return docIds
 }
 
 func (cmd *DeleteDocumentCmd) initFromArgs(args *deleteDocumentArgs, flags *config.GlobalFlags) error {
 	cmd.DocumentIDs = args.documentIds
 	cmd.DeleteAll = args.deleteAll
+	cmd.Filter = args.filterArgs.filter()
 
-	client, err := initApiClient(flags.ClientId, flags.ClientSecret, flags.LogHttp)
+	client, err := initApiClient(flags)
 
 	if err != nil {
 		return err
@@ -66,7 +66,7 @@ func (cmd *DeleteExtractorCmd) Execute(ctx context.Context) error {
 func (cmd *DeleteExtractorCmd) initFromArgs(args *deleteExtractorArgs, flags *config.GlobalFlags) error {
 	cmd.ExtractorName = args.extractorName
 
-	client, err := initApiClient(flags.ClientId, flags.ClientSecret, flags.LogHttp)
+	client, err := initApiClient(flags)
 
 	if err != nil {
 		return err
@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/config"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"io"
+	"os"
+	"strconv"
+)
+
+// DescribeModuleCmd shows the full details of an extractor module, including
+// the parameters it accepts and the fields it outputs.
+type DescribeModuleCmd struct {
+	Client   ModuleGetter
+	ModuleID string
+	Output   io.Writer
+}
+
+// ConfigureDescribeModuleCmd configures kingpin with the 'describe module' command.
+func ConfigureDescribeModuleCmd(ctx context.Context, describeCmd *kingpin.CmdClause,
+	flags *config.GlobalFlags) {
+	describeModuleCmd := &DescribeModuleCmd{}
+
+	describeModuleCli := describeCmd.Command("module",
+		"Show the parameters and fields of an extractor module.").
+		Action(func(parseContext *kingpin.ParseContext) error {
+			err := describeModuleCmd.initFromArgs(flags)
+
+			if err != nil {
+				return err
+			}
+
+			return describeModuleCmd.Execute(ctx)
+		})
+
+	describeModuleCli.
+		Arg("id", "The ID of the module to describe.").
+		Required().
+		StringVar(&describeModuleCmd.ModuleID)
+}
+
+// Execute runs the 'describe module' command.
+func (cmd *DescribeModuleCmd) Execute(ctx context.Context) error {
+	modules, err := cmd.Client.GetAll(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	module := modules.Find(cmd.ModuleID)
+	if module == nil {
+		return errors.Errorf("There is no module with ID '%s'.", cmd.ModuleID)
+	}
+
+	fmt.Fprintf(cmd.Output, "ID:          %s\n", module.ID)
+	fmt.Fprintf(cmd.Output, "Name:        %s\n", module.Name)
+	fmt.Fprintf(cmd.Output, "Summary:     %s\n", module.Summary)
+	if module.Description != "" {
+		fmt.Fprintf(cmd.Output, "Description: %s\n", module.Description)
+	}
+
+	fmt.Fprintln(cmd.Output, "\nParameters:")
+	if len(module.Parameters) == 0 {
+		fmt.Fprintln(cmd.Output, "  (none)")
+	} else {
+		table := NewTable(cmd.Output, []string{"ID", "Name", "Type", "Required", "Description"})
+		for _, parameter := range module.Parameters {
+			table.Append([]string{parameter.ID, parameter.Name, parameter.Type,
+				strconv.FormatBool(parameter.Required), parameter.Description})
+		}
+		table.Render()
+	}
+
+	fmt.Fprintln(cmd.Output, "\nFields:")
+	if len(module.Fields) == 0 {
+		fmt.Fprintln(cmd.Output, "  (none)")
+	} else {
+		table := NewTable(cmd.Output, []string{"Name", "Description"})
+		for _, field := range module.Fields {
+			table.Append([]string{field.Name, fieldDescription(field)})
+		}
+		table.Render()
+	}
+
+	return nil
+}
+
+func (cmd *DescribeModuleCmd) initFromArgs(flags *config.GlobalFlags) error {
+	apiClient, err := initApiClient(flags)
+
+	if err != nil {
+		return err
+	}
+
+	cmd.Client = apiClient.Modules
+	cmd.Output = os.Stdout
+	return nil
+}
+
+// fieldDescription renders the description of a module field, which the API
+// returns as null when there isn't one.
+func fieldDescription(field ch360.ModuleField) string {
+	if field.Description == nil {
+		return ""
+	}
+	return fmt.Sprint(field.Description)
+}
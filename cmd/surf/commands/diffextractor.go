@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/config"
+	"github.com/waives/surf/output/diff"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"io"
+	"os"
+)
+
+// DiffExtractorCmd compares the definition of an extractor in waives with a local
+// template file, and writes out the differences as a unified diff.
+type DiffExtractorCmd struct {
+	Client           ExtractorTemplateGetter
+	ExtractorName    string
+	Template         *ch360.ExtractorTemplate
+	TemplateFilename string
+	Output           io.Writer
+}
+
+type diffExtractorArgs struct {
+	extractorName    string
+	templateFilename string
+}
+
+// ConfigureDiffExtractorCmd configures kingpin with the 'diff extractor' command.
+func ConfigureDiffExtractorCmd(ctx context.Context, diffCmd *kingpin.CmdClause,
+	flags *config.GlobalFlags) {
+	args := &diffExtractorArgs{}
+	diffExtractorCmd := &DiffExtractorCmd{}
+
+	diffExtractorCli := diffCmd.Command("extractor",
+		"Show the differences between a waives extractor and an extractor template.").
+		Action(func(parseContext *kingpin.ParseContext) error {
+			err := diffExtractorCmd.initFromArgs(args, flags)
+
+			if err != nil {
+				return err
+			}
+
+			return diffExtractorCmd.Execute(ctx)
+		})
+
+	diffExtractorCli.
+		Arg("name", "The name of the extractor.").
+		Required().
+		StringVar(&args.extractorName)
+
+	diffExtractorCli.
+		Arg("template-file", "The extraction template file (json).").
+		Required().
+		StringVar(&args.templateFilename)
+}
+
+// Execute runs the 'diff extractor' command.
+func (cmd *DiffExtractorCmd) Execute(ctx context.Context) error {
+	existingTemplate, err := cmd.Client.GetTemplate(ctx, cmd.ExtractorName)
+
+	if err != nil {
+		return errors.WithMessagef(err, "failed to get extractor '%s'", cmd.ExtractorName)
+	}
+
+	existingJson, err := marshalExtractorTemplate(existingTemplate)
+	if err != nil {
+		return err
+	}
+
+	templateJson, err := marshalExtractorTemplate(cmd.Template)
+	if err != nil {
+		return err
+	}
+
+	differs, err := diff.WriteUnified(cmd.Output,
+		"extractor/"+cmd.ExtractorName,
+		cmd.TemplateFilename,
+		string(existingJson),
+		string(templateJson))
+
+	if err == nil && !differs {
+		_, err = fmt.Fprintf(cmd.Output, "Extractor '%s' matches %s.\n", cmd.ExtractorName,
+			cmd.TemplateFilename)
+	}
+
+	return err
+}
+
+func (cmd *DiffExtractorCmd) initFromArgs(args *diffExtractorArgs, flags *config.GlobalFlags) error {
+	var err error
+
+	cmd.ExtractorName = args.extractorName
+	cmd.TemplateFilename = args.templateFilename
+	cmd.Template, err = ch360.NewModulesTemplateFromFile(args.templateFilename)
+
+	if err != nil {
+		return err
+	}
+
+	client, err := initApiClient(flags)
+
+	if err != nil {
+		return err
+	}
+
+	cmd.Client = client.Extractors
+	cmd.Output = os.Stdout
+	return nil
+}
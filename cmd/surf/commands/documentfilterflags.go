@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"github.com/alecthomas/units"
+	"github.com/waives/surf/ch360"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type documentFilterArgs struct {
+	fileType   string
+	sha256     string
+	largerThan units.Base2Bytes
+}
+
+func addDocumentFilterFlagsTo(args *documentFilterArgs, cmdClause *kingpin.CmdClause) {
+	cmdClause.Flag("type", "Only include documents of the specified file type (e.g. pdf, tiff).").
+		PlaceHolder("type").
+		StringVar(&args.fileType)
+	cmdClause.Flag("sha256", "Only include documents with the specified SHA256 hash.").
+		PlaceHolder("hash").
+		StringVar(&args.sha256)
+	cmdClause.Flag("larger-than", "Only include documents larger than the specified size (e."+
+		"g. 500KB, 2MB).").
+		PlaceHolder("size").
+		BytesVar(&args.largerThan)
+}
+
+func (args *documentFilterArgs) filter() ch360.DocumentFilter {
+	return ch360.DocumentFilter{
+		FileType:   args.fileType,
+		Sha256:     args.sha256,
+		LargerThan: int(args.largerThan),
+	}
+}
@@ -0,0 +1,224 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/cmd/surf/services"
+	"github.com/waives/surf/config"
+	"github.com/waives/surf/evaluation"
+	"github.com/waives/surf/fs"
+	"github.com/waives/surf/output/progress"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// EvaluateClassifierCmd classifies a set of files of known document types, and
+// reports how well the classifier performed.
+type EvaluateClassifierCmd struct {
+	ClassificationService ClassificationService
+	Evaluator             *evaluation.ClassificationEvaluator
+	FilePaths             []string
+	ClassifierName        string
+	OutputFormat          string
+	// OutputFile, if set, is the file to which the report is written. It is only replaced
+	// once the report is complete. Otherwise, the report is written to Output.
+	OutputFile string
+	Output     io.Writer
+}
+
+type evaluateClassifierArgs struct {
+	classifierName   string
+	samplesDirectory string
+	outputFormat     string
+}
+
+// ConfigureEvaluateClassifierCmd configures kingpin with the 'evaluate classifier' command.
+func ConfigureEvaluateClassifierCmd(ctx context.Context, evaluateCmd *kingpin.CmdClause,
+	flags *config.GlobalFlags) {
+	args := &evaluateClassifierArgs{}
+	evaluateClassifierCmd := &EvaluateClassifierCmd{}
+
+	evaluateClassifierCli := evaluateCmd.Command("classifier",
+		"Measure the accuracy of a classifier against files of known document types.").
+		Action(func(parseContext *kingpin.ParseContext) error {
+			err := evaluateClassifierCmd.initFromArgs(args, flags)
+			if err != nil {
+				return err
+			}
+
+			return evaluateClassifierCmd.Execute(ctx)
+		})
+
+	evaluateClassifierCli.
+		Arg("name", "The name of the classifier to evaluate.").
+		Required().
+		StringVar(&args.classifierName)
+
+	evaluateClassifierCli.
+		Arg("dir", "A directory with a subdirectory of files for each document type.").
+		Required().
+		StringVar(&args.samplesDirectory)
+
+	evaluateClassifierCli.Flag("format", "The output format. Allowed values: table, json "+
+		"[default: table].").
+		Short('f').
+		Default("table").
+		EnumVar(&args.outputFormat, "table", "json")
+
+	addEvaluationOutputFlagsTo(flags, evaluateClassifierCli)
+}
+
+// Execute runs the 'evaluate classifier' command.
+func (cmd *EvaluateClassifierCmd) Execute(ctx context.Context) error {
+	err := cmd.ClassificationService.ClassifyAll(ctx, cmd.FilePaths, cmd.ClassifierName)
+
+	if err != nil {
+		return errors.Wrap(err, "classification failed")
+	}
+
+	report := cmd.Evaluator.Report()
+
+	return writeOutput(cmd.OutputFile, cmd.Output, func(out io.Writer) error {
+		if cmd.OutputFormat == "json" {
+			return writeJsonReport(out, report)
+		}
+
+		return writeClassificationReportTable(out, report)
+	})
+}
+
+func writeClassificationReportTable(out io.Writer, report *evaluation.ClassificationReport) error {
+	_, err := fmt.Fprintf(out, "Accuracy: %s (%d/%d)\n\n", percentage(report.Accuracy),
+		report.Correct, report.Total)
+	if err != nil {
+		return err
+	}
+
+	metricsTable := NewTable(out, []string{"Document Type", "Files", "Precision", "Recall", "F1"})
+	for _, metrics := range report.DocumentTypes {
+		metricsTable.Append([]string{
+			metrics.DocumentType,
+			strconv.Itoa(metrics.Support),
+			percentage(metrics.Precision),
+			percentage(metrics.Recall),
+			fmt.Sprintf("%.3f", metrics.F1),
+		})
+	}
+	metricsTable.Render()
+
+	_, err = fmt.Fprintln(out, "\nConfusion matrix (rows: expected, columns: classified as)")
+	if err != nil {
+		return err
+	}
+
+	matrix := report.ConfusionMatrix
+	matrixTable := NewTable(out, append([]string{""}, matrix.DocumentTypes...))
+	for i, documentType := range matrix.DocumentTypes {
+		row := []string{documentType}
+		for _, count := range matrix.Counts[i] {
+			row = append(row, strconv.Itoa(count))
+		}
+		matrixTable.Append(row)
+	}
+	matrixTable.Render()
+
+	_, err = fmt.Fprintf(out, "\nConfident results:     %d (%s of files), %s accurate\n"+
+		"Not confident results: %d (%s of files), %s accurate\n",
+		report.Confident.Total, percentage(report.Confident.Coverage),
+		percentage(report.Confident.Accuracy),
+		report.NotConfident.Total, percentage(report.NotConfident.Coverage),
+		percentage(report.NotConfident.Accuracy))
+
+	return err
+}
+
+func (cmd *EvaluateClassifierCmd) initFromArgs(args *evaluateClassifierArgs, flags *config.GlobalFlags) error {
+	expectedTypes, err := readLabelledSamples(args.samplesDirectory)
+	if err != nil {
+		return err
+	}
+
+	cmd.FilePaths = nil
+	for filename := range expectedTypes {
+		cmd.FilePaths = append(cmd.FilePaths, filename)
+	}
+	sort.Strings(cmd.FilePaths)
+
+	client, err := initApiClient(flags)
+	if err != nil {
+		return err
+	}
+
+	cmd.OutputFile = flags.OutputFile
+	cmd.Output = os.Stdout
+
+	cmd.Evaluator = evaluation.NewClassificationEvaluator(expectedTypes)
+	progressHandler := progress.NewProgressHandler(cmd.Evaluator, flags.ShowProgress, os.Stderr)
+	fileClassifier := ch360.NewFileClassifier(client.Documents, client.Documents, client.Documents)
+
+	cmd.ClassificationService = services.NewParallelClassificationService(fileClassifier,
+		client.Documents,
+		progressHandler)
+	cmd.ClassifierName = args.classifierName
+	cmd.OutputFormat = args.outputFormat
+
+	return nil
+}
+
+// readLabelledSamples returns the supported files in a labelled directory tree (see
+// fs.ReadLabelledTree), mapped to their labels.
+func readLabelledSamples(samplesDirectory string) (map[string]string, error) {
+	samples, err := fs.ReadLabelledTree(samplesDirectory)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to read directory '%s'", samplesDirectory)
+	}
+
+	labels := map[string]string{}
+	for _, sample := range samples {
+		if ch360.IsSupportedSample(sample.Path) {
+			labels[sample.Path] = sample.Label
+		}
+	}
+
+	if len(labels) == 0 {
+		return nil, errors.Errorf("no files were found in the subdirectories of '%s'",
+			samplesDirectory)
+	}
+
+	return labels, nil
+}
+
+func addEvaluationOutputFlagsTo(globalFlags *config.GlobalFlags, cmdClause *kingpin.CmdClause) {
+	cmdClause.Flag("output-file", "Write the report to the specified file").
+		Short('o').
+		PlaceHolder("file").
+		StringVar(&globalFlags.OutputFile)
+	cmdClause.Flag("progress", "Show a progress bar (only for use with -o).").
+		Short('p').
+		BoolVar(&globalFlags.ShowProgress)
+
+	cmdClause.Validate(func(clause *kingpin.CmdClause) error {
+		if globalFlags.ShowProgress && !globalFlags.CanShowProgressBar() {
+			return errors.New("The --progress / -p option can only be used when " +
+				"redirecting stdout, or in combination with -o.")
+		}
+		return nil
+	})
+}
+
+func writeJsonReport(out io.Writer, report interface{}) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(report)
+}
+
+func percentage(proportion float64) string {
+	return fmt.Sprintf("%.1f%%", proportion*100)
+}
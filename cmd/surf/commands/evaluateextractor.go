@@ -0,0 +1,230 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/cmd/surf/services"
+	"github.com/waives/surf/config"
+	"github.com/waives/surf/evaluation"
+	"github.com/waives/surf/fs"
+	"github.com/waives/surf/output/progress"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// EvaluateExtractorCmd extracts data from a set of files with known field values,
+// and reports how well the extractor performed.
+type EvaluateExtractorCmd struct {
+	ExtractionService ExtractionService
+	Evaluator         *evaluation.ExtractionEvaluator
+	FilePaths         []string
+	ExtractorName     string
+	WorstDocuments    int
+	OutputFormat      string
+	// OutputFile, if set, is the file to which the report is written. It is only replaced
+	// once the report is complete. Otherwise, the report is written to Output.
+	OutputFile string
+	Output     io.Writer
+}
+
+type evaluateExtractorArgs struct {
+	extractorName  string
+	directory      string
+	truthFilename  string
+	matchMode      string
+	fuzzyThreshold float64
+	worstDocuments int
+	outputFormat   string
+}
+
+// ConfigureEvaluateExtractorCmd configures kingpin with the 'evaluate extractor' command.
+func ConfigureEvaluateExtractorCmd(ctx context.Context, evaluateCmd *kingpin.CmdClause,
+	flags *config.GlobalFlags) {
+	args := &evaluateExtractorArgs{}
+	evaluateExtractorCmd := &EvaluateExtractorCmd{}
+
+	evaluateExtractorCli := evaluateCmd.Command("extractor",
+		"Measure the accuracy of an extractor against files with known field values.").
+		Action(func(parseContext *kingpin.ParseContext) error {
+			err := evaluateExtractorCmd.initFromArgs(args, flags)
+			if err != nil {
+				return err
+			}
+
+			return evaluateExtractorCmd.Execute(ctx)
+		})
+
+	evaluateExtractorCli.
+		Arg("name", "The name of the extractor to evaluate.").
+		Required().
+		StringVar(&args.extractorName)
+
+	evaluateExtractorCli.
+		Arg("dir", "The directory containing the files listed in the ground truth.").
+		Required().
+		StringVar(&args.directory)
+
+	evaluateExtractorCli.Flag("truth", "A csv file with a header of 'file' followed by the "+
+		"field names, and a row of expected values for each file.").
+		Required().
+		PlaceHolder("file").
+		StringVar(&args.truthFilename)
+
+	evaluateExtractorCli.Flag("match", "How to compare extracted and expected values. "+
+		"Allowed values: exact, normalised, fuzzy [default: normalised].").
+		Default(string(evaluation.MatchNormalised)).
+		EnumVar(&args.matchMode, evaluation.MatchModes...)
+
+	evaluateExtractorCli.Flag("fuzzy-threshold", "The minimum similarity (0-1) of values "+
+		"considered to match by --match=fuzzy.").
+		Default("0.8").
+		Float64Var(&args.fuzzyThreshold)
+
+	evaluateExtractorCli.Flag("worst", "The number of documents with the most errors to list.").
+		Default("10").
+		IntVar(&args.worstDocuments)
+
+	evaluateExtractorCli.Flag("format", "The output format. Allowed values: table, json "+
+		"[default: table].").
+		Short('f').
+		Default("table").
+		EnumVar(&args.outputFormat, "table", "json")
+
+	addEvaluationOutputFlagsTo(flags, evaluateExtractorCli)
+}
+
+// Execute runs the 'evaluate extractor' command.
+func (cmd *EvaluateExtractorCmd) Execute(ctx context.Context) error {
+	err := cmd.ExtractionService.ExtractAll(ctx, cmd.FilePaths, cmd.ExtractorName)
+
+	if err != nil {
+		return errors.Wrap(err, "extraction failed")
+	}
+
+	report := cmd.Evaluator.Report(cmd.WorstDocuments)
+
+	return writeOutput(cmd.OutputFile, cmd.Output, func(out io.Writer) error {
+		if cmd.OutputFormat == "json" {
+			return writeJsonReport(out, report)
+		}
+
+		return writeExtractionReportTable(out, report)
+	})
+}
+
+func writeExtractionReportTable(out io.Writer, report *evaluation.ExtractionReport) error {
+	_, err := fmt.Fprintf(out, "Documents: %d\n\n", report.Documents)
+	if err != nil {
+		return err
+	}
+
+	fieldsTable := NewTable(out, []string{"Field", "Correct", "Incorrect", "Missed", "Spurious",
+		"Precision", "Recall", "Reject Rate"})
+	for _, metrics := range report.Fields {
+		fieldsTable.Append([]string{
+			metrics.Field,
+			strconv.Itoa(metrics.Correct),
+			strconv.Itoa(metrics.Incorrect),
+			strconv.Itoa(metrics.Missed),
+			strconv.Itoa(metrics.Spurious),
+			percentage(metrics.Precision),
+			percentage(metrics.Recall),
+			percentage(metrics.RejectRate),
+		})
+	}
+	fieldsTable.Render()
+
+	if len(report.WorstDocuments) == 0 {
+		return nil
+	}
+
+	_, err = fmt.Fprintln(out, "\nDocuments with the most errors")
+	if err != nil {
+		return err
+	}
+
+	documentsTable := NewTable(out, []string{"File", "Errors", "Fields"})
+	for _, document := range report.WorstDocuments {
+		documentsTable.Append([]string{
+			filepath.FromSlash(document.Filename),
+			strconv.Itoa(len(document.Fields)),
+			strings.Join(document.Fields, ", "),
+		})
+	}
+	documentsTable.Render()
+
+	return nil
+}
+
+func (cmd *EvaluateExtractorCmd) initFromArgs(args *evaluateExtractorArgs, flags *config.GlobalFlags) error {
+	truth, err := readGroundTruth(args.truthFilename, args.directory)
+	if err != nil {
+		return err
+	}
+
+	client, err := initApiClient(flags)
+	if err != nil {
+		return err
+	}
+
+	cmd.OutputFile = flags.OutputFile
+	cmd.Output = os.Stdout
+
+	cmd.Evaluator = evaluation.NewExtractionEvaluator(truth, evaluation.Matcher{
+		Mode:           evaluation.MatchMode(args.matchMode),
+		FuzzyThreshold: args.fuzzyThreshold,
+	})
+	progressHandler := progress.NewProgressHandler(cmd.Evaluator, flags.ShowProgress, os.Stderr)
+	fileExtractor := ch360.NewFileExtractor(client.Documents, client.Documents, client.Documents)
+
+	cmd.ExtractionService = services.NewParallelExtractionService(fileExtractor,
+		client.Documents,
+		progressHandler)
+	cmd.FilePaths = truth.Files
+	cmd.ExtractorName = args.extractorName
+	cmd.WorstDocuments = args.worstDocuments
+	cmd.OutputFormat = args.outputFormat
+
+	return nil
+}
+
+// readGroundTruth reads the ground truth csv, resolving the filenames in it relative
+// to directory.
+func readGroundTruth(truthFilename, directory string) (*evaluation.GroundTruth, error) {
+	truthFile, err := os.Open(truthFilename)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to open ground truth '%s'", truthFilename)
+	}
+	defer truthFile.Close()
+
+	truth, err := evaluation.ReadGroundTruth(truthFile)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to read ground truth '%s'", truthFilename)
+	}
+
+	resolvedValues := map[string]map[string]string{}
+	for i, filename := range truth.Files {
+		resolvedFilename := filepath.Join(directory, filepath.FromSlash(filename))
+
+		exists, err := fs.DirectoryOrFileExists(resolvedFilename)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, errors.Errorf("the file '%s' in the ground truth could not be found",
+				resolvedFilename)
+		}
+
+		resolvedValues[resolvedFilename] = truth.Values[filename]
+		truth.Files[i] = resolvedFilename
+	}
+	truth.Values = resolvedValues
+
+	return truth, nil
+}
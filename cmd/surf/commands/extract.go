@@ -9,6 +9,7 @@ import (
 	"github.com/waives/surf/output/progress"
 	"github.com/waives/surf/output/resultsWriters"
 	"gopkg.in/alecthomas/kingpin.v2"
+	"io"
 	"os"
 )
 
@@ -22,6 +23,8 @@ type ExtractCmd struct {
 	ExtractorName     string
 	FilePaths         []string
 	ExtractionService ExtractionService
+	// inputs are the files to process, which are closed once they have been processed
+	inputs io.Closer
 }
 
 //go:generate mockery -name "ExtractionService"
@@ -38,10 +41,12 @@ func ConfigureExtractCommand(ctx context.Context,
 	extractCli := app.
 		Command("extract", "Perform data extraction on a file or set of files.").
 		Action(func(parseContext *kingpin.ParseContext) error {
-			err := cmd.initWithArgs(args, globalFlags)
+			err := cmd.initWithArgs(ctx, args, globalFlags)
 			if err != nil {
 				return err
 			}
+			defer cmd.inputs.Close()
+
 			return cmd.Execute(ctx)
 		})
 
@@ -54,16 +59,17 @@ func ConfigureExtractCommand(ctx context.Context,
 		Required().
 		StringVar(&args.extractorName)
 
-	extractCli.Arg("files", "The files to read.").
-		Required().
+	extractCli.Arg("files", "The files to read, or '-' to read from stdin. "+
+		"URLs, and zip and tar archives, are also accepted.").
 		StringsVar(&args.filePatterns)
 
+	addInputFlagsTo(globalFlags, extractCli)
 	addFileHandlingFlagsTo(globalFlags, extractCli)
 }
 
-func (cmd *ExtractCmd) initWithArgs(args *extractArgs, flags *config.GlobalFlags) error {
-	resultsWriter, err := resultsWriters.NewExtractionResultsWriter(flags.MultiFileOut,
-		flags.OutputFile,
+func (cmd *ExtractCmd) initWithArgs(ctx context.Context, args *extractArgs,
+	flags *config.GlobalFlags) error {
+	resultsWriter, err := resultsWriters.NewExtractionResultsWriter(outputDestination(flags),
 		args.outputFormat)
 
 	if err != nil {
@@ -73,15 +79,16 @@ func (cmd *ExtractCmd) initWithArgs(args *extractArgs, flags *config.GlobalFlags
 	progressHandler := progress.NewProgressHandler(resultsWriter,
 		flags.ShowProgress, os.Stderr)
 
-	cmd.FilePaths, err = GlobMany(args.filePatterns)
+	files, err := resolveInputs(ctx, args.filePatterns, flags)
 
 	if err != nil {
 		return err
 	}
 
-	client, err := initApiClient(flags.ClientId,
-		flags.ClientSecret,
-		flags.LogHttp)
+	cmd.FilePaths = files.Names()
+	cmd.inputs = files
+
+	client, err := initApiClient(flags)
 
 	if err != nil {
 		return err
@@ -90,7 +97,9 @@ func (cmd *ExtractCmd) initWithArgs(args *extractArgs, flags *config.GlobalFlags
 	fileExtractor := ch360.NewFileExtractor(client.Documents, client.Documents, client.Documents)
 
 	cmd.ExtractionService = services.NewParallelExtractionService(fileExtractor, client.Documents,
-		progressHandler)
+		progressHandler).
+		WithFileOpener(files.Open).
+		WithRecorder(flags.Telemetry)
 	cmd.ExtractorName = args.extractorName
 
 	return nil
@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/config"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"io"
+	"os"
+)
+
+//go:generate mockery -name "ExtractorTemplateGetter"
+type ExtractorTemplateGetter interface {
+	GetTemplate(ctx context.Context, name string) (*ch360.ExtractorTemplate, error)
+}
+
+// GetExtractorCmd retrieves the definition of an extractor from waives and writes it
+// out as a template, suitable for use with 'create extractor from-template' or
+// 'apply extractor'.
+type GetExtractorCmd struct {
+	Client        ExtractorTemplateGetter
+	ExtractorName string
+	// OutputFile, if set, is the file to which the template is written. It is only replaced
+	// once the template has been retrieved and written. Otherwise, the template is written
+	// to Output.
+	OutputFile string
+	Output     io.Writer
+}
+
+type getExtractorArgs struct {
+	extractorName string
+	outputFile    string
+}
+
+// ConfigureGetExtractorCmd configures kingpin with the 'get extractor' command.
+func ConfigureGetExtractorCmd(ctx context.Context, getCmd *kingpin.CmdClause,
+	flags *config.GlobalFlags) {
+	args := &getExtractorArgs{}
+	getExtractorCmd := &GetExtractorCmd{}
+
+	getExtractorCli := getCmd.Command("extractor", "Get the template of a waives extractor.").
+		Action(func(parseContext *kingpin.ParseContext) error {
+			err := getExtractorCmd.initFromArgs(args, flags)
+
+			if err != nil {
+				return err
+			}
+
+			return getExtractorCmd.Execute(ctx)
+		})
+
+	getExtractorCli.
+		Arg("name", "The name of the extractor.").
+		Required().
+		StringVar(&args.extractorName)
+
+	getExtractorCli.Flag("output-file", "Write the template to the specified file").
+		Short('o').
+		PlaceHolder("file").
+		StringVar(&args.outputFile)
+}
+
+// Execute runs the 'get extractor' command.
+func (cmd *GetExtractorCmd) Execute(ctx context.Context) error {
+	template, err := cmd.Client.GetTemplate(ctx, cmd.ExtractorName)
+
+	if err != nil {
+		return errors.WithMessagef(err, "failed to get extractor '%s'", cmd.ExtractorName)
+	}
+
+	jsonData, err := marshalExtractorTemplate(template)
+
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(cmd.OutputFile, cmd.Output, func(out io.Writer) error {
+		_, err := out.Write(jsonData)
+		return err
+	})
+}
+
+func (cmd *GetExtractorCmd) initFromArgs(args *getExtractorArgs, flags *config.GlobalFlags) error {
+	cmd.ExtractorName = args.extractorName
+
+	client, err := initApiClient(flags)
+
+	if err != nil {
+		return err
+	}
+
+	cmd.Client = client.Extractors
+	cmd.OutputFile = args.outputFile
+	cmd.Output = os.Stdout
+
+	return nil
+}
+
+// marshalExtractorTemplate serialises an extractor template to indented json. The
+// output is stable (map keys are sorted), so that templates can be compared textually
+// and kept under version control.
+func marshalExtractorTemplate(template *ch360.ExtractorTemplate) ([]byte, error) {
+	jsonData, err := json.MarshalIndent(template, "", "  ")
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "unable to serialise template")
+	}
+
+	return append(jsonData, '\n'), nil
+}
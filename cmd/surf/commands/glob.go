@@ -1,6 +1,13 @@
 package commands
 
-import "github.com/mattn/go-zglob"
+import (
+	"context"
+	"github.com/mattn/go-zglob"
+	"github.com/pkg/errors"
+	"github.com/waives/surf/config"
+	"github.com/waives/surf/inputs"
+	"os"
+)
 
 // GlobMany searches multiple patterns for files.
 func GlobMany(filePatterns []string) ([]string, error) {
@@ -20,3 +27,33 @@ func GlobMany(filePatterns []string) ([]string, error) {
 func Glob(filePattern string) ([]string, error) {
 	return zglob.Glob(filePattern)
 }
+
+// resolveInputs resolves the files to process from the file patterns (which may also
+// be '-' to read from stdin, http(s) URLs, or zip and tar archives) and the file list
+// specified with --files-from. Documents are downloaded from URLs with the configured
+// connection settings, and the downloads are cancelled when ctx is done.
+func resolveInputs(ctx context.Context, filePatterns []string,
+	flags *config.GlobalFlags) (*inputs.Inputs, error) {
+	if len(filePatterns) == 0 && flags.FilesFrom == "" {
+		return nil, errors.New("you must specify the files to process, or use --files-from")
+	}
+
+	downloadClient, err := initDownloadClient(flags)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := inputs.New(os.Stdin).WithDownloads(ctx, downloadClient)
+
+	if err := resolved.AddPatterns(filePatterns); err != nil {
+		return nil, err
+	}
+
+	if flags.FilesFrom != "" {
+		if err := resolved.AddList(flags.FilesFrom, flags.NullSeparated); err != nil {
+			return nil, err
+		}
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/waives/surf/config"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveInputs_Requires_Files(t *testing.T) {
+	_, err := resolveInputs(context.Background(), nil, &config.GlobalFlags{})
+
+	assert.EqualError(t, err, "you must specify the files to process, or use --files-from")
+}
+
+func TestResolveInputs_Adds_Patterns_And_Listed_Files(t *testing.T) {
+	dir, err := ioutil.TempDir("", "surf")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	globbed := filepath.Join(dir, "globbed.pdf")
+	listed := filepath.Join(dir, "listed.tif")
+	list := filepath.Join(dir, "list.txt")
+	require.NoError(t, ioutil.WriteFile(globbed, nil, 0666))
+	require.NoError(t, ioutil.WriteFile(listed, nil, 0666))
+	require.NoError(t, ioutil.WriteFile(list, []byte(listed+"\n"), 0666))
+
+	files, err := resolveInputs(context.Background(), []string{filepath.Join(dir, "*.pdf")},
+		&config.GlobalFlags{FilesFrom: list})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{globbed, listed}, files.Names())
+}
@@ -1,15 +1,19 @@
 package commands
 
 import (
+	"github.com/pkg/errors"
 	"github.com/waives/surf/ch360"
 	"github.com/waives/surf/config"
+	"github.com/waives/surf/net"
 	"io"
 	"net/http"
-	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
-func initApiClient(clientIdFlag, clientSecretFlag string, logHttpFile *os.File) (*ch360.ApiClient, error) {
+func initApiClient(flags *config.GlobalFlags) (*ch360.ApiClient, error) {
 	appDir, err := config.NewAppDirectory()
 	if err != nil {
 		return nil, err
@@ -17,17 +21,313 @@ func initApiClient(clientIdFlag, clientSecretFlag string, logHttpFile *os.File)
 
 	credentialsResolver := &CredentialsResolver{}
 
-	clientId, clientSecret, err := credentialsResolver.Resolve(clientIdFlag, clientSecretFlag, appDir)
+	clientId, clientSecret, err := credentialsResolver.Resolve(flags.ClientId, flags.ClientSecret, appDir)
 
 	if err != nil {
 		return nil, err
 	}
 
+	settings := readHttpSettings(appDir)
+
+	rateLimits, err := resolveRateLimits(flags, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	retryPolicies, err := resolveRetryPolicies(flags, settings.Retry)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, err := newHttpClient(flags, settings)
+	if err != nil {
+		return nil, err
+	}
+
 	var logSink io.Writer = nil
-	if logHttpFile != nil {
-		logSink = logHttpFile
+	if flags.LogHttp != nil {
+		logSink = flags.LogHttp
+	}
+	return ch360.NewApiClient(httpClient, ch360.ApiAddress, clientId, clientSecret,
+		logSink, ch360.HttpLogFormat(flags.LogHttpFormat), rateLimits, retryPolicies, flags.Telemetry), nil
+}
+
+// initDownloadClient returns the client which downloads input documents from URLs, with
+// the same connection settings and timeouts as API requests.
+func initDownloadClient(flags *config.GlobalFlags) (net.HttpDoer, error) {
+	appDir, err := config.NewAppDirectory()
+	if err != nil {
+		return nil, err
+	}
+
+	return newHttpClient(flags, readHttpSettings(appDir))
+}
+
+// readHttpSettings returns the http settings from the configuration file, if there is one.
+func readHttpSettings(configurationReader config.ConfigurationReader) config.HttpSettings {
+	configuration, err := configurationReader.ReadConfiguration()
+	if err != nil {
+		// the credentials may have been specified on the command line, in which
+		// case there need not be a configuration file
+		return config.HttpSettings{}
+	}
+
+	return configuration.Http
+}
+
+// resolveRateLimits returns the limits on API requests specified by the --max-rps and
+// --max-concurrency flags or, for those which are not specified, the configuration file.
+func resolveRateLimits(flags *config.GlobalFlags, settings config.HttpSettings) (net.RateLimits, error) {
+	if flags.MaxRps < 0 {
+		return net.RateLimits{}, errors.New("The --max-rps option cannot be negative.")
+	}
+	if flags.MaxConcurrency < 0 {
+		return net.RateLimits{}, errors.New("The --max-concurrency option cannot be negative.")
+	}
+
+	rateLimits := net.RateLimits{
+		MaxRequestsPerSecond: flags.MaxRps,
+		MaxConcurrency:       flags.MaxConcurrency,
+	}
+
+	if rateLimits.MaxRequestsPerSecond == 0 {
+		rateLimits.MaxRequestsPerSecond = settings.MaxRequestsPerSecond
+	}
+	if rateLimits.MaxConcurrency == 0 {
+		rateLimits.MaxConcurrency = settings.MaxConcurrency
 	}
-	return ch360.NewApiClient(DefaultHttpClient, ch360.ApiAddress, clientId, clientSecret, logSink), nil
+
+	return rateLimits, nil
 }
 
-var DefaultHttpClient = &http.Client{Timeout: time.Minute * 2}
+// resolveRetryPolicies returns the policies for retrying failed API requests: the default
+// policies, overridden by the settings in the configuration file, and then by the
+// --retry-* flags (which apply to every endpoint).
+func resolveRetryPolicies(flags *config.GlobalFlags, settings config.RetrySettings) (net.RetryPolicies, error) {
+	policies := net.DefaultRetryPolicies()
+
+	var err error
+	policies, err = applyRetrySettings(policies, settings.RetryPolicySettings, "the configuration file")
+	if err != nil {
+		return net.RetryPolicies{}, err
+	}
+
+	var endpointRules []net.RetryRule
+	for _, endpoint := range settings.Endpoints {
+		rule := net.RetryRule{
+			Method: strings.ToUpper(endpoint.Method),
+			Path:   endpoint.Path,
+			Policy: policies.Default,
+		}
+
+		// keep the policy of any default rule for the same endpoint (so that uploads
+		// are still known to be non-idempotent)
+		for _, existing := range policies.Rules {
+			if existing.Method == rule.Method && existing.Path == rule.Path {
+				rule.Policy = existing.Policy
+			}
+		}
+
+		rule.Policy, err = retryPolicyWith(rule.Policy, endpoint.RetryPolicySettings,
+			"the configuration file")
+		if err != nil {
+			return net.RetryPolicies{}, err
+		}
+
+		endpointRules = append(endpointRules, rule)
+	}
+	policies.Rules = append(endpointRules, policies.Rules...)
+
+	flagSettings, err := retryFlagSettings(flags)
+	if err != nil {
+		return net.RetryPolicies{}, err
+	}
+
+	return applyRetrySettings(policies, flagSettings, "")
+}
+
+func applyRetrySettings(policies net.RetryPolicies, settings config.RetryPolicySettings,
+	source string) (net.RetryPolicies, error) {
+	var err error
+
+	policies = policies.Map(func(policy net.RetryPolicy) net.RetryPolicy {
+		if err == nil {
+			policy, err = retryPolicyWith(policy, settings, source)
+		}
+		return policy
+	})
+
+	return policies, err
+}
+
+// retryPolicyWith returns the policy with any of the settings which are specified.
+func retryPolicyWith(policy net.RetryPolicy, settings config.RetryPolicySettings,
+	source string) (net.RetryPolicy, error) {
+	if settings.Attempts != nil {
+		if *settings.Attempts < 0 {
+			return policy, invalidRetrySetting("the number of retry attempts cannot be negative", source)
+		}
+		policy.Attempts = *settings.Attempts
+	}
+
+	if settings.MaxElapsedTime != "" {
+		maxElapsedTime, err := time.ParseDuration(settings.MaxElapsedTime)
+		if err != nil || maxElapsedTime < 0 {
+			return policy, invalidRetrySetting("the maximum retry time must be a duration, such as 2m", source)
+		}
+		policy.MaxElapsedTime = maxElapsedTime
+	}
+
+	if settings.Jitter != nil {
+		if *settings.Jitter < 0 || *settings.Jitter > 1 {
+			return policy, invalidRetrySetting("the retry jitter must be between 0 and 1", source)
+		}
+		policy.Jitter = *settings.Jitter
+	}
+
+	if settings.StatusCodes != nil {
+		policy.RetryableStatusCodes = settings.StatusCodes
+	}
+
+	return policy, nil
+}
+
+func invalidRetrySetting(message, source string) error {
+	if source == "" {
+		return errors.New("Invalid retry option: " + message + ".")
+	}
+	return errors.Errorf("Invalid retry setting in %s: %s.", source, message)
+}
+
+// retryFlagSettings returns the settings specified by the --retry-* flags.
+func retryFlagSettings(flags *config.GlobalFlags) (config.RetryPolicySettings, error) {
+	settings := config.RetryPolicySettings{
+		MaxElapsedTime: flags.RetryMaxTime,
+	}
+
+	if flags.RetryAttempts != "" {
+		attempts, err := strconv.Atoi(flags.RetryAttempts)
+		if err != nil {
+			return settings, errors.New("The --retry-attempts option must be a whole number.")
+		}
+		settings.Attempts = &attempts
+	}
+
+	if flags.RetryJitter != "" {
+		jitter, err := strconv.ParseFloat(flags.RetryJitter, 64)
+		if err != nil {
+			return settings, errors.New("The --retry-jitter option must be a number between 0 and 1.")
+		}
+		settings.Jitter = &jitter
+	}
+
+	if flags.RetryStatusCodes != "" {
+		// an explicitly empty list is allowed, so that no status codes are retried
+		settings.StatusCodes = []int{}
+
+		for _, code := range strings.Split(flags.RetryStatusCodes, ",") {
+			code = strings.TrimSpace(code)
+			if code == "" || code == "none" {
+				continue
+			}
+
+			statusCode, err := strconv.Atoi(code)
+			if err != nil {
+				return settings, errors.Errorf("The --retry-status option must be a list of HTTP "+
+					"status codes, such as 429,503 (not '%s').", code)
+			}
+			settings.StatusCodes = append(settings.StatusCodes, statusCode)
+		}
+	}
+
+	return settings, nil
+}
+
+// newHttpClient returns the client which sends API requests, with the connection
+// settings and timeouts specified by the flags or, for those which are not specified,
+// the configuration file.
+func newHttpClient(flags *config.GlobalFlags, settings config.HttpSettings) (net.HttpDoer, error) {
+	options := net.TransportOptions{
+		Proxy:             settings.Proxy,
+		CACertificates:    settings.CACertificates,
+		ClientCertificate: settings.ClientCertificate,
+		ClientKey:         settings.ClientKey,
+	}
+
+	if flags.Proxy != "" {
+		options.Proxy = flags.Proxy
+	}
+	if flags.CACertificates != "" {
+		options.CACertificates = flags.CACertificates
+	}
+	// the key belongs with the certificate, so neither is taken from the configuration
+	// file if the certificate is specified by the flags
+	if flags.ClientCertificate != "" {
+		options.ClientCertificate = flags.ClientCertificate
+		options.ClientKey = flags.ClientKey
+	} else if flags.ClientKey != "" {
+		options.ClientKey = flags.ClientKey
+	}
+
+	transport, err := net.NewTransport(options)
+	if err != nil {
+		return nil, err
+	}
+
+	timeouts, err := resolveTimeouts(flags, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return net.NewTimeoutHttpClient(&http.Client{Transport: transport}, timeouts), nil
+}
+
+// resolveTimeouts returns the default timeouts for each operation, overridden by the
+// settings in the configuration file and then by the --timeout flags.
+func resolveTimeouts(flags *config.GlobalFlags, settings config.HttpSettings) (net.Timeouts, error) {
+	timeouts := ch360.DefaultTimeouts()
+
+	var operations []string
+	for operation := range settings.Timeouts {
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+
+	for _, operation := range operations {
+		timeout, err := time.ParseDuration(settings.Timeouts[operation])
+		if err != nil || timeout < 0 {
+			return net.Timeouts{}, errors.Errorf("Invalid timeout in the configuration file: "+
+				"the timeout for %s must be a duration, such as 5m.", operation)
+		}
+
+		var ok bool
+		if timeouts, ok = ch360.WithTimeout(timeouts, operation, timeout); !ok {
+			return net.Timeouts{}, unknownOperation(operation, "in the configuration file")
+		}
+	}
+
+	for _, flag := range flags.Timeouts {
+		operation, value := ch360.DefaultOperation, flag
+		if i := strings.Index(flag, "="); i >= 0 {
+			operation, value = strings.TrimSpace(flag[:i]), flag[i+1:]
+		}
+
+		timeout, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || timeout < 0 {
+			return net.Timeouts{}, errors.Errorf("The --timeout option must be a duration, "+
+				"optionally for an operation, such as 5m or read=30m (not '%s').", flag)
+		}
+
+		var ok bool
+		if timeouts, ok = ch360.WithTimeout(timeouts, operation, timeout); !ok {
+			return net.Timeouts{}, unknownOperation(operation, "for the --timeout option")
+		}
+	}
+
+	return timeouts, nil
+}
+
+func unknownOperation(operation, source string) error {
+	return errors.Errorf("Unknown operation '%s' %s (expected %s or %s).", operation, source,
+		strings.Join(ch360.Operations(), ", "), ch360.DefaultOperation)
+}
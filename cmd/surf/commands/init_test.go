@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/config"
+	"github.com/waives/surf/config/mocks"
+	"github.com/waives/surf/net"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestReadHttpSettings_Returns_Settings_From_Configuration(t *testing.T) {
+	configuration := config.NewConfiguration("id", "secret")
+	configuration.Http = config.HttpSettings{MaxRequestsPerSecond: 5}
+	reader := new(mocks.ConfigurationReader)
+	reader.On("ReadConfiguration").Return(configuration, nil)
+
+	assert.Equal(t, configuration.Http, readHttpSettings(reader))
+}
+
+func TestReadHttpSettings_Returns_No_Settings_Without_Configuration(t *testing.T) {
+	reader := new(mocks.ConfigurationReader)
+	reader.On("ReadConfiguration").Return(nil, os.ErrNotExist)
+
+	assert.Equal(t, config.HttpSettings{}, readHttpSettings(reader))
+}
+
+func TestResolveRateLimits_Uses_Configuration_For_Unspecified_Flags(t *testing.T) {
+	settings := config.HttpSettings{MaxRequestsPerSecond: 5, MaxConcurrency: 3}
+
+	rateLimits, err := resolveRateLimits(&config.GlobalFlags{MaxConcurrency: 10}, settings)
+
+	require.NoError(t, err)
+	assert.Equal(t, net.RateLimits{MaxRequestsPerSecond: 5, MaxConcurrency: 10}, rateLimits)
+}
+
+func TestResolveRateLimits_Rejects_Negative_Flags(t *testing.T) {
+	_, err := resolveRateLimits(&config.GlobalFlags{MaxRps: -1}, config.HttpSettings{})
+	assert.Error(t, err)
+
+	_, err = resolveRateLimits(&config.GlobalFlags{MaxConcurrency: -1}, config.HttpSettings{})
+	assert.Error(t, err)
+}
+
+func TestResolveRetryPolicies_Returns_Defaults_Without_Settings(t *testing.T) {
+	policies, err := resolveRetryPolicies(&config.GlobalFlags{}, config.RetrySettings{})
+
+	require.NoError(t, err)
+	assert.Equal(t, net.DefaultRetryPolicies(), policies)
+}
+
+func TestResolveRetryPolicies_Applies_Configuration_Then_Flags(t *testing.T) {
+	var (
+		attempts = 5
+		jitter   = 0.1
+		settings = config.RetrySettings{
+			RetryPolicySettings: config.RetryPolicySettings{
+				Attempts:       &attempts,
+				MaxElapsedTime: "2m",
+				Jitter:         &jitter,
+			},
+		}
+		flags = &config.GlobalFlags{RetryAttempts: "1", RetryStatusCodes: "429, 503"}
+	)
+
+	policies, err := resolveRetryPolicies(flags, settings)
+
+	require.NoError(t, err)
+	for _, policy := range []net.RetryPolicy{policies.Default, policies.Rules[0].Policy} {
+		assert.Equal(t, 1, policy.Attempts)
+		assert.Equal(t, 2*time.Minute, policy.MaxElapsedTime)
+		assert.Equal(t, 0.1, policy.Jitter)
+		assert.Equal(t, []int{429, 503}, policy.RetryableStatusCodes)
+	}
+	assert.True(t, policies.Rules[0].Policy.NonIdempotent)
+}
+
+func TestResolveRetryPolicies_Adds_Endpoint_Rules_From_Configuration(t *testing.T) {
+	noAttempts := 0
+	settings := config.RetrySettings{
+		Endpoints: []config.EndpointRetrySettings{
+			{Method: "post", Path: "/documents", RetryPolicySettings: config.RetryPolicySettings{
+				StatusCodes: []int{429},
+			}},
+			{Path: "/documents/*/reads", RetryPolicySettings: config.RetryPolicySettings{
+				Attempts: &noAttempts,
+			}},
+		},
+	}
+
+	policies, err := resolveRetryPolicies(&config.GlobalFlags{}, settings)
+
+	require.NoError(t, err)
+	require.Len(t, policies.Rules, 3)
+	assert.Equal(t, "POST", policies.Rules[0].Method)
+	assert.Equal(t, []int{429}, policies.Rules[0].Policy.RetryableStatusCodes)
+	assert.True(t, policies.Rules[0].Policy.NonIdempotent)
+	assert.Equal(t, "/documents/*/reads", policies.Rules[1].Path)
+	assert.Equal(t, 0, policies.Rules[1].Policy.Attempts)
+	assert.False(t, policies.Rules[1].Policy.NonIdempotent)
+}
+
+func TestResolveRetryPolicies_Allows_No_Status_Codes(t *testing.T) {
+	policies, err := resolveRetryPolicies(&config.GlobalFlags{RetryStatusCodes: "none"}, config.RetrySettings{})
+
+	require.NoError(t, err)
+	assert.Empty(t, policies.Default.RetryableStatusCodes)
+}
+
+func TestResolveRetryPolicies_Rejects_Invalid_Settings(t *testing.T) {
+	negative := -1
+	fixtures := []struct {
+		flags    config.GlobalFlags
+		settings config.RetrySettings
+	}{
+		{flags: config.GlobalFlags{RetryAttempts: "many"}},
+		{flags: config.GlobalFlags{RetryAttempts: "-1"}},
+		{flags: config.GlobalFlags{RetryMaxTime: "2"}},
+		{flags: config.GlobalFlags{RetryJitter: "2"}},
+		{flags: config.GlobalFlags{RetryStatusCodes: "429,5xx"}},
+		{settings: config.RetrySettings{RetryPolicySettings: config.RetryPolicySettings{Attempts: &negative}}},
+		{settings: config.RetrySettings{Endpoints: []config.EndpointRetrySettings{
+			{Path: "/documents", RetryPolicySettings: config.RetryPolicySettings{MaxElapsedTime: "soon"}},
+		}}},
+	}
+
+	for _, fixture := range fixtures {
+		_, err := resolveRetryPolicies(&fixture.flags, fixture.settings)
+
+		assert.Error(t, err, fixture)
+	}
+}
+
+func TestResolveTimeouts_Applies_Configuration_Then_Flags(t *testing.T) {
+	settings := config.HttpSettings{
+		Timeouts: map[string]string{"default": "1m", "upload": "5m", "read": "20m"},
+	}
+	flags := &config.GlobalFlags{Timeouts: []string{"read=30m", "90s"}}
+
+	timeouts, err := resolveTimeouts(flags, settings)
+
+	require.NoError(t, err)
+	expected, _ := ch360.WithTimeout(net.Timeouts{Default: 90 * time.Second}, "upload", 5*time.Minute)
+	expected, _ = ch360.WithTimeout(expected, "read", 30*time.Minute)
+	assert.ElementsMatch(t, expected.Rules, timeouts.Rules)
+	assert.Equal(t, expected.Default, timeouts.Default)
+}
+
+func TestResolveTimeouts_Rejects_Invalid_Settings(t *testing.T) {
+	fixtures := []struct {
+		flags    config.GlobalFlags
+		settings config.HttpSettings
+	}{
+		{flags: config.GlobalFlags{Timeouts: []string{"soon"}}},
+		{flags: config.GlobalFlags{Timeouts: []string{"read=-1m"}}},
+		{flags: config.GlobalFlags{Timeouts: []string{"unknown=1m"}}},
+		{settings: config.HttpSettings{Timeouts: map[string]string{"read": "5"}}},
+		{settings: config.HttpSettings{Timeouts: map[string]string{"unknown": "5m"}}},
+	}
+
+	for _, fixture := range fixtures {
+		_, err := resolveTimeouts(&fixture.flags, fixture.settings)
+
+		assert.Error(t, err, fixture)
+	}
+}
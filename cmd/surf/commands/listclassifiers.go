@@ -43,7 +43,7 @@ func (cmd *ListClassifiersCmd) Execute(ctx context.Context) error {
 }
 
 func (cmd *ListClassifiersCmd) initFromArgs(flags *config.GlobalFlags) error {
-	apiClient, err := initApiClient(flags.ClientId, flags.ClientSecret, flags.LogHttp)
+	apiClient, err := initApiClient(flags)
 
 	if err != nil {
 		return err
@@ -6,26 +6,43 @@ import (
 	"github.com/waives/surf/ch360"
 	"github.com/waives/surf/config"
 	"gopkg.in/alecthomas/kingpin.v2"
+	"io"
 	"os"
 	"strconv"
 )
 
 type ListDocumentsCmd struct {
 	Client ch360.DocumentGetter
+	Filter ch360.DocumentFilter
+	SortBy string
+	Output io.Writer
+}
+
+type listDocumentsArgs struct {
+	filterArgs documentFilterArgs
+	sortBy     string
 }
 
 // Configures kingpin with the 'list documents' command
 func ConfigureListDocumentsCmd(ctx context.Context, parentCmd *kingpin.CmdClause, flags *config.GlobalFlags) {
+	args := &listDocumentsArgs{}
 	listDocumentsCmd := &ListDocumentsCmd{}
-	parentCmd.Command("documents", "List all available documents.").
+	listDocumentsCli := parentCmd.Command("documents", "List all available documents.").
 		Alias("document").
 		Action(func(parseContext *kingpin.ParseContext) error {
-			err := listDocumentsCmd.initFromArgs(flags)
+			err := listDocumentsCmd.initFromArgs(args, flags)
 			if err != nil {
 				return err
 			}
 			return listDocumentsCmd.Execute(ctx)
 		})
+
+	addDocumentFilterFlagsTo(&args.filterArgs, listDocumentsCli)
+
+	listDocumentsCli.Flag("sort", "Sort the documents by the specified field. Allowed values: id, "+
+		"size, type, sha256.").
+		PlaceHolder("field").
+		EnumVar(&args.sortBy, ch360.DocumentSortFields...)
 }
 
 // Executes the command.
@@ -35,12 +52,14 @@ func (cmd *ListDocumentsCmd) Execute(ctx context.Context) error {
 		return err
 	}
 
+	documents = documents.Filter(cmd.Filter).SortBy(cmd.SortBy)
+
 	if len(documents) == 0 {
-		fmt.Println("No documents found.")
+		fmt.Fprintln(cmd.Output, "No documents found.")
 		return nil
 	}
 
-	table := NewTable(os.Stdout, []string{"ID", "Size", "Type", "SHA256"})
+	table := NewTable(cmd.Output, []string{"ID", "Size", "Type", "SHA256"})
 
 	for _, document := range documents {
 		table.Append([]string{document.Id, strconv.Itoa(document.Size), document.FileType,
@@ -51,13 +70,17 @@ func (cmd *ListDocumentsCmd) Execute(ctx context.Context) error {
 	return nil
 }
 
-func (cmd *ListDocumentsCmd) initFromArgs(flags *config.GlobalFlags) error {
-	apiClient, err := initApiClient(flags.ClientId, flags.ClientSecret, flags.LogHttp)
+func (cmd *ListDocumentsCmd) initFromArgs(args *listDocumentsArgs, flags *config.GlobalFlags) error {
+	cmd.Filter = args.filterArgs.filter()
+	cmd.SortBy = args.sortBy
+
+	apiClient, err := initApiClient(flags)
 
 	if err != nil {
 		return err
 	}
 
 	cmd.Client = apiClient.Documents
+	cmd.Output = os.Stdout
 	return nil
 }
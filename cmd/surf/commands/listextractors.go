@@ -61,7 +61,7 @@ func (cmd ListExtractorsCmd) Usage() string {
 }
 
 func (cmd *ListExtractorsCmd) initFromArgs(flags *config.GlobalFlags) error {
-	apiClient, err := initApiClient(flags.ClientId, flags.ClientSecret, flags.LogHttp)
+	apiClient, err := initApiClient(flags)
 
 	if err != nil {
 		return err
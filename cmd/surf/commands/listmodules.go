@@ -6,6 +6,7 @@ import (
 	"github.com/waives/surf/ch360"
 	"github.com/waives/surf/config"
 	"gopkg.in/alecthomas/kingpin.v2"
+	"io"
 	"os"
 )
 
@@ -19,13 +20,15 @@ type ModuleGetter interface {
 
 type ListModulesCmd struct {
 	Client ModuleGetter
+	Search string
+	Output io.Writer
 }
 
 func ConfigureListModulesCommand(ctx context.Context,
 	listCmd *kingpin.CmdClause, globalFlags *config.GlobalFlags) {
 	cmd := &ListModulesCmd{}
 
-	listCmd.Command("modules", "List all available extractor modules.").
+	listModulesCli := listCmd.Command("modules", "List all available extractor modules.").
 		Action(func(parseContext *kingpin.ParseContext) error {
 			err := cmd.initFromArgs(globalFlags)
 
@@ -34,16 +37,21 @@ func ConfigureListModulesCommand(ctx context.Context,
 			}
 			return cmd.Execute(ctx)
 		})
+
+	listModulesCli.
+		Flag("search", "Only list modules whose ID, name, summary or field names contain the text.").
+		StringVar(&cmd.Search)
 }
 
 func (cmd *ListModulesCmd) initFromArgs(flags *config.GlobalFlags) error {
-	apiClient, err := initApiClient(flags.ClientId, flags.ClientSecret, flags.LogHttp)
+	apiClient, err := initApiClient(flags)
 
 	if err != nil {
 		return err
 	}
 
 	cmd.Client = apiClient.Modules
+	cmd.Output = os.Stdout
 	return nil
 }
 
@@ -53,12 +61,16 @@ func (cmd *ListModulesCmd) Execute(ctx context.Context) error {
 		return err
 	}
 
+	if cmd.Search != "" {
+		modules = modules.Search(cmd.Search)
+	}
+
 	if len(modules) == 0 {
-		fmt.Println("No modules found.")
-		return nil
+		_, err = fmt.Fprintln(cmd.Output, "No modules found.")
+		return err
 	}
 
-	table := NewTable(os.Stdout, []string{"Name", "ID", "Summary"})
+	table := NewTable(cmd.Output, []string{"Name", "ID", "Summary"})
 	for _, module := range modules {
 		table.Append([]string{module.Name, module.ID, module.Summary})
 	}
@@ -31,15 +31,26 @@ func ConfigureLoginCommand(ctx context.Context,
 type LoginCmd struct {
 	TokenRetriever      auth.TokenRetriever
 	ConfigurationWriter config.ConfigurationWriter
+	// ConfigurationReader, if set, reads any existing configuration, whose
+	// settings (other than the credentials) are kept.
+	ConfigurationReader config.ConfigurationReader
 }
 
 func (cmd *LoginCmd) initFromArgs(flags *config.GlobalFlags) error {
+	appDir, err := config.NewAppDirectory()
+	if err != nil {
+		return err
+	}
+	cmd.ConfigurationWriter = appDir
+	cmd.ConfigurationReader = appDir
 
-	var err error
-	cmd.TokenRetriever = ch360.NewTokenRetriever(DefaultHttpClient, ch360.ApiAddress)
-	cmd.ConfigurationWriter, err = config.NewAppDirectory()
+	httpClient, err := newHttpClient(flags, readHttpSettings(appDir))
+	if err != nil {
+		return err
+	}
+	cmd.TokenRetriever = ch360.NewTokenRetriever(httpClient, ch360.ApiAddress)
 
-	return err
+	return nil
 }
 
 func (cmd *LoginCmd) Execute(ctx context.Context, flags *config.GlobalFlags) error {
@@ -73,6 +84,12 @@ func (cmd *LoginCmd) Execute(ctx context.Context, flags *config.GlobalFlags) err
 
 	configuration := config.NewConfiguration(clientId, clientSecret)
 
+	if cmd.ConfigurationReader != nil {
+		if existing, err := cmd.ConfigurationReader.ReadConfiguration(); err == nil {
+			configuration.Http = existing.Http
+		}
+	}
+
 	err = cmd.ConfigurationWriter.WriteConfiguration(configuration)
 
 	return err
@@ -3,7 +3,6 @@
 package mocks
 
 import ch360 "github.com/waives/surf/ch360"
-
 import context "context"
 import mock "github.com/stretchr/testify/mock"
 
@@ -25,3 +24,17 @@ func (_m *ReaderService) ReadAll(ctx context.Context, files []string, readMode c
 
 	return r0
 }
+
+// ReadAllModes provides a mock function with given fields: ctx, files, readModes
+func (_m *ReaderService) ReadAllModes(ctx context.Context, files []string, readModes []ch360.ReadMode) error {
+	ret := _m.Called(ctx, files, readModes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []ch360.ReadMode) error); ok {
+		r0 = rf(ctx, files, readModes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"github.com/spf13/afero"
+	"github.com/waives/surf/output/sinks"
+	"io"
+)
+
+// writeOutput writes output to the named file or, if the filename is empty or "-", to
+// out. The file is only replaced once all of the output has been written, so an error
+// part-way through never leaves it partially written.
+func writeOutput(filename string, out io.Writer, write func(io.Writer) error) error {
+	if filename == "" || filename == "-" {
+		return write(out)
+	}
+
+	sink := sinks.NewFileSink(afero.NewOsFs(), filename)
+	if err := sink.Open(); err != nil {
+		return err
+	}
+
+	if err := write(sink); err != nil {
+		_ = sink.Abort()
+		return err
+	}
+
+	return sink.Close()
+}
@@ -6,58 +6,94 @@ import (
 	"github.com/waives/surf/cmd/surf/services"
 	"github.com/waives/surf/output/resultsWriters"
 	"os"
+	"strings"
 
 	"github.com/waives/surf/ch360"
 	"github.com/waives/surf/config"
 	"github.com/waives/surf/output/progress"
+	"github.com/waives/surf/pages"
 	"gopkg.in/alecthomas/kingpin.v2"
+	"io"
 )
 
 type ReadArgs struct {
 	outputFormat string
 	filePatterns []string
+	pages        string
+	splitPages   bool
 }
 
 //go:generate mockery -name ReaderService
 type ReaderService interface {
 	ReadAll(ctx context.Context, files []string, readMode ch360.ReadMode) error
+	ReadAllModes(ctx context.Context, files []string, readModes []ch360.ReadMode) error
 }
 
 // ReadCmd represents the 'read' command. It relies on a 'ReaderService' to perform the actual OCR.
+// When ReadModes is set, each file is read once and the results retrieved in each of the
+// modes; otherwise the results are retrieved in ReadMode.
 type ReadCmd struct {
 	FilePaths     []string
 	ReaderService ReaderService
 	ReadMode      ch360.ReadMode
+	ReadModes     []ch360.ReadMode
+	// inputs are the files to process, which are closed once they have been processed
+	inputs io.Closer
 }
 
-func (cmd *ReadCmd) initFromArgs(args *ReadArgs, globalFlags *config.GlobalFlags) error {
-	resultsWriter, err := resultsWriters.NewReaderResultsWriter(globalFlags.MultiFileOut,
-		globalFlags.OutputFile, args.outputFormat)
+func (cmd *ReadCmd) initFromArgs(ctx context.Context, args *ReadArgs,
+	globalFlags *config.GlobalFlags) error {
+	var resultsWriter resultsWriters.ResultsWriter
 
+	outputFormats, err := parseReadFormats(args.outputFormat)
 	if err != nil {
 		return err
 	}
 
-	progressHandler := progress.NewProgressHandler(resultsWriter, globalFlags.ShowProgress, os.Stderr)
+	options, err := readOutputOptions(args, outputFormats)
+	if err != nil {
+		return err
+	}
+
+	if len(outputFormats) > 1 {
+		if !globalFlags.MultiFileOut {
+			return errors.New("you must use '-m' when reading more than one output format")
+		}
+
+		resultsWriter, err = resultsWriters.NewReadFormatsResultsWriter(outputDestination(globalFlags).Location,
+			outputFormats, readModes, options)
+		cmd.ReadModes = readModesFor(outputFormats)
+	} else {
+		outputFormat := outputFormats[0]
+		resultsWriter, err = resultsWriters.NewReaderResultsWriter(outputDestination(globalFlags),
+			outputFormat, options)
+		cmd.ReadMode = readModes[outputFormat]
 
-	cmd.ReadMode = readModes[args.outputFormat]
+		// ensure we're not printing binary data to the console
+		if !config.IsOutputRedirected() &&
+			cmd.ReadMode.IsBinary() &&
+			!convertedReadFormats[outputFormat] &&
+			!globalFlags.IsOutputSpecified() {
+			return errors.New("you must use '-o' or '-m' or redirect stdout when the output " +
+				"file format is pdf or wvdoc")
+		}
+	}
 
-	// ensure we're not printing binary data to the console
-	if !config.IsOutputRedirected() &&
-		cmd.ReadMode.IsBinary() &&
-		!globalFlags.IsOutputSpecified() {
-		return errors.New("you must use '-o' or '-m' or redirect stdout when the output " +
-			"file format is pdf or wvdoc")
+	if err != nil {
+		return err
 	}
 
-	cmd.FilePaths, err = GlobMany(args.filePatterns)
+	progressHandler := progress.NewProgressHandler(resultsWriter, globalFlags.ShowProgress, os.Stderr)
+
+	files, err := resolveInputs(ctx, args.filePatterns, globalFlags)
 	if err != nil {
 		return err
 	}
 
-	client, err := initApiClient(globalFlags.ClientId,
-		globalFlags.ClientSecret,
-		globalFlags.LogHttp)
+	cmd.FilePaths = files.Names()
+	cmd.inputs = files
+
+	client, err := initApiClient(globalFlags)
 
 	if err != nil {
 		return err
@@ -65,8 +101,25 @@ func (cmd *ReadCmd) initFromArgs(args *ReadArgs, globalFlags *config.GlobalFlags
 
 	singleFileReader := ch360.NewFileReader(client.Documents, client.Documents, client.Documents)
 
-	cmd.ReaderService = services.NewParallelReaderService(singleFileReader, client.Documents,
-		progressHandler)
+	if globalFlags.Deduplicate {
+		singleFileReader.WithDeduplicator(ch360.NewDeduplicator(client.Documents))
+	}
+
+	var fileReader services.FileReader = singleFileReader
+
+	if isCacheEnabled(globalFlags) {
+		resultCache, err := newResultCache()
+		if err != nil {
+			return err
+		}
+
+		fileReader = services.NewCachingFileReader(fileReader, resultCache, globalFlags.RefreshCache)
+	}
+
+	cmd.ReaderService = services.NewParallelReaderService(fileReader, client.Documents,
+		progressHandler).
+		WithFileOpener(files.Open).
+		WithRecorder(globalFlags.Telemetry)
 
 	return nil
 }
@@ -83,33 +136,144 @@ func ConfigureReadCommand(ctx context.Context,
 	cliCmd := app.
 		Command("read", "Perform OCR on a file or set of files.").
 		Action(func(parseContext *kingpin.ParseContext) error {
-			err := readCmd.initFromArgs(readArgs, globalFlags)
+			err := readCmd.initFromArgs(ctx, readArgs, globalFlags)
 			if err != nil {
 				return err
 			}
+			defer readCmd.inputs.Close()
+
 			return readCmd.Execute(ctx)
 		})
 
-	cliCmd.Flag("format", "The output format. Allowed values: pdf, wvdoc, txt [default: txt].").
+	cliCmd.Flag("format", "The output format. Allowed values: pdf, wvdoc, txt, hocr, alto, "+
+		"json [default: txt]. The hocr, alto and json formats include the position of each word. "+
+		"Several formats may be given as a comma-separated list (e.g. txt,pdf), in which case "+
+		"each file is read once and '-m' must be used.").
 		Short('f').
 		Default("txt").
-		EnumVar(&readArgs.outputFormat, "pdf", "wvdoc", "txt")
+		StringVar(&readArgs.outputFormat)
+
+	cliCmd.Flag("pages", "Only output the specified pages, e.g. 1-3,7. Not supported for "+
+		"the pdf and wvdoc formats.").
+		PlaceHolder("PAGES").
+		StringVar(&readArgs.pages)
+
+	cliCmd.Flag("split-pages", "Write each page of the txt output to its own file "+
+		"(<name>.page-0001.txt). Pages are separated by form feeds in the txt output. "+
+		"Must be used with '-m'.").
+		BoolVar(&readArgs.splitPages)
 
-	cliCmd.Arg("files", "The files to read.").
-		Required().
+	cliCmd.Arg("files", "The files to read, or '-' to read from stdin. "+
+		"URLs, and zip and tar archives, are also accepted.").
 		StringsVar(&readArgs.filePatterns)
 
+	addDeduplicationFlagTo(globalFlags, cliCmd)
+	addCacheFlagsTo(globalFlags, cliCmd)
+	addInputFlagsTo(globalFlags, cliCmd)
 	addFileHandlingFlagsTo(globalFlags, cliCmd)
 }
 
 // Execute is the main entry point for the 'read' command.
 func (cmd *ReadCmd) Execute(ctx context.Context) error {
-	err := cmd.ReaderService.ReadAll(ctx, cmd.FilePaths, cmd.ReadMode)
+	var err error
+
+	if len(cmd.ReadModes) > 0 {
+		err = cmd.ReaderService.ReadAllModes(ctx, cmd.FilePaths, cmd.ReadModes)
+	} else {
+		err = cmd.ReaderService.ReadAll(ctx, cmd.FilePaths, cmd.ReadMode)
+	}
+
 	return errors.Wrap(err, "read failed")
 }
 
+// parseReadFormats parses a comma-separated list of output formats, ignoring any
+// duplicates.
+func parseReadFormats(formatList string) ([]string, error) {
+	var (
+		outputFormats []string
+		seen          = map[string]bool{}
+	)
+
+	for _, outputFormat := range strings.Split(formatList, ",") {
+		outputFormat = strings.ToLower(strings.TrimSpace(outputFormat))
+
+		if _, ok := readModes[outputFormat]; !ok {
+			return nil, errors.Errorf("'%s' is not a valid output format. Allowed values: "+
+				"pdf, wvdoc, txt, hocr, alto, json", outputFormat)
+		}
+
+		if !seen[outputFormat] {
+			seen[outputFormat] = true
+			outputFormats = append(outputFormats, outputFormat)
+		}
+	}
+
+	return outputFormats, nil
+}
+
+// readOutputOptions returns the options for writing the output formats.
+func readOutputOptions(args *ReadArgs, outputFormats []string) (resultsWriters.ReadOutputOptions, error) {
+	var (
+		options = resultsWriters.ReadOutputOptions{SplitPages: args.splitPages}
+		err     error
+	)
+
+	if args.pages != "" {
+		options.Pages, err = pages.ParseSelection(args.pages)
+		if err != nil {
+			return options, err
+		}
+	}
+
+	if args.splitPages && !containsString(outputFormats, "txt") {
+		return options, errors.New("the --split-pages option can only be used with the txt format")
+	}
+
+	return options, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// readModesFor returns the read modes needed to produce the output formats.
+func readModesFor(outputFormats []string) []ch360.ReadMode {
+	var (
+		modes []ch360.ReadMode
+		seen  = map[ch360.ReadMode]bool{}
+	)
+
+	for _, outputFormat := range outputFormats {
+		mode := readModes[outputFormat]
+		if !seen[mode] {
+			seen[mode] = true
+			modes = append(modes, mode)
+		}
+	}
+
+	return modes
+}
+
+// readModes maps output formats to the read mode used to produce them. The hocr,
+// alto and json formats are converted from the wvdoc read result.
 var readModes = map[string]ch360.ReadMode{
 	"wvdoc": ch360.ReadWvdoc,
 	"pdf":   ch360.ReadPDF,
 	"txt":   ch360.ReadText,
+	"hocr":  ch360.ReadWvdoc,
+	"alto":  ch360.ReadWvdoc,
+	"json":  ch360.ReadWvdoc,
+}
+
+// convertedReadFormats are the output formats which are converted from another
+// read mode, rather than being written as they are.
+var convertedReadFormats = map[string]bool{
+	"hocr": true,
+	"alto": true,
+	"json": true,
 }
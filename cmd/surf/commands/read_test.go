@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/pages"
+	"testing"
+)
+
+func TestParseReadFormats_Parses_Format_List(t *testing.T) {
+	outputFormats, err := parseReadFormats("txt, PDF,hocr,txt")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"txt", "pdf", "hocr"}, outputFormats)
+}
+
+func TestParseReadFormats_Rejects_Unknown_Formats(t *testing.T) {
+	for _, formatList := range []string{"docx", "txt,docx", "txt,", ""} {
+		_, err := parseReadFormats(formatList)
+
+		assert.Error(t, err, formatList)
+	}
+}
+
+func TestReadModesFor_Returns_Each_Mode_Once(t *testing.T) {
+	modes := readModesFor([]string{"hocr", "txt", "wvdoc", "alto", "pdf"})
+
+	assert.Equal(t, []ch360.ReadMode{ch360.ReadWvdoc, ch360.ReadText, ch360.ReadPDF}, modes)
+}
+
+func TestReadOutputOptions_Parses_Pages(t *testing.T) {
+	options, err := readOutputOptions(&ReadArgs{pages: "1-3,7", splitPages: true}, []string{"txt"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, pages.Selection{{First: 1, Last: 3}, {First: 7, Last: 7}}, options.Pages)
+	assert.True(t, options.SplitPages)
+}
+
+func TestReadOutputOptions_Rejects_Invalid_Pages(t *testing.T) {
+	_, err := readOutputOptions(&ReadArgs{pages: "3-1"}, []string{"txt"})
+
+	assert.Error(t, err)
+}
+
+func TestReadOutputOptions_Requires_Txt_Format_To_Split_Pages(t *testing.T) {
+	_, err := readOutputOptions(&ReadArgs{splitPages: true}, []string{"hocr", "pdf"})
+
+	assert.Error(t, err)
+}
@@ -3,46 +3,102 @@ package commands
 import (
 	"context"
 	"github.com/pkg/errors"
+	"github.com/waives/surf/audit"
 	"github.com/waives/surf/ch360"
 	"github.com/waives/surf/cmd/surf/services"
 	"github.com/waives/surf/config"
+	"github.com/waives/surf/inputs"
 	"github.com/waives/surf/output/progress"
 	"github.com/waives/surf/output/resultsWriters"
 	"gopkg.in/alecthomas/kingpin.v2"
+	"io"
 	"os"
 )
 
 type redactWithExtractorArgs struct {
 	extractorName string
+	fields        []string
+	excludeFields []string
 	filePatterns  []string
 }
 
+// redactArgs are the arguments shared by all of the 'redact' commands.
+type redactArgs struct {
+	preview   bool
+	bookmarks bool
+	marksOnly bool
+	audit     bool
+}
+
 type RedactWithExtractorCmd struct {
-	ExtractorName    string
-	FilePaths        []string
-	RedactionService RedactWithExtractorService
+	ExtractorName     string
+	Fields            ch360.FieldSelection
+	FilePaths         []string
+	Options           ch360.RedactionOptions
+	MarksOnly         bool
+	RecordFields      bool
+	DocumentExtractor ch360.DocumentExtractor
+	RedactionService  RedactionService
+	// inputs are the files to process, which are closed once they have been processed
+	inputs io.Closer
 }
 
-//go:generate mockery -name "RedactWithExtractorService"
-type RedactWithExtractorService interface {
-	RedactAllWithExtractor(ctx context.Context, files []string, extractorName string) error
+//go:generate mockery -name "RedactionService"
+
+// RedactionService redacts files with the requests built for them by a
+// ch360.RedactionRequestBuilder.
+type RedactionService interface {
+	RedactAll(ctx context.Context, files []string, builder ch360.RedactionRequestBuilder) error
+	BuildAllRedactionRequests(ctx context.Context, files []string,
+		builder ch360.RedactionRequestBuilder) error
 }
 
-func ConfigureRedactWithExtractionCommand(ctx context.Context,
+// ConfigureRedactCommand configures kingpin with the 'redact' commands.
+func ConfigureRedactCommand(ctx context.Context,
 	app *kingpin.Application,
 	globalFlags *config.GlobalFlags) {
-	args := &redactWithExtractorArgs{}
-	cmd := &RedactWithExtractorCmd{}
+	args := &redactArgs{}
 	redactCli := app.
 		Command("redact", "Perform data redaction on a file or set of files.")
 
+	configureRedactWithExtractorCmd(ctx, redactCli, args, globalFlags)
+	configureRedactWithAreasCmd(ctx, redactCli, args, globalFlags)
+	configureRedactWithPatternCmd(ctx, redactCli, args, globalFlags)
+
+	redactCli.Flag("preview", "Mark the areas to be redacted in the output PDF, without "+
+		"redacting them, so that the redaction can be reviewed.").
+		BoolVar(&args.preview)
+	redactCli.Flag("bookmarks", "Add bookmarks for the redacted areas to the output PDF "+
+		"(use --no-bookmarks to omit them).").
+		Default("true").
+		BoolVar(&args.bookmarks)
+	redactCli.Flag("marks-only", "Write the areas which would be redacted (as json) instead of "+
+		"redacting the files.").
+		BoolVar(&args.marksOnly)
+	redactCli.Flag("audit", "Write an audit trail for each redacted file alongside it, "+
+		"recording the areas redacted and why (only for use with -m).").
+		BoolVar(&args.audit)
+
+	addInputFlagsTo(globalFlags, redactCli)
+	addFileHandlingFlagsTo(globalFlags, redactCli)
+}
+
+func configureRedactWithExtractorCmd(ctx context.Context,
+	redactCli *kingpin.CmdClause,
+	redactArgs *redactArgs,
+	globalFlags *config.GlobalFlags) {
+	args := &redactWithExtractorArgs{}
+	cmd := &RedactWithExtractorCmd{}
+
 	redactWithExtractorCli := redactCli.Command("with-extractor",
 		"Use fields from an extractor to define areas to redact. ").
 		Action(func(parseContext *kingpin.ParseContext) error {
-			err := cmd.initWithArgs(args, globalFlags)
+			err := cmd.initWithArgs(ctx, args, redactArgs, globalFlags)
 			if err != nil {
 				return err
 			}
+			defer cmd.inputs.Close()
+
 			return cmd.Execute(ctx)
 		})
 
@@ -50,54 +106,150 @@ func ConfigureRedactWithExtractionCommand(ctx context.Context,
 		Required().
 		StringVar(&args.extractorName)
 
-	redactWithExtractorCli.Arg("files", "The files to read.").
-		Required().
+	redactWithExtractorCli.Arg("files", "The files to read, or '-' to read from stdin. "+
+		"URLs, and zip and tar archives, are also accepted.").
 		StringsVar(&args.filePatterns)
 
-	addFileHandlingFlagsTo(globalFlags, redactCli)
+	redactWithExtractorCli.Flag("fields", "Only redact the specified fields. "+
+		"May be specified more than once.").
+		PlaceHolder("field").
+		StringsVar(&args.fields)
+
+	redactWithExtractorCli.Flag("exclude-fields", "Do not redact the specified fields. "+
+		"May be specified more than once.").
+		PlaceHolder("field").
+		StringsVar(&args.excludeFields)
 }
 
-func (cmd *RedactWithExtractorCmd) initWithArgs(args *redactWithExtractorArgs, flags *config.GlobalFlags) error {
-	resultsWriter, err := resultsWriters.NewRedactResultsWriter(flags.MultiFileOut, flags.OutputFile)
+func (cmd *RedactWithExtractorCmd) initWithArgs(ctx context.Context,
+	args *redactWithExtractorArgs, redactArgs *redactArgs, flags *config.GlobalFlags) error {
+	var err error
+
+	files, err := resolveInputs(ctx, args.filePatterns, flags)
 
 	if err != nil {
 		return err
 	}
 
-	progressHandler := progress.NewProgressHandler(resultsWriter,
-		flags.ShowProgress, os.Stderr)
+	cmd.FilePaths = files.Names()
+	cmd.inputs = files
 
-	cmd.FilePaths, err = GlobMany(args.filePatterns)
+	redactionService, client, err := newRedactionService(redactArgs, flags, files, audit.Configuration{
+		Method:        audit.MethodExtractor,
+		Extractor:     args.extractorName,
+		Fields:        args.fields,
+		ExcludeFields: args.excludeFields,
+	})
 
 	if err != nil {
 		return err
 	}
 
-	client, err := initApiClient(flags.ClientId,
-		flags.ClientSecret,
-		flags.LogHttp)
+	cmd.RedactionService = redactionService
+	cmd.DocumentExtractor = client.Documents
+	cmd.RecordFields = redactArgs.audit
+	cmd.ExtractorName = args.extractorName
+	cmd.Fields = ch360.FieldSelection{
+		Include: args.fields,
+		Exclude: args.excludeFields,
+	}
+	cmd.Options = redactArgs.options()
+	cmd.MarksOnly = redactArgs.marksOnly
+
+	return nil
+}
+
+// ExecuteRedact is the main entry point for the 'redact' command.
+func (cmd *RedactWithExtractorCmd) Execute(ctx context.Context) error {
+	builder := ch360.NewExtractorRedaction(cmd.DocumentExtractor, cmd.ExtractorName).
+		WithFields(cmd.Fields)
+
+	if cmd.RecordFields {
+		builder = builder.WithFieldRecording()
+	}
+
+	return redactAll(ctx, cmd.RedactionService, cmd.FilePaths, cmd.Options.Apply(builder),
+		cmd.MarksOnly)
+}
+
+func (args *redactArgs) options() ch360.RedactionOptions {
+	return ch360.RedactionOptions{
+		Preview:       args.preview,
+		OmitBookmarks: !args.bookmarks,
+	}
+}
+
+// redactAll redacts the files with the requests built by builder or, if
+// marksOnly is set, writes out the requests instead.
+func redactAll(ctx context.Context, redactionService RedactionService, files []string,
+	builder ch360.RedactionRequestBuilder, marksOnly bool) error {
+	var err error
+
+	if marksOnly {
+		err = redactionService.BuildAllRedactionRequests(ctx, files, builder)
+	} else {
+		err = redactionService.RedactAll(ctx, files, builder)
+	}
+
+	return errors.Wrap(err, "redaction failed")
+}
+
+// newRedactionService constructs the service used by the 'redact' commands to redact
+// the files, along with the client it uses to talk to waives. The configuration is
+// recorded in the audit trail, if one is written.
+func newRedactionService(args *redactArgs, flags *config.GlobalFlags, files *inputs.Inputs,
+	configuration audit.Configuration) (*services.ParallelRedactionService, *ch360.ApiClient, error) {
+	var (
+		resultsWriter resultsWriters.ResultsWriter
+		err           error
+	)
+
+	if args.audit && (args.marksOnly || !flags.MultiFileOut) {
+		return nil, nil, errors.New("The --audit option can only be used in combination with -m, " +
+			"and not with --marks-only.")
+	}
+
+	if args.audit {
+		configuration.Preview = args.preview
+		configuration.Bookmarks = args.bookmarks
+		resultsWriter = resultsWriters.NewAuditedRedactResultsWriter(outputDestination(flags),
+			configuration)
+	} else if args.marksOnly {
+		resultsWriter, err = resultsWriters.NewRedactionRequestResultsWriter(outputDestination(flags))
+	} else {
+		resultsWriter, err = resultsWriters.NewRedactResultsWriter(outputDestination(flags))
+	}
 
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	if !config.IsOutputRedirected() &&
+	progressHandler := progress.NewProgressHandler(resultsWriter,
+		flags.ShowProgress, os.Stderr)
+
+	client, err := initApiClient(flags)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !args.marksOnly &&
+		!config.IsOutputRedirected() &&
 		!flags.IsOutputSpecified() {
-		return errors.New("you must use '-o' or '-m' or redirect stdout when redacting files")
+		return nil, nil, errors.New("you must use '-o' or '-m' or redirect stdout when redacting files")
 	}
 
 	fileRedactor := ch360.NewFileRedactor(client.Documents, client.Documents, client.Documents,
 		client.Documents)
 
-	cmd.RedactionService = services.NewParallelRedactionService(fileRedactor, client.Documents, progressHandler)
-	cmd.ExtractorName = args.extractorName
+	redactionService := services.NewParallelRedactionService(fileRedactor, client.Documents,
+		progressHandler).
+		WithFileOpener(files.Open).
+		WithRecorder(flags.Telemetry)
 
-	return nil
-}
-
-// ExecuteRedact is the main entry point for the 'redact' command.
-func (cmd *RedactWithExtractorCmd) Execute(ctx context.Context) error {
-	err := cmd.RedactionService.RedactAllWithExtractor(ctx, cmd.FilePaths, cmd.ExtractorName)
+	if args.audit {
+		redactionService = redactionService.WithAudit()
+	}
 
-	return errors.Wrap(err, "redaction failed")
+	return redactionService, client, nil
 }
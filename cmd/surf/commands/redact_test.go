@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/waives/surf/ch360/request"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func writeAreasFile(t *testing.T, contents string) string {
+	file, err := ioutil.TempFile("", "areas*.json")
+	require.NoError(t, err)
+	defer file.Close()
+
+	_, err = file.WriteString(contents)
+	require.NoError(t, err)
+	return file.Name()
+}
+
+func TestReadRedactionAreas_Reads_Areas(t *testing.T) {
+	filename := writeAreasFile(t, `[{"page_number": 2, "top": 1, "left": 2, "bottom": 3, "right": 4}]`)
+
+	defer os.Remove(filename)
+
+	areas, err := readRedactionAreas(filename)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []request.RedactionArea{{Top: 1, Left: 2, Bottom: 3, Right: 4, PageNumber: 2}}, areas)
+}
+
+func TestReadRedactionAreas_Rejects_Invalid_Areas(t *testing.T) {
+	fixtures := []string{
+		`not json`,
+		`[]`,
+		`[{"page_number": 0, "top": 1, "left": 2, "bottom": 3, "right": 4}]`,
+		`[{"page_number": 1, "top": 3, "left": 2, "bottom": 3, "right": 4}]`,
+	}
+
+	for _, fixture := range fixtures {
+		filename := writeAreasFile(t, fixture)
+		_, err := readRedactionAreas(filename)
+		os.Remove(filename)
+
+		assert.Error(t, err, fixture)
+	}
+}
+
+func TestCompileRedactionPatterns(t *testing.T) {
+	patterns, err := compileRedactionPatterns([]string{`\d+`}, []string{"ssn", "email"})
+
+	require.NoError(t, err)
+	require.Len(t, patterns, 3)
+	assert.True(t, patterns[0].MatchString("123 45 6789"))
+	assert.True(t, patterns[1].MatchString("jo@example.com"))
+	assert.Equal(t, `\d+`, patterns[2].String())
+}
+
+func TestCompileRedactionPatterns_Returns_Errors(t *testing.T) {
+	_, err := compileRedactionPatterns(nil, nil)
+	assert.EqualError(t, err, "At least one --regex or --preset must be specified")
+
+	_, err = compileRedactionPatterns([]string{`(`}, nil)
+	assert.Error(t, err)
+}
+
+func TestRedactionPresets_Match_Examples(t *testing.T) {
+	assert.Regexp(t, redactionPresets["iban"], "GB82 WEST 1234 5698 7654 32")
+	assert.Regexp(t, redactionPresets["iban"], "DE89370400440532013000")
+	assert.NotRegexp(t, redactionPresets["ssn"], "1234-56-789")
+}
@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"github.com/waives/surf/audit"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/ch360/request"
+	"github.com/waives/surf/config"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"io"
+	"io/ioutil"
+)
+
+type redactWithAreasArgs struct {
+	areasFilename string
+	filePatterns  []string
+}
+
+// RedactWithAreasCmd redacts the same, explicitly specified, areas of each file.
+type RedactWithAreasCmd struct {
+	Areas            []request.RedactionArea
+	FilePaths        []string
+	Options          ch360.RedactionOptions
+	MarksOnly        bool
+	RedactionService RedactionService
+	// inputs are the files to process, which are closed once they have been processed
+	inputs io.Closer
+}
+
+func configureRedactWithAreasCmd(ctx context.Context,
+	redactCli *kingpin.CmdClause,
+	redactArgs *redactArgs,
+	globalFlags *config.GlobalFlags) {
+	args := &redactWithAreasArgs{}
+	cmd := &RedactWithAreasCmd{}
+
+	redactWithAreasCli := redactCli.Command("with-areas",
+		"Redact areas of each page specified in a file.").
+		Action(func(parseContext *kingpin.ParseContext) error {
+			err := cmd.initWithArgs(ctx, args, redactArgs, globalFlags)
+			if err != nil {
+				return err
+			}
+			defer cmd.inputs.Close()
+
+			return cmd.Execute(ctx)
+		})
+
+	redactWithAreasCli.Arg("areas-file", "A json file containing the areas to redact, as an array "+
+		"of objects with 'page_number', 'top', 'left', 'bottom' and 'right' properties.").
+		Required().
+		StringVar(&args.areasFilename)
+
+	redactWithAreasCli.Arg("files", "The files to redact, or '-' to read from stdin. "+
+		"URLs, and zip and tar archives, are also accepted.").
+		StringsVar(&args.filePatterns)
+}
+
+func (cmd *RedactWithAreasCmd) initWithArgs(ctx context.Context,
+	args *redactWithAreasArgs, redactArgs *redactArgs, flags *config.GlobalFlags) error {
+	var err error
+
+	cmd.Areas, err = readRedactionAreas(args.areasFilename)
+
+	if err != nil {
+		return err
+	}
+
+	files, err := resolveInputs(ctx, args.filePatterns, flags)
+
+	if err != nil {
+		return err
+	}
+
+	cmd.FilePaths = files.Names()
+	cmd.inputs = files
+
+	cmd.RedactionService, _, err = newRedactionService(redactArgs, flags, files, audit.Configuration{
+		Method:    audit.MethodAreas,
+		AreasFile: args.areasFilename,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	cmd.Options = redactArgs.options()
+	cmd.MarksOnly = redactArgs.marksOnly
+
+	return nil
+}
+
+// Execute runs the 'redact with-areas' command.
+func (cmd *RedactWithAreasCmd) Execute(ctx context.Context) error {
+	return redactAll(ctx, cmd.RedactionService, cmd.FilePaths,
+		cmd.Options.Apply(ch360.AreaRedaction(cmd.Areas)), cmd.MarksOnly)
+}
+
+func readRedactionAreas(filename string) ([]request.RedactionArea, error) {
+	contents, err := ioutil.ReadFile(filename)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var areas []request.RedactionArea
+	err = json.Unmarshal(contents, &areas)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "The areas file '%s' is not valid", filename)
+	}
+
+	if len(areas) == 0 {
+		return nil, errors.Errorf("The areas file '%s' does not contain any areas", filename)
+	}
+
+	for i, area := range areas {
+		if area.PageNumber < 1 || area.Right <= area.Left || area.Bottom <= area.Top {
+			return nil, errors.Errorf("Area %d in the areas file '%s' is not valid: it must have "+
+				"a page_number of at least 1, and be of non-zero size", i+1, filename)
+		}
+	}
+
+	return areas, nil
+}
@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/waives/surf/audit"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/config"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// redactionPresets are the regular expressions used for commonly redacted data.
+var redactionPresets = map[string]string{
+	"email": `[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`,
+	"iban":  `\b[A-Z]{2}[0-9]{2}(?: ?[A-Z0-9]{4}){2,7}(?: ?[A-Z0-9]{1,3})?\b`,
+	"ssn":   `\b[0-9]{3}[- ]?[0-9]{2}[- ]?[0-9]{4}\b`,
+}
+
+type redactWithPatternArgs struct {
+	regexes      []string
+	presets      []string
+	filePatterns []string
+}
+
+// RedactWithPatternCmd redacts any text in each file which matches one of a
+// set of regular expressions.
+type RedactWithPatternCmd struct {
+	Patterns         []*regexp.Regexp
+	FilePaths        []string
+	DocumentReader   ch360.DocumentReader
+	Options          ch360.RedactionOptions
+	MarksOnly        bool
+	RedactionService RedactionService
+	// inputs are the files to process, which are closed once they have been processed
+	inputs io.Closer
+}
+
+func configureRedactWithPatternCmd(ctx context.Context,
+	redactCli *kingpin.CmdClause,
+	redactArgs *redactArgs,
+	globalFlags *config.GlobalFlags) {
+	args := &redactWithPatternArgs{}
+	cmd := &RedactWithPatternCmd{}
+
+	redactWithPatternCli := redactCli.Command("with-pattern",
+		"Read each file, and redact any text matching a regular expression.").
+		Action(func(parseContext *kingpin.ParseContext) error {
+			err := cmd.initWithArgs(ctx, args, redactArgs, globalFlags)
+			if err != nil {
+				return err
+			}
+			defer cmd.inputs.Close()
+
+			return cmd.Execute(ctx)
+		})
+
+	redactWithPatternCli.Flag("regex", "A regular expression matching the text to redact. "+
+		"May be specified more than once.").
+		Short('r').
+		PlaceHolder("pattern").
+		StringsVar(&args.regexes)
+
+	redactWithPatternCli.Flag("preset", "A predefined pattern to redact. May be specified more "+
+		"than once. Allowed values: "+strings.Join(sortedPresetNames(), ", ")+".").
+		EnumsVar(&args.presets, sortedPresetNames()...)
+
+	redactWithPatternCli.Arg("files", "The files to redact, or '-' to read from stdin. "+
+		"URLs, and zip and tar archives, are also accepted.").
+		StringsVar(&args.filePatterns)
+}
+
+func (cmd *RedactWithPatternCmd) initWithArgs(ctx context.Context,
+	args *redactWithPatternArgs, redactArgs *redactArgs, flags *config.GlobalFlags) error {
+	var err error
+
+	cmd.Patterns, err = compileRedactionPatterns(args.regexes, args.presets)
+
+	if err != nil {
+		return err
+	}
+
+	files, err := resolveInputs(ctx, args.filePatterns, flags)
+
+	if err != nil {
+		return err
+	}
+
+	cmd.FilePaths = files.Names()
+	cmd.inputs = files
+
+	redactionService, client, err := newRedactionService(redactArgs, flags, files, audit.Configuration{
+		Method:   audit.MethodPattern,
+		Patterns: patternStrings(cmd.Patterns),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	cmd.RedactionService = redactionService
+	cmd.DocumentReader = client.Documents
+	cmd.Options = redactArgs.options()
+	cmd.MarksOnly = redactArgs.marksOnly
+
+	return nil
+}
+
+// Execute runs the 'redact with-pattern' command.
+func (cmd *RedactWithPatternCmd) Execute(ctx context.Context) error {
+	builder := ch360.NewPatternRedaction(cmd.DocumentReader, cmd.Patterns)
+
+	return redactAll(ctx, cmd.RedactionService, cmd.FilePaths, cmd.Options.Apply(builder),
+		cmd.MarksOnly)
+}
+
+func compileRedactionPatterns(regexes []string, presets []string) ([]*regexp.Regexp, error) {
+	if len(regexes) == 0 && len(presets) == 0 {
+		return nil, errors.New("At least one --regex or --preset must be specified")
+	}
+
+	var patterns []*regexp.Regexp
+	for _, preset := range presets {
+		patterns = append(patterns, regexp.MustCompile(redactionPresets[preset]))
+	}
+
+	for _, regex := range regexes {
+		pattern, err := regexp.Compile(regex)
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "The regular expression '%s' is not valid", regex)
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	return patterns, nil
+}
+
+func sortedPresetNames() []string {
+	var names []string
+	for name := range redactionPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func patternStrings(patterns []*regexp.Regexp) []string {
+	result := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		result[i] = pattern.String()
+	}
+	return result
+}
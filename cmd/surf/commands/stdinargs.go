@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"github.com/waives/surf/inputs"
+	"strings"
+)
+
+// EscapeStdinArgs rewrites the command line arguments so that kingpin accepts '-' (for
+// stdin), which it would otherwise reject as an empty short flag. A '-' following a
+// long flag becomes its value (--files-from -, becomes --files-from=-), and any other
+// '-' is moved after a '--' separator, so it is parsed as a positional argument.
+func EscapeStdinArgs(args []string) []string {
+	var (
+		escaped     []string
+		positionals []string
+	)
+
+	for i, arg := range args {
+		if arg == "--" {
+			// everything after the separator is already positional
+			escaped = append(escaped, "--")
+			escaped = append(escaped, positionals...)
+			return append(escaped, args[i+1:]...)
+		}
+
+		if arg != inputs.Stdin {
+			escaped = append(escaped, arg)
+			continue
+		}
+
+		if last := len(escaped) - 1; last >= 0 && isLongFlagWithoutValue(escaped[last]) {
+			escaped[last] += "=" + arg
+		} else {
+			positionals = append(positionals, arg)
+		}
+	}
+
+	if len(positionals) > 0 {
+		escaped = append(escaped, "--")
+		escaped = append(escaped, positionals...)
+	}
+
+	return escaped
+}
+
+func isLongFlagWithoutValue(arg string) bool {
+	return strings.HasPrefix(arg, "--") && len(arg) > 2 && !strings.Contains(arg, "=")
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEscapeStdinArgs(t *testing.T) {
+	fixtures := []struct {
+		args     []string
+		expected []string
+	}{
+		{
+			args:     []string{"read", "-f", "txt", "file.pdf"},
+			expected: []string{"read", "-f", "txt", "file.pdf"},
+		},
+		{
+			args:     []string{"read", "-", "-m"},
+			expected: []string{"read", "-m", "--", "-"},
+		},
+		{
+			args:     []string{"read", "--files-from", "-", "-0"},
+			expected: []string{"read", "--files-from=-", "-0"},
+		},
+		{
+			args:     []string{"read", "-", "--", "--file.pdf"},
+			expected: []string{"read", "--", "-", "--file.pdf"},
+		},
+		{
+			args:     []string{"read", "--", "-"},
+			expected: []string{"read", "--", "-"},
+		},
+	}
+
+	for _, fixture := range fixtures {
+		assert.Equal(t, fixture.expected, EscapeStdinArgs(fixture.args), "%v", fixture.args)
+	}
+}
@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/waives/surf/config"
+	"github.com/waives/surf/workspace"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"io"
+	"os"
+)
+
+//go:generate mockery -name "WorkspaceSyncer"
+type WorkspaceSyncer interface {
+	Plan(ctx context.Context, manifest *workspace.Manifest) (workspace.Plan, error)
+	Apply(ctx context.Context, change workspace.Change) error
+}
+
+// SyncCmd brings the classifiers and extractors in a waives account in line with
+// those declared in a manifest file. Undeclared resources are only deleted with --prune.
+type SyncCmd struct {
+	Syncer   WorkspaceSyncer
+	Manifest *workspace.Manifest
+	DryRun   bool
+	Output   io.Writer
+}
+
+type syncArgs struct {
+	manifestFilename string
+	dryRun           bool
+	prune            bool
+}
+
+// ConfigureSyncCommand configures kingpin with the 'sync' command.
+func ConfigureSyncCommand(ctx context.Context, app *kingpin.Application,
+	flags *config.GlobalFlags) {
+	args := &syncArgs{}
+	syncCmd := &SyncCmd{}
+
+	syncCli := app.Command("sync", "Create, replace or delete classifiers and extractors "+
+		"to match those declared in a manifest file.").
+		Action(func(parseContext *kingpin.ParseContext) error {
+			err := syncCmd.initFromArgs(args, flags)
+
+			if err != nil {
+				return err
+			}
+
+			return syncCmd.Execute(ctx)
+		})
+
+	syncCli.Flag("manifest", "The manifest file (yaml).").
+		Short('f').
+		Default(workspace.DefaultManifestFilename).
+		PlaceHolder("file").
+		StringVar(&args.manifestFilename)
+
+	syncCli.Flag("dry-run", "Show the changes which would be made, without making them.").
+		BoolVar(&args.dryRun)
+
+	syncCli.Flag("prune", "Delete classifiers and extractors which are not declared in the "+
+		"manifest. Only the kinds of resource listed in the manifest are deleted.").
+		BoolVar(&args.prune)
+}
+
+// Execute runs the 'sync' command.
+func (cmd *SyncCmd) Execute(ctx context.Context) error {
+	plan, err := cmd.Syncer.Plan(ctx, cmd.Manifest)
+
+	if err != nil {
+		return err
+	}
+
+	if plan.IsEmpty() {
+		_, err = fmt.Fprintln(cmd.Output, "No changes required.")
+		return err
+	}
+
+	if cmd.DryRun {
+		_, err = fmt.Fprintln(cmd.Output, "The following changes would be made:")
+		for _, change := range plan {
+			if err == nil {
+				_, err = fmt.Fprintf(cmd.Output, "  %s\n", change)
+			}
+		}
+		return err
+	}
+
+	for _, change := range plan {
+		err = ExecuteWithMessage(progressMessageFor(change), func() error {
+			return cmd.Syncer.Apply(ctx, change)
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func progressMessageFor(change workspace.Change) string {
+	verbs := map[workspace.Action]string{
+		workspace.ActionCreate:  "Creating",
+		workspace.ActionReplace: "Replacing",
+		workspace.ActionDelete:  "Deleting",
+	}
+
+	return fmt.Sprintf("%s %s '%s'... ", verbs[change.Action], change.Kind, change.Name)
+}
+
+func (cmd *SyncCmd) initFromArgs(args *syncArgs, flags *config.GlobalFlags) error {
+	var err error
+
+	cmd.Manifest, err = workspace.LoadManifest(args.manifestFilename)
+
+	if err != nil {
+		return err
+	}
+
+	client, err := initApiClient(flags)
+
+	if err != nil {
+		return err
+	}
+
+	cmd.Syncer = workspace.NewSyncer(client.Classifiers, client.Extractors).
+		WithPruning(args.prune)
+	cmd.DryRun = args.dryRun
+	cmd.Output = os.Stdout
+	return nil
+}
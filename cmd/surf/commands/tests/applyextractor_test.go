@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/cmd/surf/commands"
+	"github.com/waives/surf/cmd/surf/commands/mocks"
+	"testing"
+)
+
+type ApplyExtractorSuite struct {
+	suite.Suite
+	sut    *commands.ApplyExtractorCmd
+	client *mocks.ExtractorApplier
+	output *bytes.Buffer
+	ctx    context.Context
+}
+
+func (suite *ApplyExtractorSuite) SetupTest() {
+	suite.client = new(mocks.ExtractorApplier)
+	suite.output = &bytes.Buffer{}
+	suite.ctx = context.Background()
+
+	suite.client.On("GetAll", mock.Anything).Return(AListOfExtractors("charlie", "jo"), nil)
+	suite.client.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	suite.sut = &commands.ApplyExtractorCmd{
+		Client:        suite.client,
+		ExtractorName: "charlie",
+		Template:      anExtractorTemplate("waives.date"),
+		Output:        suite.output,
+	}
+}
+
+func TestApplyExtractorSuiteRunner(t *testing.T) {
+	suite.Run(t, new(ApplyExtractorSuite))
+}
+
+func (suite *ApplyExtractorSuite) TestExecute_Creates_Extractor_Which_Does_Not_Exist() {
+	suite.sut.ExtractorName = "chris"
+	suite.client.On("CreateFromModules", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	suite.client.AssertCalled(suite.T(), "CreateFromModules", suite.ctx, "chris", *suite.sut.Template)
+	suite.client.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+	assert.Equal(suite.T(), "Extractor 'chris' created.\n", suite.output.String())
+}
+
+func (suite *ApplyExtractorSuite) TestExecute_Does_Nothing_When_Extractor_Matches_Template() {
+	suite.client.On("GetTemplate", mock.Anything, mock.Anything).
+		Return(anExtractorTemplate("waives.date"), nil)
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	suite.client.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+	suite.client.AssertNotCalled(suite.T(), "CreateFromModules", mock.Anything, mock.Anything,
+		mock.Anything)
+	assert.Equal(suite.T(), "Extractor 'charlie' unchanged.\n", suite.output.String())
+}
+
+func (suite *ApplyExtractorSuite) TestExecute_Replaces_Extractor_Which_Differs_From_Template() {
+	suite.client.On("GetTemplate", mock.Anything, mock.Anything).
+		Return(anExtractorTemplate("waives.amount"), nil)
+	suite.client.On("CreateFromModules", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	suite.client.AssertCalled(suite.T(), "Delete", suite.ctx, "charlie")
+	suite.client.AssertCalled(suite.T(), "CreateFromModules", suite.ctx, "charlie", *suite.sut.Template)
+	assert.Equal(suite.T(), "Extractor 'charlie' replaced.\n", suite.output.String())
+}
+
+func (suite *ApplyExtractorSuite) TestExecute_Restores_Previous_Extractor_If_Replacement_Fails() {
+	previousTemplate := anExtractorTemplate("waives.amount")
+	expectedErr := errors.New("simulated error")
+	suite.client.On("GetTemplate", mock.Anything, mock.Anything).Return(previousTemplate, nil)
+	suite.client.On("CreateFromModules", mock.Anything, mock.Anything, *suite.sut.Template).
+		Return(expectedErr)
+	suite.client.On("CreateFromModules", mock.Anything, mock.Anything, *previousTemplate).
+		Return(nil)
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.Equal(suite.T(), expectedErr, err)
+	suite.client.AssertCalled(suite.T(), "CreateFromModules", suite.ctx, "charlie", *previousTemplate)
+}
+
+func (suite *ApplyExtractorSuite) TestExecute_Returns_Error_If_Extractors_Cannot_Be_Retrieved() {
+	expectedErr := errors.New("simulated error")
+	suite.client.ExpectedCalls = nil
+	suite.client.On("GetAll", mock.Anything).Return(nil, expectedErr)
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.Equal(suite.T(), expectedErr, err)
+}
@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/cache"
+	"github.com/waives/surf/cmd/surf/commands"
+	"github.com/waives/surf/cmd/surf/commands/mocks"
+	"testing"
+	"time"
+)
+
+type CacheSuite struct {
+	suite.Suite
+	cache  *mocks.ResultCacheManager
+	output *bytes.Buffer
+	ctx    context.Context
+}
+
+func (suite *CacheSuite) SetupTest() {
+	suite.cache = new(mocks.ResultCacheManager)
+	suite.output = &bytes.Buffer{}
+	suite.ctx = context.Background()
+}
+
+func TestCacheSuiteRunner(t *testing.T) {
+	suite.Run(t, new(CacheSuite))
+}
+
+func (suite *CacheSuite) TestStats_Writes_Table_Of_Operations() {
+	suite.cache.On("Stats").Return(cache.Stats{
+		{Operation: cache.OperationClassify, Entries: 3, Size: 2048},
+	}, nil)
+	sut := &commands.CacheStatsCmd{Cache: suite.cache, Output: suite.output}
+
+	err := sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), suite.output.String(), "classify")
+	assert.Contains(suite.T(), suite.output.String(), "2KiB")
+}
+
+func (suite *CacheSuite) TestStats_Reports_Empty_Cache() {
+	suite.cache.On("Stats").Return(nil, nil)
+	sut := &commands.CacheStatsCmd{Cache: suite.cache, Output: suite.output}
+
+	err := sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "The cache is empty.\n", suite.output.String())
+}
+
+func (suite *CacheSuite) TestPrune_Passes_Duration_To_Cache() {
+	suite.cache.On("Prune", mock.Anything).Return(2, nil)
+	sut := &commands.CachePruneCmd{Cache: suite.cache, UnusedFor: time.Hour, Output: suite.output}
+
+	err := sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	suite.cache.AssertCalled(suite.T(), "Prune", time.Hour)
+	assert.Equal(suite.T(), "Removed 2 cached results.\n", suite.output.String())
+}
+
+func (suite *CacheSuite) TestPrune_Returns_Error_From_Cache() {
+	expectedErr := errors.New("simulated error")
+	suite.cache.On("Prune", mock.Anything).Return(0, expectedErr)
+	sut := &commands.CachePruneCmd{Cache: suite.cache, Output: suite.output}
+
+	err := sut.Execute(suite.ctx)
+
+	assert.Equal(suite.T(), expectedErr, err)
+}
@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
 	"errors"
@@ -10,7 +11,9 @@ import (
 	"github.com/waives/surf/cmd/surf/commands"
 	"github.com/waives/surf/cmd/surf/commands/mocks"
 	"github.com/waives/surf/test/generators"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -128,3 +131,94 @@ func (suite *CreateClassifierSuite) TestCreateClassifier_Execute_Deletes_The_Cla
 
 	suite.deleter.AssertCalled(suite.T(), "Delete", suite.ctx, suite.classifierName)
 }
+
+// aSamplesDirectory creates a directory containing the files (with paths relative to
+// it), returning its path.
+func (suite *CreateClassifierSuite) aSamplesDirectory(files ...string) string {
+	dir, err := ioutil.TempDir("", "surf-samples")
+	suite.Require().NoError(err)
+
+	for _, file := range files {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		suite.Require().NoError(os.MkdirAll(filepath.Dir(path), 0777))
+		suite.Require().NoError(ioutil.WriteFile(path, []byte(file), 0666))
+	}
+
+	return dir
+}
+
+func (suite *CreateClassifierSuite) TestCreateClassifier_Execute_Trains_The_Classifier_From_A_Samples_Directory() {
+	dir := suite.aSamplesDirectory("invoice/1.pdf", "invoice/2.pdf", "receipt/nested/3.tif")
+	defer os.RemoveAll(dir)
+	var (
+		archiveFilename string
+		archivedFiles   []string
+	)
+	suite.ClearExpectedCalls(false, true, false)
+	suite.trainer.On("Train", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			archive := args.Get(2).(*os.File)
+			archiveFilename = archive.Name()
+			info, err := archive.Stat()
+			suite.Require().NoError(err)
+			reader, err := zip.NewReader(archive, info.Size())
+			suite.Require().NoError(err)
+			for _, file := range reader.File {
+				archivedFiles = append(archivedFiles, file.Name)
+			}
+		}).
+		Return(nil)
+	suite.sut = suite.aClassifierCommandWithSamplesArchive(nil)
+	suite.sut.SamplesDirectory = dir
+	suite.sut.Output = suite.output
+
+	err := suite.sut.Execute(suite.ctx)
+
+	suite.Require().NoError(err)
+	suite.creator.AssertCalled(suite.T(), "Create", suite.ctx, suite.classifierName)
+	assert.Equal(suite.T(), []string{"invoice/1.pdf", "invoice/2.pdf", "receipt/3.tif"}, archivedFiles)
+	assert.Contains(suite.T(), suite.output.String(), "invoice")
+	assert.Contains(suite.T(), suite.output.String(), "receipt")
+	_, err = os.Stat(archiveFilename)
+	assert.True(suite.T(), os.IsNotExist(err), "the samples archive is removed")
+}
+
+func (suite *CreateClassifierSuite) TestCreateClassifier_Execute_Returns_Error_If_Samples_Directory_Is_Not_Valid() {
+	fixtures := []struct {
+		name          string
+		files         []string
+		expectedError string
+	}{
+		{"empty", nil, "samples of at least two document types are required"},
+		{"unlabelled", []string{"1.pdf", "2.pdf"}, "samples of at least two document types are required"},
+		{"one document type", []string{"invoice/1.pdf"}, "samples of at least two document types are required"},
+		{"unsupported files", []string{"invoice/1.pdf", "receipt/2.exe"},
+			"the following files are not of a supported type"},
+	}
+
+	for _, fixture := range fixtures {
+		dir := suite.aSamplesDirectory(fixture.files...)
+		suite.sut = suite.aClassifierCommandWithSamplesArchive(nil)
+		suite.sut.SamplesDirectory = dir
+		suite.sut.Output = suite.output
+
+		err := suite.sut.Execute(suite.ctx)
+
+		os.RemoveAll(dir)
+		if assert.Error(suite.T(), err, fixture.name) {
+			assert.Contains(suite.T(), err.Error(), fixture.expectedError, fixture.name)
+		}
+		suite.creator.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+	}
+}
+
+func (suite *CreateClassifierSuite) TestCreateClassifier_Execute_Returns_Error_If_Samples_Directory_Does_Not_Exist() {
+	suite.sut = suite.aClassifierCommandWithSamplesArchive(nil)
+	suite.sut.SamplesDirectory = filepath.Join(os.TempDir(), generators.String("missing-samples"))
+	suite.sut.Output = suite.output
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.Error(suite.T(), err)
+	suite.creator.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
@@ -91,7 +91,7 @@ Module waives.supplier_identity:
 
 	receivedErr := suite.sut.Execute(context.Background())
 
-	assert.Error(suite.T(), receivedErr, expectedErrMsg)
+	assert.EqualError(suite.T(), receivedErr, expectedErrMsg)
 }
 
 func aDetailedErrorResponse() *net.DetailedErrorResponse {
@@ -9,6 +9,9 @@ import (
 	"github.com/waives/surf/ch360"
 	"github.com/waives/surf/cmd/surf/commands"
 	"github.com/waives/surf/cmd/surf/commands/mocks"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -96,6 +99,44 @@ func (suite *CreateExtractorTemplateSuite) Test_CreateExtractorTemplate_Writes_A
 	assert.Equal(suite.T(), 3, len(template.Modules))
 }
 
+func (suite *CreateExtractorTemplateSuite) Test_CreateExtractorTemplate_Writes_Template_To_Output_File() {
+	// Arrange
+	dir, err := ioutil.TempDir("", "surf-create-extractor-template")
+	suite.Require().NoError(err)
+	defer os.RemoveAll(dir)
+	suite.sut.OutputFile = filepath.Join(dir, "template.json")
+
+	// Act
+	err = suite.sut.Execute(suite.ctx)
+
+	// Assert
+	suite.Require().NoError(err)
+	contents, err := os.Open(suite.sut.OutputFile)
+	suite.Require().NoError(err)
+	defer contents.Close()
+	template, err := ch360.NewModulesTemplateFromJson(contents)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, len(template.Modules))
+	assert.Empty(suite.T(), suite.output.String())
+}
+
+func (suite *CreateExtractorTemplateSuite) Test_CreateExtractorTemplate_Does_Not_Create_Output_File_If_Modules_Do_Not_Exist() {
+	// Arrange
+	dir, err := ioutil.TempDir("", "surf-create-extractor-template")
+	suite.Require().NoError(err)
+	defer os.RemoveAll(dir)
+	suite.sut.ModuleIds = []string{"missingModule"}
+	suite.sut.OutputFile = filepath.Join(dir, "template.json")
+
+	// Act
+	err = suite.sut.Execute(suite.ctx)
+
+	// Assert
+	assert.Error(suite.T(), err)
+	_, err = os.Stat(suite.sut.OutputFile)
+	assert.True(suite.T(), os.IsNotExist(err))
+}
+
 func (suite *CreateExtractorTemplateSuite) Test_CreateExtractorTemplate_Is_Case_Insensitive() {
 	// Arrange
 	moduleIds := suite.moduleIds
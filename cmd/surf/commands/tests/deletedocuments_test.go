@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/ch360"
 	"github.com/waives/surf/cmd/surf/commands"
 	"github.com/waives/surf/cmd/surf/commands/mocks"
 	"testing"
@@ -88,3 +89,43 @@ func (suite *DeleteDocumentSuite) TestDeleteDocument_Retrieves_All_Documents_Whe
 
 	suite.client.AssertCalled(suite.T(), "GetAll", suite.ctx)
 }
+
+func (suite *DeleteDocumentSuite) TestDeleteDocument_Deletes_Only_Matching_Documents_When_Filter_Is_Specified() {
+	documents := aListOfDocuments(suite.documentIds...)
+	documents[1].FileType = "Image:TIFF"
+	suite.client.ExpectedCalls = nil
+	suite.client.
+		On("GetAll", mock.Anything).
+		Return(documents, nil)
+	suite.client.
+		On("Delete", mock.Anything, mock.Anything).
+		Return(nil)
+	suite.sut.DocumentIDs = nil
+	suite.sut.Filter = ch360.DocumentFilter{FileType: "tiff"}
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	suite.client.AssertNumberOfCalls(suite.T(), "Delete", 1)
+	suite.client.AssertCalled(suite.T(), "Delete", suite.ctx, "jo")
+}
+
+func (suite *DeleteDocumentSuite) TestDeleteDocument_Filter_Narrows_Provided_Document_IDs() {
+	documents := aListOfDocuments(suite.documentIds...)
+	documents[0].FileType = "Image:TIFF"
+	documents[1].FileType = "Image:TIFF"
+	suite.client.ExpectedCalls = nil
+	suite.client.
+		On("GetAll", mock.Anything).
+		Return(documents, nil)
+	suite.client.
+		On("Delete", mock.Anything, mock.Anything).
+		Return(nil)
+	suite.sut.DocumentIDs = []string{"jo", "chris"}
+	suite.sut.Filter = ch360.DocumentFilter{FileType: "tiff"}
+
+	_ = suite.sut.Execute(suite.ctx)
+
+	suite.client.AssertNumberOfCalls(suite.T(), "Delete", 1)
+	suite.client.AssertCalled(suite.T(), "Delete", suite.ctx, "jo")
+}
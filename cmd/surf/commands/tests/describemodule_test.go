@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/cmd/surf/commands"
+	"github.com/waives/surf/cmd/surf/commands/mocks"
+	"testing"
+)
+
+type DescribeModuleSuite struct {
+	suite.Suite
+	sut    *commands.DescribeModuleCmd
+	client *mocks.ModuleGetter
+	output *bytes.Buffer
+	ctx    context.Context
+}
+
+func (suite *DescribeModuleSuite) SetupTest() {
+	suite.client = new(mocks.ModuleGetter)
+	suite.output = &bytes.Buffer{}
+	suite.ctx = context.Background()
+
+	suite.client.On("GetAll", mock.Anything).Return(ch360.ModuleList{
+		{
+			ID:      "waives.reference_number",
+			Name:    "Reference Number",
+			Summary: "Identifies reference numbers.",
+			Fields: []ch360.ModuleField{
+				{Name: "Reference Number", Description: nil},
+				{Name: "Prefix", Description: "The prefix of the number."},
+			},
+			Parameters: []ch360.ModuleParameter{
+				{ID: "format", Name: "Format", Type: "Regex",
+					Description: "The format of the number.", Required: true},
+			},
+		},
+	}, nil)
+
+	suite.sut = &commands.DescribeModuleCmd{
+		Client:   suite.client,
+		ModuleID: "WAIVES.reference_number",
+		Output:   suite.output,
+	}
+}
+
+func TestDescribeModuleSuiteRunner(t *testing.T) {
+	suite.Run(t, new(DescribeModuleSuite))
+}
+
+func (suite *DescribeModuleSuite) TestExecute_Writes_Module_Details() {
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	output := suite.output.String()
+	assert.Contains(suite.T(), output, "ID:          waives.reference_number\n")
+	assert.Contains(suite.T(), output, "Name:        Reference Number\n")
+	assert.Contains(suite.T(), output, "Summary:     Identifies reference numbers.\n")
+	assert.NotContains(suite.T(), output, "Description:")
+	assert.Regexp(suite.T(), `format\s+Format\s+Regex\s+true\s+The format of the number\.`, output)
+	assert.Regexp(suite.T(), `Prefix\s+The prefix of the number\.`, output)
+	assert.NotContains(suite.T(), output, "<nil>")
+}
+
+func (suite *DescribeModuleSuite) TestExecute_Returns_Error_If_Module_Does_Not_Exist() {
+	suite.sut.ModuleID = "waives.unknown"
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.EqualError(suite.T(), err, "There is no module with ID 'waives.unknown'.")
+}
+
+func (suite *DescribeModuleSuite) TestExecute_Returns_Error_If_Modules_Cannot_Be_Retrieved() {
+	expectedErr := errors.New("simulated error")
+	suite.client.ExpectedCalls = nil
+	suite.client.On("GetAll", mock.Anything).Return(nil, expectedErr)
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.Equal(suite.T(), expectedErr, err)
+}
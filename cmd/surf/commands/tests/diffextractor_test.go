@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/cmd/surf/commands"
+	"github.com/waives/surf/cmd/surf/commands/mocks"
+	"testing"
+)
+
+type DiffExtractorSuite struct {
+	suite.Suite
+	sut    *commands.DiffExtractorCmd
+	client *mocks.ExtractorTemplateGetter
+	output *bytes.Buffer
+	ctx    context.Context
+}
+
+func (suite *DiffExtractorSuite) SetupTest() {
+	suite.client = new(mocks.ExtractorTemplateGetter)
+	suite.output = &bytes.Buffer{}
+	suite.ctx = context.Background()
+
+	suite.sut = &commands.DiffExtractorCmd{
+		Client:           suite.client,
+		ExtractorName:    "extractor",
+		Template:         anExtractorTemplate("waives.date", "waives.amount"),
+		TemplateFilename: "template.json",
+		Output:           suite.output,
+	}
+}
+
+func TestDiffExtractorSuiteRunner(t *testing.T) {
+	suite.Run(t, new(DiffExtractorSuite))
+}
+
+func (suite *DiffExtractorSuite) TestExecute_Reports_No_Differences_For_Matching_Extractor() {
+	suite.client.On("GetTemplate", mock.Anything, mock.Anything).
+		Return(anExtractorTemplate("waives.date", "waives.amount"), nil)
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Extractor 'extractor' matches template.json.\n", suite.output.String())
+}
+
+func (suite *DiffExtractorSuite) TestExecute_Writes_Diff_For_Differing_Extractor() {
+	suite.client.On("GetTemplate", mock.Anything, mock.Anything).
+		Return(anExtractorTemplate("waives.date", "waives.reference_number"), nil)
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), suite.output.String(), "--- extractor/extractor\n+++ template.json\n")
+	assert.Contains(suite.T(), suite.output.String(), `-      "id": "waives.reference_number"`)
+	assert.Contains(suite.T(), suite.output.String(), `+      "id": "waives.amount"`)
+}
@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/ch360/results"
+	"github.com/waives/surf/cmd/surf/commands"
+	"github.com/waives/surf/cmd/surf/commands/mocks"
+	"github.com/waives/surf/evaluation"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type EvaluateClassifierSuite struct {
+	suite.Suite
+	sut     *commands.EvaluateClassifierCmd
+	service *mocks.ClassificationService
+	output  *bytes.Buffer
+	ctx     context.Context
+}
+
+func (suite *EvaluateClassifierSuite) SetupTest() {
+	suite.service = new(mocks.ClassificationService)
+	suite.output = &bytes.Buffer{}
+	suite.ctx = context.Background()
+
+	suite.sut = &commands.EvaluateClassifierCmd{
+		ClassificationService: suite.service,
+		Evaluator: evaluation.NewClassificationEvaluator(map[string]string{
+			"invoice.pdf": "invoice",
+			"receipt.pdf": "receipt",
+		}),
+		FilePaths:      []string{"invoice.pdf", "receipt.pdf"},
+		ClassifierName: "classifier",
+		OutputFormat:   "table",
+		Output:         suite.output,
+	}
+
+	// simulate the service passing each result to the evaluator
+	suite.service.On("ClassifyAll", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			_ = suite.sut.Evaluator.WriteResult("invoice.pdf",
+				&results.ClassificationResult{DocumentType: "invoice", IsConfident: true})
+			_ = suite.sut.Evaluator.WriteResult("receipt.pdf",
+				&results.ClassificationResult{DocumentType: "invoice"})
+		}).
+		Return(nil)
+}
+
+func TestEvaluateClassifierSuiteRunner(t *testing.T) {
+	suite.Run(t, new(EvaluateClassifierSuite))
+}
+
+func (suite *EvaluateClassifierSuite) TestExecute_Classifies_All_Files() {
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	suite.service.AssertCalled(suite.T(), "ClassifyAll", suite.ctx, suite.sut.FilePaths, "classifier")
+}
+
+func (suite *EvaluateClassifierSuite) TestExecute_Writes_Report_Table() {
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), suite.output.String(), "Accuracy: 50.0% (1/2)")
+	assert.Contains(suite.T(), suite.output.String(), "Confusion matrix")
+	assert.Contains(suite.T(), suite.output.String(), "Confident results:     1 (50.0% of files), 100.0% accurate")
+}
+
+func (suite *EvaluateClassifierSuite) TestExecute_Writes_Report_Json() {
+	suite.sut.OutputFormat = "json"
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	var report evaluation.ClassificationReport
+	assert.NoError(suite.T(), json.Unmarshal(suite.output.Bytes(), &report))
+	assert.Equal(suite.T(), 0.5, report.Accuracy)
+}
+
+func (suite *EvaluateClassifierSuite) TestExecute_Returns_Error_If_Classification_Fails() {
+	suite.service.ExpectedCalls = nil
+	suite.service.On("ClassifyAll", mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("simulated error"))
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.EqualError(suite.T(), err, "classification failed: simulated error")
+}
+
+func (suite *EvaluateClassifierSuite) TestExecute_Writes_Report_To_Output_File() {
+	dir, err := ioutil.TempDir("", "surf-evaluate-classifier")
+	suite.Require().NoError(err)
+	defer os.RemoveAll(dir)
+	suite.sut.OutputFile = filepath.Join(dir, "report.txt")
+
+	err = suite.sut.Execute(suite.ctx)
+
+	suite.Require().NoError(err)
+	contents, err := ioutil.ReadFile(suite.sut.OutputFile)
+	suite.Require().NoError(err)
+	assert.Contains(suite.T(), string(contents), "Accuracy: 50.0% (1/2)")
+	assert.Empty(suite.T(), suite.output.String())
+}
+
+func (suite *EvaluateClassifierSuite) TestExecute_Does_Not_Replace_Output_File_If_Classification_Fails() {
+	dir, err := ioutil.TempDir("", "surf-evaluate-classifier")
+	suite.Require().NoError(err)
+	defer os.RemoveAll(dir)
+	suite.sut.OutputFile = filepath.Join(dir, "report.txt")
+	suite.Require().NoError(ioutil.WriteFile(suite.sut.OutputFile, []byte("previous report"), 0644))
+	suite.service.ExpectedCalls = nil
+	suite.service.On("ClassifyAll", mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("simulated error"))
+
+	err = suite.sut.Execute(suite.ctx)
+
+	assert.Error(suite.T(), err)
+	contents, err := ioutil.ReadFile(suite.sut.OutputFile)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "previous report", string(contents))
+}
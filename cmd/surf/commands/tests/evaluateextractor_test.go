@@ -0,0 +1,143 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/ch360/results"
+	"github.com/waives/surf/cmd/surf/commands"
+	"github.com/waives/surf/cmd/surf/commands/mocks"
+	"github.com/waives/surf/evaluation"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type EvaluateExtractorSuite struct {
+	suite.Suite
+	sut     *commands.EvaluateExtractorCmd
+	service *mocks.ExtractionService
+	output  *bytes.Buffer
+	ctx     context.Context
+}
+
+func (suite *EvaluateExtractorSuite) SetupTest() {
+	suite.service = new(mocks.ExtractionService)
+	suite.output = &bytes.Buffer{}
+	suite.ctx = context.Background()
+
+	truth := &evaluation.GroundTruth{
+		Fields: []string{"Amount"},
+		Files:  []string{"a.pdf", "b.pdf"},
+		Values: map[string]map[string]string{
+			"a.pdf": {"Amount": "10.00"},
+			"b.pdf": {"Amount": "20.00"},
+		},
+	}
+
+	suite.sut = &commands.EvaluateExtractorCmd{
+		ExtractionService: suite.service,
+		Evaluator:         evaluation.NewExtractionEvaluator(truth, evaluation.Matcher{Mode: evaluation.MatchExact}),
+		FilePaths:         truth.Files,
+		ExtractorName:     "extractor",
+		WorstDocuments:    10,
+		OutputFormat:      "table",
+		Output:            suite.output,
+	}
+
+	// simulate the service passing each result to the evaluator
+	suite.service.On("ExtractAll", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			_ = suite.sut.Evaluator.WriteResult("a.pdf", anExtractionResultWithAmount("10.00"))
+			_ = suite.sut.Evaluator.WriteResult("b.pdf", anExtractionResultWithAmount("21.00"))
+		}).
+		Return(nil)
+}
+
+func TestEvaluateExtractorSuiteRunner(t *testing.T) {
+	suite.Run(t, new(EvaluateExtractorSuite))
+}
+
+func anExtractionResultWithAmount(amount string) *results.ExtractionResult {
+	return &results.ExtractionResult{
+		FieldResults: []results.FieldResult{
+			{FieldName: "Amount", Result: &results.InnerResult{Text: amount}},
+		},
+	}
+}
+
+func (suite *EvaluateExtractorSuite) TestExecute_Extracts_From_All_Files() {
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	suite.service.AssertCalled(suite.T(), "ExtractAll", suite.ctx, suite.sut.FilePaths, "extractor")
+}
+
+func (suite *EvaluateExtractorSuite) TestExecute_Writes_Report_Table() {
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), suite.output.String(), "Documents: 2")
+	assert.Contains(suite.T(), suite.output.String(), "Documents with the most errors")
+	assert.Contains(suite.T(), suite.output.String(), "b.pdf")
+}
+
+func (suite *EvaluateExtractorSuite) TestExecute_Writes_Report_Json() {
+	suite.sut.OutputFormat = "json"
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	var report evaluation.ExtractionReport
+	assert.NoError(suite.T(), json.Unmarshal(suite.output.Bytes(), &report))
+	assert.Equal(suite.T(), 1, report.Fields[0].Correct)
+	assert.Equal(suite.T(), 1, report.Fields[0].Incorrect)
+}
+
+func (suite *EvaluateExtractorSuite) TestExecute_Returns_Error_If_Extraction_Fails() {
+	suite.service.ExpectedCalls = nil
+	suite.service.On("ExtractAll", mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("simulated error"))
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.EqualError(suite.T(), err, "extraction failed: simulated error")
+}
+
+func (suite *EvaluateExtractorSuite) TestExecute_Writes_Report_To_Output_File() {
+	dir, err := ioutil.TempDir("", "surf-evaluate-extractor")
+	suite.Require().NoError(err)
+	defer os.RemoveAll(dir)
+	suite.sut.OutputFile = filepath.Join(dir, "report.txt")
+
+	err = suite.sut.Execute(suite.ctx)
+
+	suite.Require().NoError(err)
+	contents, err := ioutil.ReadFile(suite.sut.OutputFile)
+	suite.Require().NoError(err)
+	assert.Contains(suite.T(), string(contents), "Documents: 2")
+	assert.Empty(suite.T(), suite.output.String())
+}
+
+func (suite *EvaluateExtractorSuite) TestExecute_Does_Not_Replace_Output_File_If_Extraction_Fails() {
+	dir, err := ioutil.TempDir("", "surf-evaluate-extractor")
+	suite.Require().NoError(err)
+	defer os.RemoveAll(dir)
+	suite.sut.OutputFile = filepath.Join(dir, "report.txt")
+	suite.Require().NoError(ioutil.WriteFile(suite.sut.OutputFile, []byte("previous report"), 0644))
+	suite.service.ExpectedCalls = nil
+	suite.service.On("ExtractAll", mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("simulated error"))
+
+	err = suite.sut.Execute(suite.ctx)
+
+	assert.Error(suite.T(), err)
+	contents, err := ioutil.ReadFile(suite.sut.OutputFile)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "previous report", string(contents))
+}
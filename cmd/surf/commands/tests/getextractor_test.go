@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/cmd/surf/commands"
+	"github.com/waives/surf/cmd/surf/commands/mocks"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type GetExtractorSuite struct {
+	suite.Suite
+	sut    *commands.GetExtractorCmd
+	client *mocks.ExtractorTemplateGetter
+	output *bytes.Buffer
+	ctx    context.Context
+}
+
+func (suite *GetExtractorSuite) SetupTest() {
+	suite.client = new(mocks.ExtractorTemplateGetter)
+	suite.output = &bytes.Buffer{}
+	suite.ctx = context.Background()
+
+	suite.sut = &commands.GetExtractorCmd{
+		Client:        suite.client,
+		ExtractorName: "extractor",
+		Output:        suite.output,
+	}
+}
+
+func TestGetExtractorSuiteRunner(t *testing.T) {
+	suite.Run(t, new(GetExtractorSuite))
+}
+
+func (suite *GetExtractorSuite) TestExecute_Writes_Indented_Template() {
+	suite.client.On("GetTemplate", mock.Anything, mock.Anything).Return(anExtractorTemplate("waives.date"), nil)
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	suite.client.AssertCalled(suite.T(), "GetTemplate", suite.ctx, "extractor")
+	assert.Equal(suite.T(), `{
+  "modules": [
+    {
+      "id": "waives.date"
+    }
+  ]
+}
+`, suite.output.String())
+}
+
+func (suite *GetExtractorSuite) TestExecute_Returns_Error_If_Extractor_Cannot_Be_Retrieved() {
+	suite.client.On("GetTemplate", mock.Anything, mock.Anything).Return(nil, errors.New("simulated error"))
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.EqualError(suite.T(), err, "failed to get extractor 'extractor': simulated error")
+}
+
+func (suite *GetExtractorSuite) TestExecute_Writes_Template_To_Output_File() {
+	suite.client.On("GetTemplate", mock.Anything, mock.Anything).Return(anExtractorTemplate("waives.date"), nil)
+	dir, err := ioutil.TempDir("", "surf-get-extractor")
+	suite.Require().NoError(err)
+	defer os.RemoveAll(dir)
+	suite.sut.OutputFile = filepath.Join(dir, "extractor.json")
+
+	err = suite.sut.Execute(suite.ctx)
+
+	suite.Require().NoError(err)
+	contents, err := ioutil.ReadFile(suite.sut.OutputFile)
+	suite.Require().NoError(err)
+	assert.Contains(suite.T(), string(contents), `"id": "waives.date"`)
+	assert.Empty(suite.T(), suite.output.String())
+}
+
+func (suite *GetExtractorSuite) TestExecute_Does_Not_Create_Output_File_If_Extractor_Cannot_Be_Retrieved() {
+	suite.client.On("GetTemplate", mock.Anything, mock.Anything).Return(nil, errors.New("simulated error"))
+	dir, err := ioutil.TempDir("", "surf-get-extractor")
+	suite.Require().NoError(err)
+	defer os.RemoveAll(dir)
+	suite.sut.OutputFile = filepath.Join(dir, "extractor.json")
+
+	err = suite.sut.Execute(suite.ctx)
+
+	assert.Error(suite.T(), err)
+	_, err = os.Stat(suite.sut.OutputFile)
+	assert.True(suite.T(), os.IsNotExist(err))
+}
+
+func anExtractorTemplate(moduleIds ...string) *ch360.ExtractorTemplate {
+	template := &ch360.ExtractorTemplate{}
+
+	for _, moduleId := range moduleIds {
+		template.Modules = append(template.Modules, ch360.ModuleTemplate{ID: moduleId})
+	}
+
+	return template
+}
@@ -1,15 +1,18 @@
 package tests
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/waives/surf/ch360"
 	mocks2 "github.com/waives/surf/ch360/mocks"
 	"github.com/waives/surf/cmd/surf/commands"
 	"github.com/waives/surf/test/generators"
+	"strings"
 	"testing"
 )
 
@@ -17,14 +20,17 @@ type ListDocumentSuite struct {
 	suite.Suite
 	sut    *commands.ListDocumentsCmd
 	client *mocks2.DocumentGetter
+	output *bytes.Buffer
 	ctx    context.Context
 }
 
 func (suite *ListDocumentSuite) SetupTest() {
 	suite.client = new(mocks2.DocumentGetter)
+	suite.output = &bytes.Buffer{}
 
 	suite.sut = &commands.ListDocumentsCmd{
 		Client: suite.client,
+		Output: suite.output,
 	}
 	suite.ctx = context.Background()
 }
@@ -65,3 +71,33 @@ func aListOfDocuments(ids ...string) ch360.DocumentList {
 
 	return expected
 }
+
+func (suite *ListDocumentSuite) TestGetAllDocuments_Execute_Writes_Filtered_Documents_In_Sorted_Order() {
+	suite.client.On("GetAll", mock.Anything).Return(ch360.DocumentList{
+		{Id: "large-tiff", Size: 300, FileType: "Image:TIFF", Sha256: "sha-1"},
+		{Id: "a-pdf", Size: 100, FileType: "PDF", Sha256: "sha-2"},
+		{Id: "small-tiff", Size: 200, FileType: "Image:TIFF", Sha256: "sha-3"},
+	}, nil)
+	suite.sut.Filter = ch360.DocumentFilter{FileType: "tiff"}
+	suite.sut.SortBy = ch360.SortDocumentsBySize
+
+	err := suite.sut.Execute(suite.ctx)
+
+	require.NoError(suite.T(), err)
+	output := suite.output.String()
+	assert.NotContains(suite.T(), output, "a-pdf")
+	smallIndex := strings.Index(output, "small-tiff")
+	largeIndex := strings.Index(output, "large-tiff")
+	require.True(suite.T(), smallIndex >= 0 && largeIndex >= 0, output)
+	assert.True(suite.T(), smallIndex < largeIndex, output)
+}
+
+func (suite *ListDocumentSuite) TestGetAllDocuments_Execute_Reports_When_No_Documents_Match_The_Filter() {
+	suite.client.On("GetAll", mock.Anything).Return(aListOfDocuments("charlie", "jo"), nil)
+	suite.sut.Filter = ch360.DocumentFilter{Sha256: "does-not-match"}
+
+	err := suite.sut.Execute(suite.ctx)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "No documents found.\n", suite.output.String())
+}
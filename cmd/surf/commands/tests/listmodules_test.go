@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"github.com/stretchr/testify/assert"
@@ -16,14 +17,17 @@ type ListModuleSuite struct {
 	suite.Suite
 	sut    *commands.ListModulesCmd
 	client *mocks.ModuleGetter
+	output *bytes.Buffer
 	ctx    context.Context
 }
 
 func (suite *ListModuleSuite) SetupTest() {
 	suite.client = new(mocks.ModuleGetter)
+	suite.output = &bytes.Buffer{}
 
 	suite.sut = &commands.ListModulesCmd{
 		Client: suite.client,
+		Output: suite.output,
 	}
 	suite.ctx = context.Background()
 }
@@ -50,6 +54,30 @@ func (suite *ListModuleSuite) TestGetAllModules_Execute_Returns_An_Error_If_The_
 	assert.Equal(suite.T(), expectedErr, actualErr)
 }
 
+func (suite *ListModuleSuite) TestGetAllModules_Execute_Only_Lists_Modules_Matching_The_Search() {
+	modules := aListOfModules("charlie", "jo", "chris").(ch360.ModuleList)
+	suite.client.On("GetAll", mock.Anything).Return(modules, nil)
+	suite.sut.Search = "CH"
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), suite.output.String(), "charlie")
+	assert.Contains(suite.T(), suite.output.String(), "chris")
+	assert.NotContains(suite.T(), suite.output.String(), "jo")
+}
+
+func (suite *ListModuleSuite) TestGetAllModules_Execute_Reports_When_No_Modules_Match_The_Search() {
+	modules := aListOfModules("charlie", "jo", "chris").(ch360.ModuleList)
+	suite.client.On("GetAll", mock.Anything).Return(modules, nil)
+	suite.sut.Search = "xyz"
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "No modules found.\n", suite.output.String())
+}
+
 func aListOfModules(ids ...string) interface{} {
 	expected := make(ch360.ModuleList, len(ids))
 
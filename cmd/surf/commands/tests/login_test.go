@@ -84,6 +84,24 @@ func (suite *LoginSuite) TestLogin_Execute_Returns_Err_From_Token_Retriever() {
 	assert.Equal(suite.T(), expectedErr, err)
 }
 
+func (suite *LoginSuite) TestLogin_Execute_Keeps_Existing_Http_Settings() {
+	// Arrange
+	existing := config.NewConfiguration(generators.String("oldid"), generators.String("oldsecret"))
+	existing.Http = config.HttpSettings{MaxRequestsPerSecond: 2.5, MaxConcurrency: 4}
+	configReader := new(mocks.ConfigurationReader)
+	configReader.On("ReadConfiguration").Return(existing, nil)
+	suite.sut.ConfigurationReader = configReader
+
+	// Act
+	err := suite.sut.Execute(context.Background(), suite.flags)
+
+	// Assert
+	require.Nil(suite.T(), err)
+	suite.assertConfigurationWrittenWithCredentials(suite.clientId, suite.clientSecret)
+	configuration := suite.configWriter.Calls[0].Arguments[0].(*config.Configuration)
+	assert.Equal(suite.T(), existing.Http, configuration.Http)
+}
+
 func (suite *LoginSuite) assertConfigurationWrittenWithCredentials(clientId string, clientSecret string) {
 	suite.configWriter.AssertCalled(suite.T(), "WriteConfiguration", mock.Anything)
 
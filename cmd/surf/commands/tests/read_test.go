@@ -62,3 +62,17 @@ func (suite *readCommandSuite) Test_Error_Returned_From_ReaderService() {
 
 	assert.EqualError(suite.T(), errors.Cause(actualErr), suite.expectedErr.Error())
 }
+
+func (suite *readCommandSuite) Test_ReaderService_ReadAllModes_Called_When_ReadModes_Set() {
+	readModes := []ch360.ReadMode{ch360.ReadText, ch360.ReadPDF}
+	suite.sut.ReadModes = readModes
+	suite.readerService.
+		On("ReadAllModes", mock.Anything, mock.Anything, mock.Anything).
+		Return(suite.expectedErr)
+
+	actualErr := suite.sut.Execute(suite.ctx)
+
+	suite.readerService.AssertCalled(suite.T(), "ReadAllModes", suite.ctx, suite.filePatterns, readModes)
+	suite.readerService.AssertNotCalled(suite.T(), "ReadAll", mock.Anything, mock.Anything, mock.Anything)
+	assert.EqualError(suite.T(), errors.Cause(actualErr), suite.expectedErr.Error())
+}
@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/ch360"
+	ch360mocks "github.com/waives/surf/ch360/mocks"
+	"github.com/waives/surf/ch360/request"
+	"github.com/waives/surf/cmd/surf/commands"
+	"github.com/waives/surf/cmd/surf/commands/mocks"
+	"regexp"
+	"testing"
+)
+
+type RedactSuite struct {
+	suite.Suite
+	redactionService *mocks.RedactionService
+	documentReader   *ch360mocks.DocumentReader
+	filePaths        []string
+	ctx              context.Context
+}
+
+func (suite *RedactSuite) SetupTest() {
+	suite.redactionService = new(mocks.RedactionService)
+	suite.documentReader = new(ch360mocks.DocumentReader)
+	suite.filePaths = []string{"file1.pdf", "file2.pdf"}
+	suite.ctx = context.Background()
+
+	suite.redactionService.On("RedactAll", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.redactionService.On("BuildAllRedactionRequests", mock.Anything, mock.Anything,
+		mock.Anything).Return(nil)
+}
+
+func TestRedactSuiteRunner(t *testing.T) {
+	suite.Run(t, new(RedactSuite))
+}
+
+func (suite *RedactSuite) TestRedactWithAreas_Execute_Redacts_Files_With_Areas() {
+	areas := []request.RedactionArea{{Top: 1, Left: 2, Bottom: 3, Right: 4, PageNumber: 1}}
+	sut := &commands.RedactWithAreasCmd{
+		Areas:            areas,
+		FilePaths:        suite.filePaths,
+		RedactionService: suite.redactionService,
+	}
+
+	err := sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	suite.redactionService.AssertCalled(suite.T(), "RedactAll", suite.ctx, suite.filePaths,
+		ch360.AreaRedaction(areas))
+}
+
+func (suite *RedactSuite) TestRedactWithPattern_Execute_Redacts_Files_With_Patterns() {
+	patterns := []*regexp.Regexp{regexp.MustCompile(`\d+`)}
+	sut := &commands.RedactWithPatternCmd{
+		Patterns:         patterns,
+		FilePaths:        suite.filePaths,
+		DocumentReader:   suite.documentReader,
+		RedactionService: suite.redactionService,
+	}
+
+	err := sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	suite.redactionService.AssertCalled(suite.T(), "RedactAll", suite.ctx, suite.filePaths,
+		ch360.NewPatternRedaction(suite.documentReader, patterns))
+}
+
+func (suite *RedactSuite) TestRedactWithAreas_Execute_Returns_Error_If_Redaction_Fails() {
+	suite.redactionService.ExpectedCalls = nil
+	suite.redactionService.On("RedactAll", mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("simulated error"))
+	sut := &commands.RedactWithAreasCmd{
+		FilePaths:        suite.filePaths,
+		RedactionService: suite.redactionService,
+	}
+
+	err := sut.Execute(suite.ctx)
+
+	assert.EqualError(suite.T(), err, "redaction failed: simulated error")
+}
+
+func (suite *RedactSuite) TestRedactWithExtractor_Execute_Redacts_Selected_Fields() {
+	extractor := new(ch360mocks.DocumentExtractor)
+	fields := ch360.FieldSelection{Include: []string{"Name"}}
+	sut := &commands.RedactWithExtractorCmd{
+		ExtractorName:     "extractor",
+		Fields:            fields,
+		FilePaths:         suite.filePaths,
+		DocumentExtractor: extractor,
+		RedactionService:  suite.redactionService,
+	}
+
+	err := sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	suite.redactionService.AssertCalled(suite.T(), "RedactAll", suite.ctx, suite.filePaths,
+		ch360.NewExtractorRedaction(extractor, "extractor").WithFields(fields))
+}
+
+func (suite *RedactSuite) TestRedactWithAreas_Execute_Applies_Options() {
+	areas := []request.RedactionArea{{Top: 1, Left: 2, Bottom: 3, Right: 4, PageNumber: 1}}
+	options := ch360.RedactionOptions{Preview: true}
+	sut := &commands.RedactWithAreasCmd{
+		Areas:            areas,
+		FilePaths:        suite.filePaths,
+		Options:          options,
+		RedactionService: suite.redactionService,
+	}
+
+	err := sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	suite.redactionService.AssertCalled(suite.T(), "RedactAll", suite.ctx, suite.filePaths,
+		options.Apply(ch360.AreaRedaction(areas)))
+}
+
+func (suite *RedactSuite) TestRedactWithPattern_Execute_Writes_Marks_Instead_Of_Redacting_If_MarksOnly() {
+	sut := &commands.RedactWithPatternCmd{
+		FilePaths:        suite.filePaths,
+		DocumentReader:   suite.documentReader,
+		MarksOnly:        true,
+		RedactionService: suite.redactionService,
+	}
+
+	err := sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	suite.redactionService.AssertCalled(suite.T(), "BuildAllRedactionRequests", suite.ctx,
+		suite.filePaths, mock.Anything)
+	suite.redactionService.AssertNotCalled(suite.T(), "RedactAll", mock.Anything, mock.Anything,
+		mock.Anything)
+}
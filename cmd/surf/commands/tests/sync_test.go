@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/cmd/surf/commands"
+	"github.com/waives/surf/cmd/surf/commands/mocks"
+	"github.com/waives/surf/workspace"
+	"testing"
+)
+
+type SyncSuite struct {
+	suite.Suite
+	sut    *commands.SyncCmd
+	syncer *mocks.WorkspaceSyncer
+	output *bytes.Buffer
+	plan   workspace.Plan
+	ctx    context.Context
+}
+
+func (suite *SyncSuite) SetupTest() {
+	suite.syncer = new(mocks.WorkspaceSyncer)
+	suite.output = &bytes.Buffer{}
+	suite.ctx = context.Background()
+	suite.plan = workspace.Plan{
+		{Action: workspace.ActionCreate, Kind: workspace.KindClassifier, Name: "documents",
+			Source: "samples.zip"},
+		{Action: workspace.ActionDelete, Kind: workspace.KindExtractor, Name: "stale"},
+	}
+
+	suite.syncer.On("Apply", mock.Anything, mock.Anything).Return(nil)
+
+	suite.sut = &commands.SyncCmd{
+		Syncer:   suite.syncer,
+		Manifest: &workspace.Manifest{},
+		Output:   suite.output,
+	}
+}
+
+func TestSyncSuiteRunner(t *testing.T) {
+	suite.Run(t, new(SyncSuite))
+}
+
+func (suite *SyncSuite) TestDryRun_Writes_Plan_Without_Applying_It() {
+	suite.syncer.On("Plan", mock.Anything, mock.Anything).Return(suite.plan, nil)
+	suite.sut.DryRun = true
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), `The following changes would be made:
+  create classifier 'documents' from samples.zip
+  delete extractor 'stale'
+`, suite.output.String())
+	suite.syncer.AssertNotCalled(suite.T(), "Apply", mock.Anything, mock.Anything)
+}
+
+func (suite *SyncSuite) TestExecute_Applies_Each_Change_In_Plan() {
+	suite.syncer.On("Plan", mock.Anything, mock.Anything).Return(suite.plan, nil)
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	suite.syncer.AssertCalled(suite.T(), "Plan", suite.ctx, suite.sut.Manifest)
+	suite.syncer.AssertCalled(suite.T(), "Apply", suite.ctx, suite.plan[0])
+	suite.syncer.AssertCalled(suite.T(), "Apply", suite.ctx, suite.plan[1])
+}
+
+func (suite *SyncSuite) TestExecute_Stops_At_First_Failed_Change() {
+	expectedErr := errors.New("simulated error")
+	suite.syncer.ExpectedCalls = nil
+	suite.syncer.On("Plan", mock.Anything, mock.Anything).Return(suite.plan, nil)
+	suite.syncer.On("Apply", mock.Anything, mock.Anything).Return(expectedErr)
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.Equal(suite.T(), expectedErr, err)
+	suite.syncer.AssertNumberOfCalls(suite.T(), "Apply", 1)
+}
+
+func (suite *SyncSuite) TestExecute_Reports_When_No_Changes_Are_Required() {
+	suite.syncer.On("Plan", mock.Anything, mock.Anything).Return(nil, nil)
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "No changes required.\n", suite.output.String())
+}
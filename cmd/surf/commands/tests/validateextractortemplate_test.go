@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/cmd/surf/commands"
+	"github.com/waives/surf/cmd/surf/commands/mocks"
+	"testing"
+)
+
+type ValidateExtractorTemplateSuite struct {
+	suite.Suite
+	sut    *commands.ValidateExtractorTemplateCmd
+	client *mocks.ModuleGetter
+	output *bytes.Buffer
+	ctx    context.Context
+}
+
+func (suite *ValidateExtractorTemplateSuite) SetupTest() {
+	suite.client = new(mocks.ModuleGetter)
+	suite.output = &bytes.Buffer{}
+	suite.ctx = context.Background()
+
+	suite.client.On("GetAll", mock.Anything).Return(ch360.ModuleList{
+		{
+			ID:         "waives.reference_number",
+			Parameters: []ch360.ModuleParameter{{ID: "format", Type: "string", Required: true}},
+		},
+	}, nil)
+
+	suite.sut = &commands.ValidateExtractorTemplateCmd{
+		Client:           suite.client,
+		TemplateFilename: "template.json",
+		Output:           suite.output,
+	}
+}
+
+func TestValidateExtractorTemplateSuiteRunner(t *testing.T) {
+	suite.Run(t, new(ValidateExtractorTemplateSuite))
+}
+
+func (suite *ValidateExtractorTemplateSuite) TestExecute_Reports_Valid_Template() {
+	suite.sut.Template = &ch360.ExtractorTemplate{
+		Modules: []ch360.ModuleTemplate{{
+			ID:        "waives.reference_number",
+			Arguments: map[string]interface{}{"format": "[A-Z]"},
+		}},
+	}
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "The extractor template 'template.json' is valid.\n", suite.output.String())
+}
+
+func (suite *ValidateExtractorTemplateSuite) TestExecute_Returns_Problems_Grouped_By_Module() {
+	suite.sut.Template = &ch360.ExtractorTemplate{
+		Modules: []ch360.ModuleTemplate{
+			{ID: "waives.reference_number"},
+			{ID: "waives.unknown"},
+		},
+	}
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.EqualError(suite.T(), err, `The extractor template 'template.json' is not valid:
+
+Module waives.reference_number:
+  Parameter "format": No argument was specified (specified "")
+
+Module (not found):
+  The module waives.unknown does not exist.
+`)
+}
+
+func (suite *ValidateExtractorTemplateSuite) TestExecute_Returns_Error_If_Modules_Cannot_Be_Retrieved() {
+	expectedErr := errors.New("simulated error")
+	suite.client.ExpectedCalls = nil
+	suite.client.On("GetAll", mock.Anything).Return(nil, expectedErr)
+	suite.sut.Template = &ch360.ExtractorTemplate{}
+
+	err := suite.sut.Execute(suite.ctx)
+
+	assert.Equal(suite.T(), expectedErr, err)
+}
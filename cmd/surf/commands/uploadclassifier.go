@@ -73,7 +73,7 @@ func (cmd *UploadClassifierCmd) initFromArgs(args *uploadClassifierArgs, flags *
 
 	cmd.ClassifierName = args.name
 
-	apiClient, err := initApiClient(flags.ClientId, flags.ClientSecret, flags.LogHttp)
+	apiClient, err := initApiClient(flags)
 
 	if err != nil {
 		return err
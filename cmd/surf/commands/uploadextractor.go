@@ -77,7 +77,7 @@ func (cmd *UploadExtractorCmd) initFromArgs(args *uploadExtractorArgs,
 		return errors.Errorf("the file '%s' could not be found", args.extractorFile)
 	}
 
-	client, err := initApiClient(flags.ClientId, flags.ClientSecret, flags.LogHttp)
+	client, err := initApiClient(flags)
 
 	if err != nil {
 		return err
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/config"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"io"
+	"os"
+)
+
+// ValidateExtractorTemplateCmd checks an extractor template against the modules
+// available in waives, reporting any problems which would prevent an extractor
+// being created from it.
+type ValidateExtractorTemplateCmd struct {
+	Client           ModuleGetter
+	Template         *ch360.ExtractorTemplate
+	TemplateFilename string
+	Output           io.Writer
+}
+
+type validateExtractorTemplateArgs struct {
+	templateFilename string
+}
+
+// ConfigureValidateExtractorTemplateCmd configures kingpin with the 'validate
+// extractor-template' command.
+func ConfigureValidateExtractorTemplateCmd(ctx context.Context, validateCmd *kingpin.CmdClause,
+	flags *config.GlobalFlags) {
+	args := &validateExtractorTemplateArgs{}
+	validateExtractorTemplateCmd := &ValidateExtractorTemplateCmd{}
+
+	validateExtractorTemplateCli := validateCmd.Command("extractor-template",
+		"Check an extractor template for problems, without creating an extractor.").
+		Action(func(parseContext *kingpin.ParseContext) error {
+			err := validateExtractorTemplateCmd.initFromArgs(args, flags)
+
+			if err != nil {
+				return err
+			}
+
+			return validateExtractorTemplateCmd.Execute(ctx)
+		})
+
+	validateExtractorTemplateCli.
+		Arg("template-file", "The extraction template file (json).").
+		Required().
+		StringVar(&args.templateFilename)
+}
+
+// Execute runs the 'validate extractor-template' command.
+func (cmd *ValidateExtractorTemplateCmd) Execute(ctx context.Context) error {
+	modules, err := cmd.Client.GetAll(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	templateErrs := ch360.ValidateExtractorTemplate(*cmd.Template, modules)
+
+	if len(templateErrs) > 0 {
+		return buildTemplateErrorMessage(
+			fmt.Sprintf("The extractor template '%s' is not valid:\n", cmd.TemplateFilename),
+			templateErrs)
+	}
+
+	_, err = fmt.Fprintf(cmd.Output, "The extractor template '%s' is valid.\n", cmd.TemplateFilename)
+	return err
+}
+
+func (cmd *ValidateExtractorTemplateCmd) initFromArgs(args *validateExtractorTemplateArgs,
+	flags *config.GlobalFlags) error {
+	var err error
+
+	cmd.TemplateFilename = args.templateFilename
+	cmd.Template, err = ch360.NewModulesTemplateFromFile(args.templateFilename)
+
+	if err != nil {
+		return err
+	}
+
+	client, err := initApiClient(flags)
+
+	if err != nil {
+		return err
+	}
+
+	cmd.Client = client.Modules
+	cmd.Output = os.Stdout
+	return nil
+}
@@ -0,0 +1,255 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/waives/surf/cache"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/ch360/results"
+	"github.com/waives/surf/ioutils"
+	"io"
+	"io/ioutil"
+)
+
+//go:generate mockery -name "ResultCache"
+
+type ResultCache interface {
+	Get(key cache.Key) ([]byte, bool, error)
+	Put(key cache.Key, contents []byte) error
+}
+
+var _ FileClassifier = (*CachingFileClassifier)(nil)
+var _ FileExtractor = (*CachingFileExtractor)(nil)
+var _ FileReader = (*CachingFileReader)(nil)
+
+// resultCacher holds the behaviour common to the caching decorators below. When
+// refresh is set, existing cached results are ignored (but are replaced with new ones).
+type resultCacher struct {
+	cache   ResultCache
+	refresh bool
+}
+
+// cached looks up the result for the file contents, calling fn (with the, possibly
+// re-wound, file contents) to produce it if it is not present in the cache.
+func (c *resultCacher) cached(fileContents io.Reader, key cache.Key,
+	fn func(fileContents io.Reader) ([]byte, error)) ([]byte, error) {
+
+	hash, fileContents, err := ioutils.Sha256(fileContents)
+	if err != nil {
+		return nil, err
+	}
+	key.Sha256 = hash
+
+	if !c.refresh {
+		cachedResult, found, err := c.cache.Get(key)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			return cachedResult, nil
+		}
+	}
+
+	result, err := fn(fileContents)
+	if err != nil {
+		return nil, err
+	}
+
+	c.put(key, result)
+	return result, nil
+}
+
+// put stores a result in the cache. By this point the result has already been
+// produced, so failing to cache it (e.g. because the disk is full) shouldn't fail
+// the file; it'll just be processed again next time.
+func (c *resultCacher) put(key cache.Key, result []byte) {
+	_ = c.cache.Put(key, result)
+}
+
+// CachingFileClassifier is a FileClassifier decorator which stores classification
+// results in a ResultCache, and reuses them for files with identical contents.
+type CachingFileClassifier struct {
+	resultCacher
+	wrapped FileClassifier
+}
+
+func NewCachingFileClassifier(wrapped FileClassifier, cache ResultCache,
+	refresh bool) *CachingFileClassifier {
+	return &CachingFileClassifier{
+		resultCacher: resultCacher{cache: cache, refresh: refresh},
+		wrapped:      wrapped,
+	}
+}
+
+func (c *CachingFileClassifier) Classify(ctx context.Context, fileContents io.Reader,
+	classifierName string) (*results.ClassificationResult, error) {
+	key := cache.Key{
+		Operation: cache.OperationClassify,
+		Name:      classifierName,
+	}
+
+	serialisedResult, err := c.cached(fileContents, key,
+		func(fileContents io.Reader) ([]byte, error) {
+			result, err := c.wrapped.Classify(ctx, fileContents, classifierName)
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(result)
+		})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result results.ClassificationResult
+	return &result, json.Unmarshal(serialisedResult, &result)
+}
+
+// CachingFileExtractor is a FileExtractor decorator which stores extraction
+// results in a ResultCache, and reuses them for files with identical contents.
+type CachingFileExtractor struct {
+	resultCacher
+	wrapped FileExtractor
+}
+
+func NewCachingFileExtractor(wrapped FileExtractor, cache ResultCache,
+	refresh bool) *CachingFileExtractor {
+	return &CachingFileExtractor{
+		resultCacher: resultCacher{cache: cache, refresh: refresh},
+		wrapped:      wrapped,
+	}
+}
+
+func (c *CachingFileExtractor) Extract(ctx context.Context, fileContents io.Reader,
+	extractorName string) (*results.ExtractionResult, error) {
+	key := cache.Key{
+		Operation: cache.OperationExtract,
+		Name:      extractorName,
+	}
+
+	serialisedResult, err := c.cached(fileContents, key,
+		func(fileContents io.Reader) ([]byte, error) {
+			result, err := c.wrapped.Extract(ctx, fileContents, extractorName)
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(result)
+		})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result results.ExtractionResult
+	return &result, json.Unmarshal(serialisedResult, &result)
+}
+
+// CachingFileReader is a FileReader decorator which stores read results in a
+// ResultCache, and reuses them for files with identical contents.
+type CachingFileReader struct {
+	resultCacher
+	wrapped FileReader
+}
+
+func NewCachingFileReader(wrapped FileReader, cache ResultCache, refresh bool) *CachingFileReader {
+	return &CachingFileReader{
+		resultCacher: resultCacher{cache: cache, refresh: refresh},
+		wrapped:      wrapped,
+	}
+}
+
+func (c *CachingFileReader) Read(ctx context.Context, fileContents io.Reader,
+	mode ch360.ReadMode) (io.ReadCloser, error) {
+	key := cache.Key{
+		Operation: cache.OperationRead,
+		Mode:      mode.String(),
+	}
+
+	result, err := c.cached(fileContents, key,
+		func(fileContents io.Reader) ([]byte, error) {
+			result, err := c.wrapped.Read(ctx, fileContents, mode)
+			if err != nil {
+				return nil, err
+			}
+
+			buf, err := ioutils.DrainClose(result)
+			if err != nil {
+				return nil, err
+			}
+			return buf.Bytes(), nil
+		})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ioutil.NopCloser(bytes.NewReader(result)), nil
+}
+
+// ReadModes returns the cached results for each of the modes, performing a single read
+// of the file for any modes whose results are not in the cache.
+func (c *CachingFileReader) ReadModes(ctx context.Context, fileContents io.Reader,
+	modes []ch360.ReadMode) (map[ch360.ReadMode]io.ReadCloser, error) {
+	hash, fileContents, err := ioutils.Sha256(fileContents)
+	if err != nil {
+		return nil, err
+	}
+
+	keyFor := func(mode ch360.ReadMode) cache.Key {
+		return cache.Key{
+			Operation: cache.OperationRead,
+			Mode:      mode.String(),
+			Sha256:    hash,
+		}
+	}
+
+	var (
+		results      = map[ch360.ReadMode][]byte{}
+		missingModes []ch360.ReadMode
+	)
+
+	for _, mode := range modes {
+		if !c.refresh {
+			cachedResult, found, err := c.cache.Get(keyFor(mode))
+			if err != nil {
+				return nil, err
+			}
+
+			if found {
+				results[mode] = cachedResult
+				continue
+			}
+		}
+		missingModes = append(missingModes, mode)
+	}
+
+	if len(missingModes) > 0 {
+		readResults, err := c.wrapped.ReadModes(ctx, fileContents, missingModes)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, mode := range missingModes {
+			buf, err := ioutils.DrainClose(readResults[mode])
+			if err != nil {
+				// close this and the remaining results, which won't be read
+				for _, unreadMode := range missingModes[i:] {
+					ioutils.TryClose(readResults[unreadMode])
+				}
+				return nil, err
+			}
+
+			results[mode] = buf.Bytes()
+			c.put(keyFor(mode), results[mode])
+		}
+	}
+
+	readClosers := map[ch360.ReadMode]io.ReadCloser{}
+	for mode, result := range results {
+		readClosers[mode] = ioutil.NopCloser(bytes.NewReader(result))
+	}
+
+	return readClosers, nil
+}
@@ -34,3 +34,26 @@ func (_m *FileReader) Read(ctx context.Context, fileContent io.Reader, mode ch36
 
 	return r0, r1
 }
+
+// ReadModes provides a mock function with given fields: ctx, fileContent, modes
+func (_m *FileReader) ReadModes(ctx context.Context, fileContent io.Reader, modes []ch360.ReadMode) (map[ch360.ReadMode]io.ReadCloser, error) {
+	ret := _m.Called(ctx, fileContent, modes)
+
+	var r0 map[ch360.ReadMode]io.ReadCloser
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader, []ch360.ReadMode) map[ch360.ReadMode]io.ReadCloser); ok {
+		r0 = rf(ctx, fileContent, modes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[ch360.ReadMode]io.ReadCloser)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, io.Reader, []ch360.ReadMode) error); ok {
+		r1 = rf(ctx, fileContent, modes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -2,15 +2,40 @@
 
 package mocks
 
+import ch360 "github.com/waives/surf/ch360"
 import context "context"
 import io "io"
 import mock "github.com/stretchr/testify/mock"
+import request "github.com/waives/surf/ch360/request"
 
 // FileRedactor is an autogenerated mock type for the FileRedactor type
 type FileRedactor struct {
 	mock.Mock
 }
 
+// BuildRedactionRequest provides a mock function with given fields: ctx, fileContent, builder
+func (_m *FileRedactor) BuildRedactionRequest(ctx context.Context, fileContent io.Reader, builder ch360.RedactionRequestBuilder) (*request.RedactedPdfRequest, error) {
+	ret := _m.Called(ctx, fileContent, builder)
+
+	var r0 *request.RedactedPdfRequest
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader, ch360.RedactionRequestBuilder) *request.RedactedPdfRequest); ok {
+		r0 = rf(ctx, fileContent, builder)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.RedactedPdfRequest)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, io.Reader, ch360.RedactionRequestBuilder) error); ok {
+		r1 = rf(ctx, fileContent, builder)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Redact provides a mock function with given fields: ctx, fileContent, extractorName
 func (_m *FileRedactor) Redact(ctx context.Context, fileContent io.Reader, extractorName string) (io.ReadCloser, error) {
 	ret := _m.Called(ctx, fileContent, extractorName)
@@ -33,3 +58,26 @@ func (_m *FileRedactor) Redact(ctx context.Context, fileContent io.Reader, extra
 
 	return r0, r1
 }
+
+// RedactWith provides a mock function with given fields: ctx, fileContent, builder
+func (_m *FileRedactor) RedactWith(ctx context.Context, fileContent io.Reader, builder ch360.RedactionRequestBuilder) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, fileContent, builder)
+
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader, ch360.RedactionRequestBuilder) io.ReadCloser); ok {
+		r0 = rf(ctx, fileContent, builder)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, io.Reader, ch360.RedactionRequestBuilder) error); ok {
+		r1 = rf(ctx, fileContent, builder)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
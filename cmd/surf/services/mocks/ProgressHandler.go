@@ -23,6 +23,20 @@ func (_m *ProgressHandler) Notify(filename string, result interface{}) error {
 	return r0
 }
 
+// NotifyAbort provides a mock function with given fields:
+func (_m *ProgressHandler) NotifyAbort() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NotifyErr provides a mock function with given fields: filename, err
 func (_m *ProgressHandler) NotifyErr(filename string, err error) error {
 	ret := _m.Called(filename, err)
@@ -6,8 +6,8 @@ import (
 	"github.com/waives/surf/ch360"
 	"github.com/waives/surf/ch360/results"
 	"github.com/waives/surf/pool"
+	"github.com/waives/surf/telemetry"
 	"io"
-	"os"
 )
 
 //go:generate mockery -name "FileClassifier"
@@ -37,6 +37,20 @@ func NewParallelClassificationService(fileClassifier FileClassifier,
 	}
 }
 
+// WithFileOpener configures the ParallelClassificationService to open the files it processes
+// with openFile, rather than from the file system.
+func (p *ParallelClassificationService) WithFileOpener(openFile FileOpener) *ParallelClassificationService {
+	p.parallelFilesProcessor.OpenFile = openFile
+	return p
+}
+
+// WithRecorder configures the ParallelClassificationService to record a span for (and the time
+// taken to process) each file.
+func (p *ParallelClassificationService) WithRecorder(recorder *telemetry.Recorder) *ParallelClassificationService {
+	p.parallelFilesProcessor.Recorder = recorder
+	return p
+}
+
 func (p *ParallelClassificationService) ClassifyAll(ctx context.Context, files []string,
 	classifierName string) error {
 
@@ -50,7 +64,7 @@ func (p *ParallelClassificationService) ClassifyAll(ctx context.Context, files [
 	// called in parallel, once per file
 	processorFunc := func(ctx context.Context, filename string) pool.ProcessorFunc {
 		return func() (interface{}, error) {
-			file, err := os.Open(filename)
+			file, err := p.parallelFilesProcessor.Open(filename)
 			if err != nil {
 				return nil, errors.Wrapf(err, "Error classifying file %s", filename)
 			}
@@ -6,8 +6,8 @@ import (
 	"github.com/waives/surf/ch360"
 	"github.com/waives/surf/ch360/results"
 	"github.com/waives/surf/pool"
+	"github.com/waives/surf/telemetry"
 	"io"
-	"os"
 )
 
 //go:generate mockery -name "FileExtractor"
@@ -37,6 +37,20 @@ func NewParallelExtractionService(fileExtractor FileExtractor,
 	}
 }
 
+// WithFileOpener configures the ParallelExtractionService to open the files it processes
+// with openFile, rather than from the file system.
+func (p *ParallelExtractionService) WithFileOpener(openFile FileOpener) *ParallelExtractionService {
+	p.parallelFilesProcessor.OpenFile = openFile
+	return p
+}
+
+// WithRecorder configures the ParallelExtractionService to record a span for (and the time
+// taken to process) each file.
+func (p *ParallelExtractionService) WithRecorder(recorder *telemetry.Recorder) *ParallelExtractionService {
+	p.parallelFilesProcessor.Recorder = recorder
+	return p
+}
+
 func (p *ParallelExtractionService) ExtractAll(ctx context.Context, files []string,
 	extractorName string) error {
 
@@ -50,7 +64,7 @@ func (p *ParallelExtractionService) ExtractAll(ctx context.Context, files []stri
 	// called in parallel, once per file
 	processorFunc := func(ctx context.Context, filename string) pool.ProcessorFunc {
 		return func() (interface{}, error) {
-			file, err := os.Open(filename)
+			file, err := p.parallelFilesProcessor.Open(filename)
 			if err != nil {
 				return nil, errors.Wrapf(err, "Error extracting file %s", filename)
 			}
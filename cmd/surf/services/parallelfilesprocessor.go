@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"github.com/waives/surf/pool"
+	"github.com/waives/surf/telemetry"
+	"io"
+	"os"
 )
 
 var ErrGlobMatchesNoFiles = errors.New("file pattern does not match any files")
@@ -13,10 +16,34 @@ type ProgressHandler interface {
 	NotifyErr(filename string, err error) error
 	NotifyStart(totalJobs int) error
 	NotifyFinish() error
+	NotifyAbort() error
 }
 
+// OutputSkipper is implemented by ProgressHandlers which discard the results for files
+// whose output files already exist. Those files are not processed at all.
+type OutputSkipper interface {
+	SkipsOutputFor(filename string) (bool, error)
+}
+
+// FileOpener opens the named file for processing.
+type FileOpener func(filename string) (io.ReadCloser, error)
+
 type ParallelFilesProcessor struct {
 	ProgressHandler ProgressHandler
+	// OpenFile opens the files to be processed. If it is not set, files are opened
+	// from the file system.
+	OpenFile FileOpener
+	// Recorder, if set, records a span for (and the time taken to process) each file.
+	Recorder *telemetry.Recorder
+}
+
+// Open opens the named file for processing.
+func (p *ParallelFilesProcessor) Open(filename string) (io.ReadCloser, error) {
+	if p.OpenFile == nil {
+		return os.Open(filename)
+	}
+
+	return p.OpenFile(filename)
 }
 
 type ProcessorFuncFactory func(ctx context.Context, filename string) pool.ProcessorFunc
@@ -26,7 +53,13 @@ func (p *ParallelFilesProcessor) Run(ctx context.Context,
 	parallelism int,
 	processorFuncFactory ProcessorFuncFactory) error {
 
+	files, err := p.withoutSkippedOutputs(files)
+	if err != nil {
+		return err
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
 	var (
 		processFileJobs []pool.Job
@@ -40,7 +73,7 @@ func (p *ParallelFilesProcessor) Run(ctx context.Context,
 		filename := filename // <- copy
 
 		processFileJob := pool.NewJob(
-			processorFuncFactory(ctx, filename),
+			p.instrument(filename, processorFuncFactory(ctx, filename)),
 			func(result interface{}, e error) {
 				if e != nil {
 					errs = append(errs, e)
@@ -61,9 +94,16 @@ func (p *ParallelFilesProcessor) Run(ctx context.Context,
 	workPool := pool.NewPool(processFileJobs, min(parallelism, len(files)))
 
 	p.ProgressHandler.NotifyStart(len(processFileJobs))
-	defer p.ProgressHandler.NotifyFinish()
 	workPool.Run(ctx)
 
+	// The context is cancelled when any file fails, as well as when processing is
+	// cancelled; either way, the results are incomplete.
+	if ctx.Err() != nil {
+		p.ProgressHandler.NotifyAbort()
+	} else {
+		p.ProgressHandler.NotifyFinish()
+	}
+
 	// Just return the first error.
 	if len(errs) > 0 {
 		return errs[0]
@@ -72,6 +112,52 @@ func (p *ParallelFilesProcessor) Run(ctx context.Context,
 	return nil
 }
 
+// withoutSkippedOutputs removes the files whose output would be discarded from the list,
+// so that they aren't uploaded and processed for nothing.
+func (p *ParallelFilesProcessor) withoutSkippedOutputs(files []string) ([]string, error) {
+	skipper, ok := p.ProgressHandler.(OutputSkipper)
+	if !ok {
+		return files, nil
+	}
+
+	var remaining []string
+	for _, filename := range files {
+		skipped, err := skipper.SkipsOutputFor(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		if !skipped {
+			remaining = append(remaining, filename)
+		}
+	}
+
+	return remaining, nil
+}
+
+// instrument records a span for processing the file, as well as the time taken.
+func (p *ParallelFilesProcessor) instrument(filename string,
+	process pool.ProcessorFunc) pool.ProcessorFunc {
+	if p.Recorder == nil {
+		return process
+	}
+
+	return func() (interface{}, error) {
+		span := p.Recorder.StartSpan("process_file", telemetry.Labels{"file": filename})
+		result, err := process()
+		duration := span.End(err)
+
+		outcome := "ok"
+		if err != nil {
+			outcome = "error"
+		}
+		p.Recorder.Observe(telemetry.FileDuration, telemetry.Labels{"result": outcome},
+			duration.Seconds())
+
+		return result, err
+	}
+}
+
 func min(x, y int) int {
 	if x < y {
 		return x
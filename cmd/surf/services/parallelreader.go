@@ -5,14 +5,16 @@ import (
 	"github.com/pkg/errors"
 	"github.com/waives/surf/ch360"
 	"github.com/waives/surf/pool"
+	"github.com/waives/surf/telemetry"
 	"io"
-	"os"
 )
 
 //go:generate mockery -name "FileReader|FileGlobProcessor"
 
 type FileReader interface {
 	Read(ctx context.Context, fileContent io.Reader, mode ch360.ReadMode) (io.ReadCloser, error)
+	ReadModes(ctx context.Context, fileContent io.Reader,
+		modes []ch360.ReadMode) (map[ch360.ReadMode]io.ReadCloser, error)
 }
 
 type FileGlobProcessor interface {
@@ -43,8 +45,38 @@ func NewParallelReaderService(fileReader FileReader,
 	}
 }
 
+// WithFileOpener configures the ParallelReaderService to open the files it processes
+// with openFile, rather than from the file system.
+func (p *ParallelReaderService) WithFileOpener(openFile FileOpener) *ParallelReaderService {
+	p.parallelFilesProcessor.OpenFile = openFile
+	return p
+}
+
+// WithRecorder configures the ParallelReaderService to record a span for (and the time
+// taken to process) each file.
+func (p *ParallelReaderService) WithRecorder(recorder *telemetry.Recorder) *ParallelReaderService {
+	p.parallelFilesProcessor.Recorder = recorder
+	return p
+}
+
 func (p *ParallelReaderService) ReadAll(ctx context.Context, files []string,
 	readMode ch360.ReadMode) error {
+	return p.readAll(ctx, files, func(ctx context.Context, file io.Reader) (interface{}, error) {
+		return p.singleFileReader.Read(ctx, file, readMode)
+	})
+}
+
+// ReadAllModes reads each file once, producing results in each of the read modes. The
+// results are passed to the ProgressHandler as map[ch360.ReadMode]io.ReadCloser.
+func (p *ParallelReaderService) ReadAllModes(ctx context.Context, files []string,
+	readModes []ch360.ReadMode) error {
+	return p.readAll(ctx, files, func(ctx context.Context, file io.Reader) (interface{}, error) {
+		return p.singleFileReader.ReadModes(ctx, file, readModes)
+	})
+}
+
+func (p *ParallelReaderService) readAll(ctx context.Context, files []string,
+	read func(ctx context.Context, file io.Reader) (interface{}, error)) error {
 
 	// Limit the number of workers to the number of available doc slots
 	parallelWorkers, err := ch360.GetFreeDocSlots(ctx, p.documentGetter, ch360.TotalDocumentSlots)
@@ -56,15 +88,15 @@ func (p *ParallelReaderService) ReadAll(ctx context.Context, files []string,
 	// called in parallel, once per file
 	processorFunc := func(ctx context.Context, filename string) pool.ProcessorFunc {
 		return func() (interface{}, error) {
-			file, err := os.Open(filename)
+			file, err := p.parallelFilesProcessor.Open(filename)
 			if err != nil {
 				return nil, errors.Wrapf(err, "Error reading file %s", filename)
 			}
 			defer file.Close()
 
-			readCloser, err := p.singleFileReader.Read(ctx, file, readMode)
+			result, err := read(ctx, file)
 
-			return readCloser, errors.Wrapf(err, "Error reading file %s", filename)
+			return result, errors.Wrapf(err, "Error reading file %s", filename)
 		}
 	}
 
@@ -3,16 +3,23 @@ package services
 import (
 	"context"
 	"github.com/pkg/errors"
+	"github.com/waives/surf/audit"
 	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/ch360/request"
+	"github.com/waives/surf/ioutils"
 	"github.com/waives/surf/pool"
+	"github.com/waives/surf/telemetry"
 	"io"
-	"os"
 )
 
 //go:generate mockery -name "FileRedactor"
 
 type FileRedactor interface {
 	Redact(ctx context.Context, fileContent io.Reader, extractorName string) (io.ReadCloser, error)
+	RedactWith(ctx context.Context, fileContent io.Reader,
+		builder ch360.RedactionRequestBuilder) (io.ReadCloser, error)
+	BuildRedactionRequest(ctx context.Context, fileContent io.Reader,
+		builder ch360.RedactionRequestBuilder) (*request.RedactedPdfRequest, error)
 }
 
 // ParallelRedactionService wraps the ch360.FileRedactor to process multiple files in parallel.
@@ -20,6 +27,7 @@ type ParallelRedactionService struct {
 	singleFileRedactor     FileRedactor
 	documentGetter         ch360.DocumentGetter
 	parallelFilesProcessor ParallelFilesProcessor
+	audit                  bool
 }
 
 // NewParallelRedactionService constructs a new ParallelRedactionService.
@@ -36,8 +44,98 @@ func NewParallelRedactionService(fileRedactor FileRedactor,
 	}
 }
 
+// WithFileOpener configures the ParallelRedactionService to open the files it processes
+// with openFile, rather than from the file system.
+func (p *ParallelRedactionService) WithFileOpener(openFile FileOpener) *ParallelRedactionService {
+	p.parallelFilesProcessor.OpenFile = openFile
+	return p
+}
+
+// WithRecorder configures the ParallelRedactionService to record a span for (and the time
+// taken to process) each file.
+func (p *ParallelRedactionService) WithRecorder(recorder *telemetry.Recorder) *ParallelRedactionService {
+	p.parallelFilesProcessor.Recorder = recorder
+	return p
+}
+
+// WithAudit configures the ParallelRedactionService to record an audit trail
+// for each file redacted by RedactAll. The results passed to the ProgressHandler
+// are then *audit.RedactedFile, rather than the redacted PDF.
+func (p *ParallelRedactionService) WithAudit() *ParallelRedactionService {
+	p.audit = true
+	return p
+}
+
+// RedactAllWithExtractor redacts each of the files with the fields found in
+// it by the named extractor.
 func (p *ParallelRedactionService) RedactAllWithExtractor(ctx context.Context, files []string,
 	extractorName string) error {
+	return p.redactAll(ctx, files, func(ctx context.Context, file io.Reader) (interface{}, error) {
+		return p.singleFileRedactor.Redact(ctx, file, extractorName)
+	})
+}
+
+// RedactAll redacts each of the files with the request built for it by the
+// provided RedactionRequestBuilder.
+func (p *ParallelRedactionService) RedactAll(ctx context.Context, files []string,
+	builder ch360.RedactionRequestBuilder) error {
+	if p.audit {
+		return p.redactAll(ctx, files, func(ctx context.Context, file io.Reader) (interface{}, error) {
+			return p.redactAudited(ctx, file, builder)
+		})
+	}
+
+	return p.redactAll(ctx, files, func(ctx context.Context, file io.Reader) (interface{}, error) {
+		return p.singleFileRedactor.RedactWith(ctx, file, builder)
+	})
+}
+
+func (p *ParallelRedactionService) redactAudited(ctx context.Context, file io.Reader,
+	builder ch360.RedactionRequestBuilder) (*audit.RedactedFile, error) {
+	hash, file, err := ioutils.Sha256(file)
+	if err != nil {
+		return nil, err
+	}
+
+	recorder := &recordingRedactionBuilder{builder: builder}
+	contents, err := p.singleFileRedactor.RedactWith(ctx, file, recorder)
+	if err != nil {
+		return nil, err
+	}
+
+	return &audit.RedactedFile{
+		Contents:     contents,
+		SourceSHA256: hash,
+		Request:      recorder.request,
+	}, nil
+}
+
+// recordingRedactionBuilder keeps a copy of the request built by another
+// RedactionRequestBuilder. A new one is used for each file.
+type recordingRedactionBuilder struct {
+	builder ch360.RedactionRequestBuilder
+	request *request.RedactedPdfRequest
+}
+
+func (r *recordingRedactionBuilder) BuildRedactionRequest(ctx context.Context,
+	documentId string) (*request.RedactedPdfRequest, error) {
+	redactRequest, err := r.builder.BuildRedactionRequest(ctx, documentId)
+	r.request = redactRequest
+	return redactRequest, err
+}
+
+// BuildAllRedactionRequests builds the redaction request for each of the files
+// with the provided RedactionRequestBuilder, without redacting them. The requests
+// are passed to the ProgressHandler as the results.
+func (p *ParallelRedactionService) BuildAllRedactionRequests(ctx context.Context, files []string,
+	builder ch360.RedactionRequestBuilder) error {
+	return p.redactAll(ctx, files, func(ctx context.Context, file io.Reader) (interface{}, error) {
+		return p.singleFileRedactor.BuildRedactionRequest(ctx, file, builder)
+	})
+}
+
+func (p *ParallelRedactionService) redactAll(ctx context.Context, files []string,
+	redact func(ctx context.Context, file io.Reader) (interface{}, error)) error {
 
 	// Limit the number of workers to the number of available doc slots
 	parallelWorkers, err := ch360.GetFreeDocSlots(ctx, p.documentGetter, ch360.TotalDocumentSlots)
@@ -49,15 +147,15 @@ func (p *ParallelRedactionService) RedactAllWithExtractor(ctx context.Context, f
 	// called in parallel, once per file
 	processorFunc := func(ctx context.Context, filename string) pool.ProcessorFunc {
 		return func() (interface{}, error) {
-			file, err := os.Open(filename)
+			file, err := p.parallelFilesProcessor.Open(filename)
 			if err != nil {
 				return nil, errors.Wrapf(err, "Error redacting file %s", filename)
 			}
 			defer file.Close()
 
-			readCloser, err := p.singleFileRedactor.Redact(ctx, file, extractorName)
+			result, err := redact(ctx, file)
 
-			return readCloser, errors.Wrapf(err, "Error redacting file %s", filename)
+			return result, errors.Wrapf(err, "Error redacting file %s", filename)
 		}
 	}
 
@@ -0,0 +1,263 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/cache"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/ch360/results"
+	"github.com/waives/surf/cmd/surf/services"
+	"github.com/waives/surf/cmd/surf/services/mocks"
+	"github.com/waives/surf/test/generators"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+type cachingFileProcessorsSuite struct {
+	suite.Suite
+	resultCache          *mocks.ResultCache
+	fileClassifier       *mocks.FileClassifier
+	fileReader           *mocks.FileReader
+	classificationResult *results.ClassificationResult
+	fileContent          []byte
+	ctx                  context.Context
+}
+
+// sha256 of "some data"
+const fileContentSha256 = "1307990e6ba5ca145eb35e99182a9bec46531bc54ddf656a602c780fa0240dee"
+
+func (suite *cachingFileProcessorsSuite) SetupTest() {
+	suite.resultCache = new(mocks.ResultCache)
+	suite.fileClassifier = new(mocks.FileClassifier)
+	suite.fileReader = new(mocks.FileReader)
+	suite.ctx = context.Background()
+	suite.fileContent = []byte("some data")
+	suite.classificationResult = &results.ClassificationResult{
+		DocumentType: generators.String("documentType"),
+	}
+
+	suite.fileClassifier.
+		On("Classify", mock.Anything, mock.Anything, mock.Anything).
+		Return(suite.classificationResult, nil)
+	suite.fileReader.
+		On("Read", mock.Anything, mock.Anything, mock.Anything).
+		Return(ioutil.NopCloser(bytes.NewBufferString("read result")), nil)
+	suite.resultCache.On("Put", mock.Anything, mock.Anything).Return(nil)
+}
+
+func TestCachingFileProcessorsSuiteRunner(t *testing.T) {
+	suite.Run(t, new(cachingFileProcessorsSuite))
+}
+
+func (suite *cachingFileProcessorsSuite) classifierKey() cache.Key {
+	return cache.Key{
+		Sha256:    fileContentSha256,
+		Operation: cache.OperationClassify,
+		Name:      "classifier",
+	}
+}
+
+func (suite *cachingFileProcessorsSuite) TestClassifier_Returns_Cached_Result_Without_Classifying() {
+	serialisedResult, _ := json.Marshal(suite.classificationResult)
+	suite.resultCache.On("Get", suite.classifierKey()).Return(serialisedResult, true, nil)
+	sut := services.NewCachingFileClassifier(suite.fileClassifier, suite.resultCache, false)
+
+	result, err := sut.Classify(suite.ctx, bytes.NewReader(suite.fileContent), "classifier")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), suite.classificationResult, result)
+	suite.fileClassifier.AssertNotCalled(suite.T(), "Classify", mock.Anything, mock.Anything,
+		mock.Anything)
+	suite.resultCache.AssertNotCalled(suite.T(), "Put", mock.Anything, mock.Anything)
+}
+
+func (suite *cachingFileProcessorsSuite) TestClassifier_Classifies_And_Stores_Result_On_Cache_Miss() {
+	suite.resultCache.On("Get", mock.Anything).Return(nil, false, nil)
+	sut := services.NewCachingFileClassifier(suite.fileClassifier, suite.resultCache, false)
+
+	result, err := sut.Classify(suite.ctx, bytes.NewReader(suite.fileContent), "classifier")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), suite.classificationResult, result)
+	serialisedResult, _ := json.Marshal(suite.classificationResult)
+	suite.resultCache.AssertCalled(suite.T(), "Put", suite.classifierKey(), serialisedResult)
+}
+
+func (suite *cachingFileProcessorsSuite) TestClassifier_Returns_Result_When_It_Cannot_Be_Cached() {
+	suite.resultCache = new(mocks.ResultCache)
+	suite.resultCache.On("Get", mock.Anything).Return(nil, false, nil)
+	suite.resultCache.On("Put", mock.Anything, mock.Anything).Return(errors.New("disk full"))
+	sut := services.NewCachingFileClassifier(suite.fileClassifier, suite.resultCache, false)
+
+	result, err := sut.Classify(suite.ctx, bytes.NewReader(suite.fileContent), "classifier")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), suite.classificationResult, result)
+}
+
+func (suite *cachingFileProcessorsSuite) TestClassifier_Passes_File_Contents_To_Wrapped_Classifier() {
+	suite.resultCache.On("Get", mock.Anything).Return(nil, false, nil)
+	suite.fileClassifier.ExpectedCalls = nil
+	var receivedContents []byte
+	suite.fileClassifier.
+		On("Classify", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			receivedContents, _ = ioutil.ReadAll(args.Get(1).(*bytes.Reader))
+		}).
+		Return(suite.classificationResult, nil)
+	sut := services.NewCachingFileClassifier(suite.fileClassifier, suite.resultCache, false)
+
+	_, _ = sut.Classify(suite.ctx, bytes.NewReader(suite.fileContent), "classifier")
+
+	assert.Equal(suite.T(), suite.fileContent, receivedContents)
+}
+
+func (suite *cachingFileProcessorsSuite) TestClassifier_Ignores_Cached_Result_When_Refreshing() {
+	suite.resultCache.On("Get", mock.Anything).Return([]byte("{}"), true, nil)
+	sut := services.NewCachingFileClassifier(suite.fileClassifier, suite.resultCache, true)
+
+	result, err := sut.Classify(suite.ctx, bytes.NewReader(suite.fileContent), "classifier")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), suite.classificationResult, result)
+	suite.resultCache.AssertNotCalled(suite.T(), "Get", mock.Anything)
+	suite.resultCache.AssertNumberOfCalls(suite.T(), "Put", 1)
+}
+
+func (suite *cachingFileProcessorsSuite) TestReader_Includes_Read_Mode_In_Key() {
+	suite.resultCache.On("Get", mock.Anything).Return(nil, false, nil)
+	sut := services.NewCachingFileReader(suite.fileReader, suite.resultCache, false)
+
+	result, err := sut.Read(suite.ctx, bytes.NewReader(suite.fileContent), ch360.ReadText)
+
+	assert.NoError(suite.T(), err)
+	contents, _ := ioutil.ReadAll(result)
+	assert.Equal(suite.T(), "read result", string(contents))
+	suite.resultCache.AssertCalled(suite.T(), "Get", cache.Key{
+		Sha256:    fileContentSha256,
+		Operation: cache.OperationRead,
+		Mode:      "txt",
+	})
+}
+
+func (suite *cachingFileProcessorsSuite) TestReader_Returns_Cached_Result_Without_Reading() {
+	suite.resultCache.On("Get", mock.Anything).Return([]byte("cached result"), true, nil)
+	sut := services.NewCachingFileReader(suite.fileReader, suite.resultCache, false)
+
+	result, err := sut.Read(suite.ctx, bytes.NewReader(suite.fileContent), ch360.ReadPDF)
+
+	assert.NoError(suite.T(), err)
+	contents, _ := ioutil.ReadAll(result)
+	assert.Equal(suite.T(), "cached result", string(contents))
+	suite.fileReader.AssertNotCalled(suite.T(), "Read", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *cachingFileProcessorsSuite) readerKey(mode ch360.ReadMode) cache.Key {
+	return cache.Key{
+		Sha256:    fileContentSha256,
+		Operation: cache.OperationRead,
+		Mode:      mode.String(),
+	}
+}
+
+func (suite *cachingFileProcessorsSuite) TestReader_Reads_Only_Modes_Missing_From_Cache() {
+	suite.resultCache.On("Get", suite.readerKey(ch360.ReadText)).Return([]byte("cached text"), true, nil)
+	suite.resultCache.On("Get", suite.readerKey(ch360.ReadPDF)).Return(nil, false, nil)
+	suite.fileReader.
+		On("ReadModes", mock.Anything, mock.Anything, mock.Anything).
+		Return(map[ch360.ReadMode]io.ReadCloser{
+			ch360.ReadPDF: ioutil.NopCloser(bytes.NewBufferString("read pdf")),
+		}, nil)
+	sut := services.NewCachingFileReader(suite.fileReader, suite.resultCache, false)
+
+	results, err := sut.ReadModes(suite.ctx, bytes.NewReader(suite.fileContent),
+		[]ch360.ReadMode{ch360.ReadText, ch360.ReadPDF})
+
+	suite.Require().NoError(err)
+	text, _ := ioutil.ReadAll(results[ch360.ReadText])
+	pdf, _ := ioutil.ReadAll(results[ch360.ReadPDF])
+	assert.Equal(suite.T(), "cached text", string(text))
+	assert.Equal(suite.T(), "read pdf", string(pdf))
+	suite.fileReader.AssertCalled(suite.T(), "ReadModes", suite.ctx, mock.Anything,
+		[]ch360.ReadMode{ch360.ReadPDF})
+	suite.resultCache.AssertCalled(suite.T(), "Put", suite.readerKey(ch360.ReadPDF), []byte("read pdf"))
+	suite.resultCache.AssertNumberOfCalls(suite.T(), "Put", 1)
+}
+
+func (suite *cachingFileProcessorsSuite) TestReader_Does_Not_Read_When_All_Modes_Are_Cached() {
+	suite.resultCache.On("Get", mock.Anything).Return([]byte("cached result"), true, nil)
+	sut := services.NewCachingFileReader(suite.fileReader, suite.resultCache, false)
+
+	results, err := sut.ReadModes(suite.ctx, bytes.NewReader(suite.fileContent),
+		[]ch360.ReadMode{ch360.ReadText, ch360.ReadWvdoc})
+
+	suite.Require().NoError(err)
+	assert.Len(suite.T(), results, 2)
+	suite.fileReader.AssertNotCalled(suite.T(), "ReadModes", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *cachingFileProcessorsSuite) TestReader_Returns_Results_When_They_Cannot_Be_Cached() {
+	suite.resultCache = new(mocks.ResultCache)
+	suite.resultCache.On("Get", mock.Anything).Return(nil, false, nil)
+	suite.resultCache.On("Put", mock.Anything, mock.Anything).Return(errors.New("disk full"))
+	suite.fileReader.
+		On("ReadModes", mock.Anything, mock.Anything, mock.Anything).
+		Return(map[ch360.ReadMode]io.ReadCloser{
+			ch360.ReadText: ioutil.NopCloser(bytes.NewBufferString("read text")),
+			ch360.ReadPDF:  ioutil.NopCloser(bytes.NewBufferString("read pdf")),
+		}, nil)
+	sut := services.NewCachingFileReader(suite.fileReader, suite.resultCache, false)
+
+	results, err := sut.ReadModes(suite.ctx, bytes.NewReader(suite.fileContent),
+		[]ch360.ReadMode{ch360.ReadText, ch360.ReadPDF})
+
+	suite.Require().NoError(err)
+	text, _ := ioutil.ReadAll(results[ch360.ReadText])
+	pdf, _ := ioutil.ReadAll(results[ch360.ReadPDF])
+	assert.Equal(suite.T(), "read text", string(text))
+	assert.Equal(suite.T(), "read pdf", string(pdf))
+}
+
+func (suite *cachingFileProcessorsSuite) TestReader_Closes_All_Results_When_One_Cannot_Be_Read() {
+	suite.resultCache.On("Get", mock.Anything).Return(nil, false, nil)
+	failingResult := &closeCountingReader{Reader: failingReader{errors.New("connection reset")}}
+	unreadResult := &closeCountingReader{Reader: bytes.NewBufferString("read pdf")}
+	suite.fileReader.
+		On("ReadModes", mock.Anything, mock.Anything, mock.Anything).
+		Return(map[ch360.ReadMode]io.ReadCloser{
+			ch360.ReadText: failingResult,
+			ch360.ReadPDF:  unreadResult,
+		}, nil)
+	sut := services.NewCachingFileReader(suite.fileReader, suite.resultCache, false)
+
+	_, err := sut.ReadModes(suite.ctx, bytes.NewReader(suite.fileContent),
+		[]ch360.ReadMode{ch360.ReadText, ch360.ReadPDF})
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), 1, failingResult.closes)
+	assert.Equal(suite.T(), 1, unreadResult.closes)
+}
+
+type failingReader struct {
+	err error
+}
+
+func (r failingReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
+
+type closeCountingReader struct {
+	io.Reader
+	closes int
+}
+
+func (r *closeCountingReader) Close() error {
+	r.closes++
+	return nil
+}
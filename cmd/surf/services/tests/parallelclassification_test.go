@@ -59,6 +59,10 @@ func (suite *parallelClassificationSuite) SetupTest() {
 		On("NotifyFinish").
 		Return(nil)
 
+	suite.progressHandler.
+		On("NotifyAbort").
+		Return(nil)
+
 	suite.progressHandler.
 		On("NotifyErr", mock.Anything, mock.Anything).
 		Return(nil)
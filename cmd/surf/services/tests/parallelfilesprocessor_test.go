@@ -1,7 +1,9 @@
 package tests
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/stretchr/testify/mock"
@@ -9,6 +11,7 @@ import (
 	"github.com/waives/surf/cmd/surf/services"
 	"github.com/waives/surf/cmd/surf/services/mocks"
 	"github.com/waives/surf/pool"
+	"github.com/waives/surf/telemetry"
 	"io/ioutil"
 	"math/rand"
 	"os"
@@ -48,6 +51,7 @@ func (suite *ParallelFilesProcessorSuite) SetupTest() {
 
 	suite.progressHandler.On("NotifyStart", mock.Anything).Return(nil)
 	suite.progressHandler.On("NotifyFinish").Return(nil)
+	suite.progressHandler.On("NotifyAbort").Return(nil)
 	suite.progressHandler.On("Notify", mock.Anything, mock.Anything).Return(nil)
 	suite.progressHandler.On("NotifyErr", mock.Anything, mock.Anything).Return(nil)
 
@@ -110,6 +114,50 @@ func (suite *ParallelFilesProcessorSuite) Test_ProgressHandler_NotifyStart_And_N
 	suite.progressHandler.AssertCalled(suite.T(), "NotifyFinish")
 }
 
+func (suite *ParallelFilesProcessorSuite) Test_Files_Whose_Output_Is_Skipped_Are_Not_Processed() {
+	var (
+		files            = someTempFiles(3)
+		processorFactory = &countingProcessorFactory{}
+	)
+	defer deleteFiles(files)
+	suite.sut.ProgressHandler = &skippingProgressHandler{
+		ProgressHandler: suite.progressHandler,
+		skipped:         map[string]bool{files[1]: true},
+	}
+
+	err := suite.sut.Run(suite.ctx, files, 1, processorFactory.ProcessorFor)
+
+	suite.Require().NoError(err)
+	suite.Assert().Equal(2, processorFactory.processorCalls)
+	suite.progressHandler.AssertCalled(suite.T(), "NotifyStart", 2)
+	suite.progressHandler.AssertNotCalled(suite.T(), "Notify", files[1], mock.Anything)
+}
+
+func (suite *ParallelFilesProcessorSuite) Test_ProgressHandler_NotifyAbort_Called_When_Processing_Fails() {
+	var (
+		files            = someTempFiles(5)
+		processorFactory = erroringProcessorFactory{}
+	)
+	defer deleteFiles(files)
+
+	suite.sut.Run(suite.ctx, files, 1, processorFactory.ProcessorFor)
+
+	suite.progressHandler.AssertCalled(suite.T(), "NotifyAbort")
+	suite.progressHandler.AssertNotCalled(suite.T(), "NotifyFinish")
+}
+
+func (suite *ParallelFilesProcessorSuite) Test_ProgressHandler_NotifyAbort_Called_When_Cancelled() {
+	files := someTempFiles(5)
+	defer deleteFiles(files)
+	ctx, cancel := context.WithCancel(suite.ctx)
+	cancel()
+
+	suite.sut.Run(ctx, files, 1, suite.processorFactory)
+
+	suite.progressHandler.AssertCalled(suite.T(), "NotifyAbort")
+	suite.progressHandler.AssertNotCalled(suite.T(), "NotifyFinish")
+}
+
 func (suite *ParallelFilesProcessorSuite) Test_First_Error_From_Processor_Func_Returned() {
 	// Arrange
 	var (
@@ -127,8 +175,58 @@ func (suite *ParallelFilesProcessorSuite) Test_First_Error_From_Processor_Func_R
 	suite.Assert().Equal(expectedErr, receivedErr)
 }
 
+func (suite *ParallelFilesProcessorSuite) Test_Records_Span_And_Duration_For_Each_File() {
+	// Arrange
+	var (
+		files    = someTempFiles(3)
+		recorder = telemetry.NewRecorder()
+		summary  struct {
+			Metrics []struct {
+				Name  string
+				Count int
+			}
+			Spans []struct {
+				Name       string
+				Attributes map[string]string
+			}
+		}
+		summaryJson = bytes.Buffer{}
+	)
+	defer deleteFiles(files)
+	suite.sut.Recorder = recorder
+
+	// Act
+	suite.sut.Run(suite.ctx, files, 1, suite.processorFactory)
+
+	// Assert
+	suite.Require().NoError(recorder.WriteJson(&summaryJson))
+	suite.Require().NoError(json.Unmarshal(summaryJson.Bytes(), &summary))
+
+	suite.Require().Len(summary.Metrics, 1)
+	suite.Assert().Equal(telemetry.FileDuration.Name, summary.Metrics[0].Name)
+	suite.Assert().Equal(3, summary.Metrics[0].Count)
+
+	var spanFiles []string
+	for _, span := range summary.Spans {
+		suite.Assert().Equal("process_file", span.Name)
+		spanFiles = append(spanFiles, span.Attributes["file"])
+	}
+	suite.Assert().ElementsMatch(files, spanFiles)
+}
+
 var _ services.ProcessorFuncFactory = (*countingProcessorFactory)(nil).ProcessorFor
 
+// skippingProgressHandler is a ProgressHandler which discards the results for some files,
+// as their output files already exist.
+type skippingProgressHandler struct {
+	*mocks.ProgressHandler
+	skipped map[string]bool
+}
+
+func (h *skippingProgressHandler) SkipsOutputFor(filename string) (bool, error) {
+	return h.skipped[filename], nil
+}
+
 type countingProcessorFactory struct {
 	processorFactoryCalls int
 	processorCalls        int
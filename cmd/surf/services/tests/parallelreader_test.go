@@ -10,6 +10,9 @@ import (
 	ch360mocks "github.com/waives/surf/ch360/mocks"
 	"github.com/waives/surf/cmd/surf/services"
 	"github.com/waives/surf/cmd/surf/services/mocks"
+	"io"
+	"io/ioutil"
+	"strings"
 	"testing"
 )
 
@@ -51,6 +54,10 @@ func (suite *parallelReaderSuite) SetupTest() {
 		On("NotifyFinish").
 		Return(nil)
 
+	suite.progressHandler.
+		On("NotifyAbort").
+		Return(nil)
+
 	suite.progressHandler.
 		On("NotifyErr", mock.Anything, mock.Anything).
 		Return(nil)
@@ -102,3 +109,45 @@ func (suite *parallelReaderSuite) Test_ReadAll_Returns_Error_If_ExtractDocument_
 
 	assert.Error(suite.T(), err)
 }
+
+func (suite *parallelReaderSuite) Test_ReadAllModes_Notifies_Results_For_Each_File() {
+	readModes := []ch360.ReadMode{ch360.ReadText, ch360.ReadWvdoc}
+	results := map[ch360.ReadMode]io.ReadCloser{}
+	suite.fileReader.
+		On("ReadModes", mock.Anything, mock.Anything, mock.Anything).
+		Return(results, nil)
+
+	err := suite.sut.ReadAllModes(suite.ctx, suite.filePatterns, readModes)
+
+	assert.NoError(suite.T(), err)
+	suite.fileReader.AssertNumberOfCalls(suite.T(), "ReadModes", len(suite.filePatterns))
+	suite.fileReader.AssertCalled(suite.T(), "ReadModes", mock.Anything, mock.Anything, readModes)
+	for _, filename := range suite.filePatterns {
+		suite.progressHandler.AssertCalled(suite.T(), "Notify", filename, results)
+	}
+}
+
+func (suite *parallelReaderSuite) Test_ReadAllModes_Returns_Error_If_ReadModes_Fails() {
+	suite.fileReader.
+		On("ReadModes", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("simulated error"))
+
+	err := suite.sut.ReadAllModes(suite.ctx, suite.filePatterns, []ch360.ReadMode{ch360.ReadText})
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *parallelReaderSuite) Test_ReadAll_Opens_Files_With_FileOpener() {
+	var opened []string
+	suite.sut.WithFileOpener(func(filename string) (io.ReadCloser, error) {
+		opened = append(opened, filename)
+		return ioutil.NopCloser(strings.NewReader(filename)), nil
+	})
+
+	err := suite.sut.ReadAll(suite.ctx, []string{"archive/entry.pdf"}, suite.readMode)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"archive/entry.pdf"}, opened)
+	suite.fileReader.AssertCalled(suite.T(), "Read", mock.Anything,
+		ioutil.NopCloser(strings.NewReader("archive/entry.pdf")), suite.readMode)
+}
@@ -7,7 +7,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/audit"
+	"github.com/waives/surf/ch360"
 	ch360mocks "github.com/waives/surf/ch360/mocks"
+	"github.com/waives/surf/ch360/request"
 	"github.com/waives/surf/cmd/surf/services"
 	"github.com/waives/surf/cmd/surf/services/mocks"
 	"github.com/waives/surf/test/generators"
@@ -60,6 +63,10 @@ func (suite *parallelRedactionSuite) SetupTest() {
 		On("NotifyFinish").
 		Return(nil)
 
+	suite.progressHandler.
+		On("NotifyAbort").
+		Return(nil)
+
 	suite.progressHandler.
 		On("NotifyErr", mock.Anything, mock.Anything).
 		Return(nil)
@@ -68,6 +75,14 @@ func (suite *parallelRedactionSuite) SetupTest() {
 		On("Redact", mock.Anything, mock.Anything, mock.Anything).
 		Return(nil, nil)
 
+	suite.fileRedactor.
+		On("RedactWith", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, nil)
+
+	suite.fileRedactor.
+		On("BuildRedactionRequest", mock.Anything, mock.Anything, mock.Anything).
+		Return(&request.RedactedPdfRequest{}, nil)
+
 	suite.sut = services.NewParallelRedactionService(suite.fileRedactor, suite.documentGetter,
 		suite.progressHandler)
 }
@@ -133,3 +148,61 @@ func (suite *parallelRedactionSuite) Test_Redact_Returns_Error_If_RedactDocument
 
 	assert.Error(suite.T(), err)
 }
+
+func (suite *parallelRedactionSuite) Test_RedactAll_Redacts_Each_File_With_Builder() {
+	builder := ch360.AreaRedaction{}
+
+	err := suite.sut.RedactAll(suite.ctx, suite.testFilePatterns, builder)
+
+	assert.Nil(suite.T(), err)
+	suite.fileRedactor.AssertNumberOfCalls(suite.T(), "RedactWith", len(suite.testFilePatterns))
+	suite.fileRedactor.AssertCalled(suite.T(), "RedactWith", mock.Anything, mock.Anything, builder)
+	suite.fileRedactor.AssertNotCalled(suite.T(), "Redact", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *parallelRedactionSuite) Test_RedactAll_Returns_Error_If_RedactWith_Fails() {
+	suite.fileRedactor.ExpectedCalls = nil
+	suite.fileRedactor.
+		On("RedactWith", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("simulated error"))
+
+	err := suite.sut.RedactAll(suite.ctx, suite.testFilePatterns, ch360.AreaRedaction{})
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *parallelRedactionSuite) Test_BuildAllRedactionRequests_Passes_Requests_To_ProgressHandler() {
+	builder := ch360.AreaRedaction{}
+
+	err := suite.sut.BuildAllRedactionRequests(suite.ctx, suite.testFilePatterns, builder)
+
+	assert.Nil(suite.T(), err)
+	suite.fileRedactor.AssertNumberOfCalls(suite.T(), "BuildRedactionRequest", len(suite.testFilePatterns))
+	suite.fileRedactor.AssertNotCalled(suite.T(), "RedactWith", mock.Anything, mock.Anything, mock.Anything)
+	suite.progressHandler.AssertCalled(suite.T(), "Notify", mock.Anything, &request.RedactedPdfRequest{})
+}
+
+func (suite *parallelRedactionSuite) Test_RedactAll_WithAudit_Returns_RedactedFile_With_Hash_And_Request() {
+	expectedRequest := &request.RedactedPdfRequest{ApplyMarks: true}
+	builder := new(ch360mocks.RedactionRequestBuilder)
+	builder.On("BuildRedactionRequest", mock.Anything, mock.Anything).Return(expectedRequest, nil)
+	suite.fileRedactor.ExpectedCalls = nil
+	suite.fileRedactor.
+		On("RedactWith", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(2).(ch360.RedactionRequestBuilder).
+				BuildRedactionRequest(suite.ctx, suite.documentId)
+		}).
+		Return(suite.redactionResult, nil)
+
+	err := suite.sut.WithAudit().RedactAll(suite.ctx, suite.testFilePatterns[:1], builder)
+
+	assert.Nil(suite.T(), err)
+	suite.progressHandler.AssertCalled(suite.T(), "Notify", suite.testFilePatterns[0],
+		&audit.RedactedFile{
+			Contents: suite.redactionResult,
+			// the SHA256 of an empty file
+			SourceSHA256: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			Request:      expectedRequest,
+		})
+}
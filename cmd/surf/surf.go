@@ -12,6 +12,7 @@ import (
 	"github.com/waives/surf/cmd/surf/commands"
 	"github.com/waives/surf/config"
 	"github.com/waives/surf/ioutils"
+	"github.com/waives/surf/telemetry"
 	"gopkg.in/alecthomas/kingpin.v2"
 )
 
@@ -23,8 +24,14 @@ func main() {
 
 		listCmd = app.Command("list", "List waives resources.")
 		// uploadCmd = app.Command("upload", "Upload waives resources.")
-		deleteCmd = app.Command("delete", "Delete waives resources.")
-		createCmd = app.Command("create", "Create waives resources.")
+		deleteCmd   = app.Command("delete", "Delete waives resources.")
+		createCmd   = app.Command("create", "Create waives resources.")
+		getCmd      = app.Command("get", "Get the definitions of waives resources.")
+		diffCmd     = app.Command("diff", "Compare waives resources with local definitions.")
+		applyCmd    = app.Command("apply", "Create or update waives resources from local definitions.")
+		evaluateCmd = app.Command("evaluate", "Measure the performance of waives resources.")
+		validateCmd = app.Command("validate", "Check local definitions of waives resources.")
+		describeCmd = app.Command("describe", "Show the details of waives resources.")
 
 		ctx, canceller = context.WithCancel(context.Background())
 	)
@@ -32,7 +39,7 @@ func main() {
 	go handleInterrupt(canceller)
 
 	commands.ConfigureLoginCommand(ctx, app, &globalFlags)
-	// commands.ConfigureListModulesCommand(ctx, listCmd, &globalFlags)
+	commands.ConfigureListModulesCommand(ctx, listCmd, &globalFlags)
 	// commands.ConfigureListClassifiersCmd(ctx, listCmd, &globalFlags)
 	// commands.ConfigureListExtractorsCmd(ctx, listCmd, &globalFlags)
 	commands.ConfigureListDocumentsCmd(ctx, listCmd, &globalFlags)
@@ -40,15 +47,24 @@ func main() {
 	// commands.ConfigureDeleteExtractorCmd(ctx, deleteCmd, &globalFlags)
 	// commands.ConfigureDeleteClassifierCmd(ctx, deleteCmd, &globalFlags)
 	commands.ConfigureDeleteDocumentCmd(ctx, deleteCmd, &globalFlags)
-	// commands.ConfigureCreateClassifierCmd(ctx, createCmd, &globalFlags)
+	commands.ConfigureCreateClassifierCmd(ctx, createCmd, &globalFlags)
 	// commands.ConfigureCreateExtractorCmd(ctx, createCmd, &globalFlags)
 	// commands.ConfigureCreateExtractorTemplateCmd(ctx, createCmd, &globalFlags)
 	commands.ConfigureCreateDocumentCmd(ctx, createCmd, &globalFlags)
+	commands.ConfigureGetExtractorCmd(ctx, getCmd, &globalFlags)
+	commands.ConfigureDiffExtractorCmd(ctx, diffCmd, &globalFlags)
+	commands.ConfigureApplyExtractorCmd(ctx, applyCmd, &globalFlags)
+	commands.ConfigureEvaluateClassifierCmd(ctx, evaluateCmd, &globalFlags)
+	commands.ConfigureEvaluateExtractorCmd(ctx, evaluateCmd, &globalFlags)
+	commands.ConfigureValidateExtractorTemplateCmd(ctx, validateCmd, &globalFlags)
+	commands.ConfigureDescribeModuleCmd(ctx, describeCmd, &globalFlags)
 	commands.ConfigureReadCommand(ctx, app, &globalFlags)
+	commands.ConfigureCacheCommand(ctx, app, &globalFlags)
+	commands.ConfigureSyncCommand(ctx, app, &globalFlags)
 	// commands.ConfigureExtractCommand(ctx, app, &globalFlags)
 	// commands.ConfigureClassifyCommand(ctx, app, &globalFlags)
 	// commands.ConfigureUploadClassifierCommand(ctx, uploadCmd, &globalFlags)
-	// commands.ConfigureRedactWithExtractionCommand(ctx, app, &globalFlags)
+	commands.ConfigureRedactCommand(ctx, app, &globalFlags)
 
 	app.Flag("client-id", "Client ID").
 		Short('i').
@@ -62,6 +78,64 @@ func main() {
 		"to a file.").
 		PlaceHolder("file").
 		OpenFileVar(&globalFlags.LogHttp, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+	app.Flag("log-http-format", "The format of the --log-http log: text, or json for "+
+		"one record per request.").
+		Default(string(ch360.HttpLogText)).
+		EnumVar(&globalFlags.LogHttpFormat, ch360.HttpLogFormats...)
+	app.Flag("max-rps", "Send at most this many API requests per second, on average "+
+		"(overrides maxRequestsPerSecond in the configuration file).").
+		PlaceHolder("n").
+		Float64Var(&globalFlags.MaxRps)
+	app.Flag("max-concurrency", "Have at most this many API requests in progress at once "+
+		"(overrides maxConcurrency in the configuration file).").
+		PlaceHolder("n").
+		IntVar(&globalFlags.MaxConcurrency)
+	app.Flag("retry-attempts", "Retry failed API requests at most this many times "+
+		"(default 3).").
+		PlaceHolder("n").
+		StringVar(&globalFlags.RetryAttempts)
+	app.Flag("retry-max-time", "Stop retrying a failed API request this long after it was "+
+		"first sent, e.g. 2m (default 15m, 0 for no limit).").
+		PlaceHolder("duration").
+		StringVar(&globalFlags.RetryMaxTime)
+	app.Flag("retry-jitter", "Randomly vary the delay between retries by up to this "+
+		"proportion, from 0 to 1 (default 0.5).").
+		PlaceHolder("fraction").
+		StringVar(&globalFlags.RetryJitter)
+	app.Flag("retry-status", "Retry API requests which fail with these HTTP status codes, or "+
+		"'none' (default 408,429,500,502,503,504).").
+		PlaceHolder("codes").
+		StringVar(&globalFlags.RetryStatusCodes)
+	app.Flag("proxy", "Send API requests via this HTTP(S) proxy (overrides proxy in the "+
+		"configuration file, and the HTTPS_PROXY environment variable).").
+		PlaceHolder("url").
+		StringVar(&globalFlags.Proxy)
+	app.Flag("ca-cert", "Trust the certificate authorities in this PEM file, as well as "+
+		"the system's (overrides caCertificates in the configuration file).").
+		PlaceHolder("file").
+		StringVar(&globalFlags.CACertificates)
+	app.Flag("client-cert", "Authenticate with the client certificate in this PEM file "+
+		"(overrides clientCertificate in the configuration file).").
+		PlaceHolder("file").
+		StringVar(&globalFlags.ClientCertificate)
+	app.Flag("client-key", "The private key of the client certificate, if it is not in "+
+		"the certificate's file (overrides clientKey in the configuration file).").
+		PlaceHolder("file").
+		StringVar(&globalFlags.ClientKey)
+	app.Flag("timeout", "Give up on API requests which take longer than this, e.g. 5m "+
+		"(0 for no limit). Prefix it with an operation to apply it to just those requests, "+
+		"e.g. read=30m (default 2m, and read=10m). Operations: "+
+		strings.Join(ch360.Operations(), ", ")+". Can be repeated.").
+		PlaceHolder("[operation=]duration").
+		StringsVar(&globalFlags.Timeouts)
+	app.Flag("metrics-file", "Record the time taken by API requests and to process each "+
+		"file, and write the metrics to a file when surf exits.").
+		PlaceHolder("file").
+		StringVar(&globalFlags.MetricsFile)
+	app.Flag("metrics-format", "The format of the --metrics-file: prometheus (text format), "+
+		"or json for a summary of the metrics and a span for each file.").
+		Default(metricsFormatPrometheus).
+		EnumVar(&globalFlags.MetricsFormat, metricsFormatPrometheus, metricsFormatJson)
 	app.Flag("version", "Show the application version.").
 		PreAction(func(parseContext *kingpin.ParseContext) error {
 			fmt.Println(ch360.Version)
@@ -70,15 +144,53 @@ func main() {
 		}).
 		Bool()
 
+	app.PreAction(func(parseContext *kingpin.ParseContext) error {
+		if globalFlags.MetricsFile != "" {
+			globalFlags.Telemetry = telemetry.NewRecorder()
+		}
+		return nil
+	})
+
 	app.UsageTemplate(kingpin.CompactUsageTemplate)
 	app.HelpFlag.Hidden()
 
 	defer ioutils.TryClose(globalFlags.LogHttp)
 
-	_, err := app.Parse(os.Args[1:])
+	_, err := app.Parse(commands.EscapeStdinArgs(os.Args[1:]))
+
+	// the metrics are written even if the command failed, to show where time was spent
+	if metricsErr := writeMetrics(&globalFlags); err == nil {
+		err = metricsErr
+	}
 	exitOnErr(err)
 }
 
+const (
+	metricsFormatPrometheus = "prometheus"
+	metricsFormatJson       = "json"
+)
+
+// writeMetrics writes the recorded metrics to the --metrics-file, if it was specified.
+func writeMetrics(globalFlags *config.GlobalFlags) error {
+	if globalFlags.Telemetry == nil {
+		return nil
+	}
+
+	file, err := os.Create(globalFlags.MetricsFile)
+	if err != nil {
+		return errors.Wrap(err, "Unable to write metrics")
+	}
+	defer file.Close()
+
+	if globalFlags.MetricsFormat == metricsFormatJson {
+		err = globalFlags.Telemetry.WriteJson(file)
+	} else {
+		err = globalFlags.Telemetry.WritePrometheus(file)
+	}
+
+	return errors.Wrap(err, "Unable to write metrics")
+}
+
 func handleInterrupt(canceller context.CancelFunc) {
 	interruptChan := make(chan os.Signal, 1)
 	signal.Notify(interruptChan, os.Interrupt)
@@ -77,6 +77,11 @@ func (appDirectory *AppDirectory) getPath() string {
 	return filepath.Join(appDirectory.homeDirectory, ".surf")
 }
 
+// CacheDirectory returns the path of the directory used to cache results.
+func (appDirectory *AppDirectory) CacheDirectory() string {
+	return filepath.Join(appDirectory.getPath(), "cache")
+}
+
 func (appDirectory *AppDirectory) configFilePath() string {
 	return filepath.Join(appDirectory.getPath(), "config.yaml")
 }
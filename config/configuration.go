@@ -6,6 +6,55 @@ import (
 
 type Configuration struct {
 	Credentials ApiCredentialsList `yaml:"credentials"`
+	Http        HttpSettings       `yaml:"http,omitempty"`
+}
+
+// HttpSettings configure how requests are sent to the API. They are overridden by
+// the equivalent command line flags.
+type HttpSettings struct {
+	// MaxRequestsPerSecond limits the rate at which requests are sent (0 for no limit).
+	MaxRequestsPerSecond float64 `yaml:"maxRequestsPerSecond,omitempty"`
+	// MaxConcurrency limits the number of requests in progress at once (0 for no limit).
+	MaxConcurrency int `yaml:"maxConcurrency,omitempty"`
+	// Retry configures how failed requests are retried.
+	Retry RetrySettings `yaml:"retry,omitempty"`
+	// Proxy is the URL of the proxy requests are sent via (by default, that specified
+	// by the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables).
+	Proxy string `yaml:"proxy,omitempty"`
+	// CACertificates is the name of a PEM file of additional trusted certificate authorities.
+	CACertificates string `yaml:"caCertificates,omitempty"`
+	// ClientCertificate and ClientKey are the names of the PEM files of the client
+	// certificate and its key, for mutual TLS. The key may be in the certificate's file.
+	ClientCertificate string `yaml:"clientCertificate,omitempty"`
+	ClientKey         string `yaml:"clientKey,omitempty"`
+	// Timeouts are durations, such as "5m" (or "0" for no limit), by the name of the
+	// operation (e.g. "read") they apply to, or "default" for any other operation.
+	Timeouts map[string]string `yaml:"timeouts,omitempty"`
+}
+
+// RetrySettings override the default policy for retrying failed requests. Any settings
+// which are not specified keep their default values.
+type RetrySettings struct {
+	RetryPolicySettings `yaml:",inline"`
+	// Endpoints override the policy for the requests to particular endpoints.
+	Endpoints []EndpointRetrySettings `yaml:"endpoints,omitempty"`
+}
+
+// RetryPolicySettings describe how failed requests are retried.
+type RetryPolicySettings struct {
+	Attempts *int `yaml:"attempts,omitempty"`
+	// MaxElapsedTime is a duration, such as "2m" (or "0" for no limit).
+	MaxElapsedTime string   `yaml:"maxElapsedTime,omitempty"`
+	Jitter         *float64 `yaml:"jitter,omitempty"`
+	StatusCodes    []int    `yaml:"statusCodes,omitempty"`
+}
+
+// EndpointRetrySettings describe how requests to an endpoint (those with the method,
+// and a URL path matching the pattern) are retried.
+type EndpointRetrySettings struct {
+	Method              string `yaml:"method,omitempty"`
+	Path                string `yaml:"path,omitempty"`
+	RetryPolicySettings `yaml:",inline"`
 }
 
 type ApiCredentialsList []ApiCredentials
@@ -2,16 +2,50 @@ package config
 
 import (
 	"github.com/mattn/go-isatty"
+	"github.com/waives/surf/telemetry"
 	"os"
 )
 
 type GlobalFlags struct {
-	MultiFileOut bool
-	OutputFile   string
-	ShowProgress bool
-	ClientId     string
-	ClientSecret string
-	LogHttp      *os.File
+	MultiFileOut  bool
+	OutputFile    string
+	ShowProgress  bool
+	ClientId      string
+	ClientSecret  string
+	LogHttp       *os.File
+	LogHttpFormat string
+	Deduplicate   bool
+	UseCache      bool
+	RefreshCache  bool
+	FilesFrom     string
+	NullSeparated bool
+	OutputDir     string
+	Overwrite     bool
+	SkipExisting  bool
+	FailIfExists  bool
+	// MaxRps and MaxConcurrency are 0 when not specified, in which case
+	// any limits in the configuration file are used.
+	MaxRps         float64
+	MaxConcurrency int
+	// The retry flags are empty when not specified, in which case any
+	// settings in the configuration file are used.
+	RetryAttempts    string
+	RetryMaxTime     string
+	RetryJitter      string
+	RetryStatusCodes string
+	// The connection flags are empty when not specified, in which case any
+	// settings in the configuration file are used.
+	Proxy             string
+	CACertificates    string
+	ClientCertificate string
+	ClientKey         string
+	// Timeouts are of the form [operation=]duration.
+	Timeouts []string
+	// MetricsFile, if specified, is written with the metrics recorded by
+	// Telemetry (which is nil otherwise), in MetricsFormat.
+	MetricsFile   string
+	MetricsFormat string
+	Telemetry     *telemetry.Recorder
 }
 
 func (r *GlobalFlags) CanShowProgressBar() bool {
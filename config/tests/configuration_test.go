@@ -47,6 +47,17 @@ func (suite *ConfigurationSuite) TestConfigurationSerialise_Can_Be_Deserialised_
 	assert.Equal(suite.T(), expectedConfiguration, configuration)
 }
 
+func (suite *ConfigurationSuite) TestConfigurationSerialise_Can_Be_Deserialised_With_Http_Settings() {
+	suite.sut.Http = config.HttpSettings{MaxRequestsPerSecond: 2.5, MaxConcurrency: 4}
+
+	bytes, err := suite.sut.Serialise()
+	assert.Nil(suite.T(), err)
+
+	configuration, err := config.DeserialiseConfiguration(bytes)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), suite.sut.Http, configuration.Http)
+}
+
 func (suite *ConfigurationSuite) TestConfigurationDeserialise_Returns_Error_If_Attempting_To_Deserialised_Invalid_Contents() {
 	_, err := config.DeserialiseConfiguration(generators.Bytes())
 	assert.NotNil(suite.T(), err)
@@ -0,0 +1,204 @@
+package evaluation
+
+import (
+	"github.com/pkg/errors"
+	"github.com/waives/surf/ch360/results"
+	"sort"
+)
+
+// ClassificationOutcome is the result of classifying a single file of a known document type.
+type ClassificationOutcome struct {
+	Filename           string
+	Expected           string
+	Actual             string
+	IsConfident        bool
+	RelativeConfidence float64
+}
+
+// IsCorrect returns true if the file was classified as the expected document type.
+func (o ClassificationOutcome) IsCorrect() bool {
+	return o.Expected == o.Actual
+}
+
+// ClassificationEvaluator compares classification results with the expected document
+// type of each file. It implements resultsWriters.ResultsWriter, so it can collect the
+// results of a ParallelClassificationService.
+type ClassificationEvaluator struct {
+	expectedTypes map[string]string
+	outcomes      []ClassificationOutcome
+}
+
+// NewClassificationEvaluator constructs a ClassificationEvaluator for files with the
+// provided expected document types, keyed by filename.
+func NewClassificationEvaluator(expectedTypes map[string]string) *ClassificationEvaluator {
+	return &ClassificationEvaluator{
+		expectedTypes: expectedTypes,
+	}
+}
+
+func (e *ClassificationEvaluator) Start() error {
+	e.outcomes = nil
+	return nil
+}
+
+func (e *ClassificationEvaluator) WriteResult(filename string, result interface{}) error {
+	classificationResult, ok := result.(*results.ClassificationResult)
+	if !ok {
+		return errors.Errorf("unexpected result type: %T", result)
+	}
+
+	expected, ok := e.expectedTypes[filename]
+	if !ok {
+		return errors.Errorf("the expected document type of '%s' is not known", filename)
+	}
+
+	e.outcomes = append(e.outcomes, ClassificationOutcome{
+		Filename:           filename,
+		Expected:           expected,
+		Actual:             classificationResult.DocumentType,
+		IsConfident:        classificationResult.IsConfident,
+		RelativeConfidence: classificationResult.RelativeConfidence,
+	})
+	return nil
+}
+
+func (e *ClassificationEvaluator) Finish() error {
+	return nil
+}
+
+func (e *ClassificationEvaluator) Abort() error {
+	return nil
+}
+
+// Outcomes returns the outcome for each file classified so far.
+func (e *ClassificationEvaluator) Outcomes() []ClassificationOutcome {
+	return e.outcomes
+}
+
+// ClassificationReport summarises how well a classifier performed against files of
+// known document types.
+type ClassificationReport struct {
+	Total           int                   `json:"total"`
+	Correct         int                   `json:"correct"`
+	Accuracy        float64               `json:"accuracy"`
+	DocumentTypes   []DocumentTypeMetrics `json:"document_types"`
+	ConfusionMatrix ConfusionMatrix       `json:"confusion_matrix"`
+	Confident       ConfidenceMetrics     `json:"confident"`
+	NotConfident    ConfidenceMetrics     `json:"not_confident"`
+}
+
+// DocumentTypeMetrics describes how well a classifier performed for a single document type.
+type DocumentTypeMetrics struct {
+	DocumentType string  `json:"document_type"`
+	Support      int     `json:"support"`
+	Precision    float64 `json:"precision"`
+	Recall       float64 `json:"recall"`
+	F1           float64 `json:"f1"`
+}
+
+// ConfusionMatrix holds the number of files of each expected document type (the rows)
+// which were classified as each document type (the columns).
+type ConfusionMatrix struct {
+	DocumentTypes []string `json:"document_types"`
+	Counts        [][]int  `json:"counts"`
+}
+
+// ConfidenceMetrics describes the results which were (or were not) confident. Comparing
+// the two shows the effect of only accepting confident results.
+type ConfidenceMetrics struct {
+	Total    int     `json:"total"`
+	Correct  int     `json:"correct"`
+	Coverage float64 `json:"coverage"`
+	Accuracy float64 `json:"accuracy"`
+}
+
+// Report calculates the metrics for the outcomes collected so far.
+func (e *ClassificationEvaluator) Report() *ClassificationReport {
+	report := &ClassificationReport{
+		Total: len(e.outcomes),
+	}
+
+	documentTypes := e.documentTypes()
+	index := map[string]int{}
+	for i, documentType := range documentTypes {
+		index[documentType] = i
+	}
+
+	report.ConfusionMatrix = ConfusionMatrix{
+		DocumentTypes: documentTypes,
+		Counts:        make([][]int, len(documentTypes)),
+	}
+	for i := range report.ConfusionMatrix.Counts {
+		report.ConfusionMatrix.Counts[i] = make([]int, len(documentTypes))
+	}
+
+	for _, outcome := range e.outcomes {
+		report.ConfusionMatrix.Counts[index[outcome.Expected]][index[outcome.Actual]]++
+
+		band := &report.NotConfident
+		if outcome.IsConfident {
+			band = &report.Confident
+		}
+		band.Total++
+
+		if outcome.IsCorrect() {
+			report.Correct++
+			band.Correct++
+		}
+	}
+
+	report.Accuracy = ratio(report.Correct, report.Total)
+	for _, band := range []*ConfidenceMetrics{&report.Confident, &report.NotConfident} {
+		band.Coverage = ratio(band.Total, report.Total)
+		band.Accuracy = ratio(band.Correct, band.Total)
+	}
+
+	for i, documentType := range documentTypes {
+		var expectedCount, actualCount int
+		for j := range documentTypes {
+			expectedCount += report.ConfusionMatrix.Counts[i][j]
+			actualCount += report.ConfusionMatrix.Counts[j][i]
+		}
+		correct := report.ConfusionMatrix.Counts[i][i]
+
+		metrics := DocumentTypeMetrics{
+			DocumentType: documentType,
+			Support:      expectedCount,
+			Precision:    ratio(correct, actualCount),
+			Recall:       ratio(correct, expectedCount),
+		}
+		if metrics.Precision+metrics.Recall > 0 {
+			metrics.F1 = 2 * metrics.Precision * metrics.Recall / (metrics.Precision + metrics.Recall)
+		}
+
+		report.DocumentTypes = append(report.DocumentTypes, metrics)
+	}
+
+	return report
+}
+
+// documentTypes returns the expected and actual document types, in alphabetical order.
+func (e *ClassificationEvaluator) documentTypes() []string {
+	seen := map[string]bool{}
+	documentTypes := []string{}
+
+	for _, outcome := range e.outcomes {
+		for _, documentType := range []string{outcome.Expected, outcome.Actual} {
+			if !seen[documentType] {
+				seen[documentType] = true
+				documentTypes = append(documentTypes, documentType)
+			}
+		}
+	}
+	sort.Strings(documentTypes)
+
+	return documentTypes
+}
+
+// ratio returns numerator / denominator, or 0 if the denominator is 0.
+func ratio(numerator, denominator int) float64 {
+	if denominator == 0 {
+		return 0
+	}
+	return float64(numerator) / float64(denominator)
+}
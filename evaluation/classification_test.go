@@ -0,0 +1,72 @@
+package evaluation_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/waives/surf/ch360/results"
+	"github.com/waives/surf/evaluation"
+	"testing"
+)
+
+func classify(t *testing.T, sut *evaluation.ClassificationEvaluator, filename, documentType string,
+	isConfident bool) {
+	err := sut.WriteResult(filename, &results.ClassificationResult{
+		DocumentType: documentType,
+		IsConfident:  isConfident,
+	})
+	require.NoError(t, err)
+}
+
+func TestClassificationEvaluator_Report_Calculates_Metrics(t *testing.T) {
+	sut := evaluation.NewClassificationEvaluator(map[string]string{
+		"inv1": "invoice", "inv2": "invoice", "inv3": "invoice", "rec1": "receipt",
+	})
+	require.NoError(t, sut.Start())
+
+	classify(t, sut, "inv1", "invoice", true)
+	classify(t, sut, "inv2", "invoice", true)
+	classify(t, sut, "inv3", "receipt", false)
+	classify(t, sut, "rec1", "receipt", true)
+
+	report := sut.Report()
+
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, 3, report.Correct)
+	assert.Equal(t, 0.75, report.Accuracy)
+	assert.Equal(t, evaluation.ConfusionMatrix{
+		DocumentTypes: []string{"invoice", "receipt"},
+		Counts:        [][]int{{2, 1}, {0, 1}},
+	}, report.ConfusionMatrix)
+
+	require.Len(t, report.DocumentTypes, 2)
+	invoice, receipt := report.DocumentTypes[0], report.DocumentTypes[1]
+	assert.Equal(t, 3, invoice.Support)
+	assert.Equal(t, 1.0, invoice.Precision)
+	assert.InDelta(t, 2.0/3, invoice.Recall, 1e-9)
+	assert.InDelta(t, 0.8, invoice.F1, 1e-9)
+	assert.Equal(t, 0.5, receipt.Precision)
+	assert.Equal(t, 1.0, receipt.Recall)
+
+	assert.Equal(t, evaluation.ConfidenceMetrics{Total: 3, Correct: 3, Coverage: 0.75, Accuracy: 1},
+		report.Confident)
+	assert.Equal(t, evaluation.ConfidenceMetrics{Total: 1, Correct: 0, Coverage: 0.25, Accuracy: 0},
+		report.NotConfident)
+}
+
+func TestClassificationEvaluator_Report_Handles_No_Results(t *testing.T) {
+	sut := evaluation.NewClassificationEvaluator(nil)
+
+	report := sut.Report()
+
+	assert.Equal(t, 0, report.Total)
+	assert.Equal(t, 0.0, report.Accuracy)
+	assert.Empty(t, report.DocumentTypes)
+}
+
+func TestClassificationEvaluator_WriteResult_Returns_Error_For_Unknown_File(t *testing.T) {
+	sut := evaluation.NewClassificationEvaluator(map[string]string{})
+
+	err := sut.WriteResult("unknown", &results.ClassificationResult{})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,187 @@
+package evaluation
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/waives/surf/ch360/results"
+	"sort"
+)
+
+// ExtractionEvaluator compares extraction results with the expected field values of
+// each file. It implements resultsWriters.ResultsWriter, so it can collect the results
+// of a ParallelExtractionService.
+type ExtractionEvaluator struct {
+	truth     *GroundTruth
+	matcher   Matcher
+	fields    map[string]*FieldMetrics
+	errors    []DocumentErrors
+	documents int
+}
+
+// NewExtractionEvaluator constructs an ExtractionEvaluator for the files in the
+// provided GroundTruth.
+func NewExtractionEvaluator(truth *GroundTruth, matcher Matcher) *ExtractionEvaluator {
+	evaluator := &ExtractionEvaluator{
+		truth:   truth,
+		matcher: matcher,
+	}
+	_ = evaluator.Start()
+
+	return evaluator
+}
+
+// FieldMetrics describes how well an extractor performed for a single field.
+type FieldMetrics struct {
+	Field     string `json:"field"`
+	Documents int    `json:"documents"`
+	// Correct is the number of results which matched the expected value.
+	Correct int `json:"correct"`
+	// Incorrect is the number of results which did not match the expected value.
+	Incorrect int `json:"incorrect"`
+	// Missed is the number of documents with an expected value, but no result.
+	Missed int `json:"missed"`
+	// Spurious is the number of results for documents without an expected value.
+	Spurious int `json:"spurious"`
+	// Rejected is the number of documents without a result.
+	Rejected   int     `json:"rejected"`
+	Precision  float64 `json:"precision"`
+	Recall     float64 `json:"recall"`
+	RejectRate float64 `json:"reject_rate"`
+}
+
+// DocumentErrors lists the fields which were not extracted correctly from a document.
+type DocumentErrors struct {
+	Filename string   `json:"filename"`
+	Fields   []string `json:"fields"`
+}
+
+// ExtractionReport summarises how well an extractor performed against files with
+// known field values.
+type ExtractionReport struct {
+	Documents      int              `json:"documents"`
+	Fields         []FieldMetrics   `json:"fields"`
+	WorstDocuments []DocumentErrors `json:"worst_documents"`
+}
+
+func (e *ExtractionEvaluator) Start() error {
+	e.fields = map[string]*FieldMetrics{}
+	for _, field := range e.truth.Fields {
+		e.fields[field] = &FieldMetrics{Field: field}
+	}
+	e.errors = nil
+	e.documents = 0
+
+	return nil
+}
+
+func (e *ExtractionEvaluator) WriteResult(filename string, result interface{}) error {
+	extractionResult, ok := result.(*results.ExtractionResult)
+	if !ok {
+		return errors.Errorf("unexpected result type: %T", result)
+	}
+
+	expectedValues, ok := e.truth.Values[filename]
+	if !ok {
+		return errors.Errorf("the expected field values of '%s' are not known", filename)
+	}
+
+	e.documents++
+
+	actualResults := map[string]*results.InnerResult{}
+	for _, fieldResult := range extractionResult.FieldResults {
+		if !fieldResult.Rejected && fieldResult.Result != nil {
+			actualResults[fieldResult.FieldName] = fieldResult.Result
+		}
+	}
+
+	documentErrors := DocumentErrors{Filename: filename}
+	for _, field := range e.truth.Fields {
+		if !e.evaluateField(e.fields[field], expectedValues[field], actualResults[field]) {
+			documentErrors.Fields = append(documentErrors.Fields, field)
+		}
+	}
+
+	if len(documentErrors.Fields) > 0 {
+		e.errors = append(e.errors, documentErrors)
+	}
+
+	return nil
+}
+
+// evaluateField records the outcome for a single field, returning false if it is an error.
+func (e *ExtractionEvaluator) evaluateField(metrics *FieldMetrics, expected string,
+	actual *results.InnerResult) bool {
+	metrics.Documents++
+
+	if actual == nil {
+		metrics.Rejected++
+
+		if expected != "" {
+			metrics.Missed++
+			return false
+		}
+		return true
+	}
+
+	if expected == "" {
+		metrics.Spurious++
+		return false
+	}
+
+	if e.matches(expected, actual) {
+		metrics.Correct++
+		return true
+	}
+
+	metrics.Incorrect++
+	return false
+}
+
+// matches compares the expected value with the text of the result, and its value (the
+// normalised form of the text, for some field types).
+func (e *ExtractionEvaluator) matches(expected string, actual *results.InnerResult) bool {
+	if e.matcher.Matches(expected, actual.Text) {
+		return true
+	}
+
+	return actual.Value != nil && e.matcher.Matches(expected, fmt.Sprint(actual.Value))
+}
+
+func (e *ExtractionEvaluator) Finish() error {
+	return nil
+}
+
+func (e *ExtractionEvaluator) Abort() error {
+	return nil
+}
+
+// Report calculates the metrics for the results collected so far, listing (at most)
+// the specified number of documents with the most errors.
+func (e *ExtractionEvaluator) Report(worstDocuments int) *ExtractionReport {
+	report := &ExtractionReport{
+		Documents: e.documents,
+	}
+
+	for _, field := range e.truth.Fields {
+		metrics := *e.fields[field]
+		metrics.Precision = ratio(metrics.Correct, metrics.Correct+metrics.Incorrect+metrics.Spurious)
+		metrics.Recall = ratio(metrics.Correct, metrics.Correct+metrics.Incorrect+metrics.Missed)
+		metrics.RejectRate = ratio(metrics.Rejected, metrics.Documents)
+
+		report.Fields = append(report.Fields, metrics)
+	}
+
+	worst := append([]DocumentErrors{}, e.errors...)
+	sort.SliceStable(worst, func(i, j int) bool {
+		if len(worst[i].Fields) != len(worst[j].Fields) {
+			return len(worst[i].Fields) > len(worst[j].Fields)
+		}
+		return worst[i].Filename < worst[j].Filename
+	})
+	if len(worst) > worstDocuments {
+		worst = worst[:worstDocuments]
+	}
+	report.WorstDocuments = worst
+
+	return report
+}
@@ -0,0 +1,102 @@
+package evaluation_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/waives/surf/ch360/results"
+	"github.com/waives/surf/evaluation"
+	"testing"
+)
+
+func anExtractionResult(values map[string]string) *results.ExtractionResult {
+	result := &results.ExtractionResult{}
+
+	for field, value := range values {
+		fieldResult := results.FieldResult{FieldName: field}
+		if value == "" {
+			fieldResult.Rejected = true
+		} else {
+			fieldResult.Result = &results.InnerResult{Text: value}
+		}
+		result.FieldResults = append(result.FieldResults, fieldResult)
+	}
+
+	return result
+}
+
+func TestExtractionEvaluator_Report_Calculates_Field_Metrics(t *testing.T) {
+	truth := &evaluation.GroundTruth{
+		Fields: []string{"Amount"},
+		Files:  []string{"a", "b", "c", "d", "e"},
+		Values: map[string]map[string]string{
+			"a": {"Amount": "10.00"},
+			"b": {"Amount": "20.00"},
+			"c": {"Amount": "30.00"},
+			"d": {"Amount": ""},
+			"e": {"Amount": ""},
+		},
+	}
+	sut := evaluation.NewExtractionEvaluator(truth, evaluation.Matcher{Mode: evaluation.MatchNormalised})
+
+	require.NoError(t, sut.WriteResult("a", anExtractionResult(map[string]string{"Amount": "10.00"})))
+	require.NoError(t, sut.WriteResult("b", anExtractionResult(map[string]string{"Amount": "99.00"})))
+	require.NoError(t, sut.WriteResult("c", anExtractionResult(map[string]string{"Amount": ""})))
+	require.NoError(t, sut.WriteResult("d", anExtractionResult(map[string]string{"Amount": "1.00"})))
+	require.NoError(t, sut.WriteResult("e", anExtractionResult(nil)))
+
+	report := sut.Report(10)
+
+	assert.Equal(t, 5, report.Documents)
+	require.Len(t, report.Fields, 1)
+	assert.Equal(t, evaluation.FieldMetrics{
+		Field:      "Amount",
+		Documents:  5,
+		Correct:    1,
+		Incorrect:  1,
+		Missed:     1,
+		Spurious:   1,
+		Rejected:   2,
+		Precision:  1.0 / 3,
+		Recall:     1.0 / 3,
+		RejectRate: 0.4,
+	}, report.Fields[0])
+}
+
+func TestExtractionEvaluator_Report_Lists_Documents_With_Most_Errors_First(t *testing.T) {
+	truth := &evaluation.GroundTruth{
+		Fields: []string{"Amount", "Date"},
+		Files:  []string{"a", "b", "c"},
+		Values: map[string]map[string]string{
+			"a": {"Amount": "1", "Date": "2019-01-01"},
+			"b": {"Amount": "1", "Date": "2019-01-01"},
+			"c": {"Amount": "1", "Date": "2019-01-01"},
+		},
+	}
+	sut := evaluation.NewExtractionEvaluator(truth, evaluation.Matcher{Mode: evaluation.MatchExact})
+
+	require.NoError(t, sut.WriteResult("a", anExtractionResult(map[string]string{"Amount": "2", "Date": "2019-01-01"})))
+	require.NoError(t, sut.WriteResult("b", anExtractionResult(nil)))
+	require.NoError(t, sut.WriteResult("c", anExtractionResult(map[string]string{"Amount": "1", "Date": "2019-01-01"})))
+
+	report := sut.Report(1)
+
+	assert.Equal(t, []evaluation.DocumentErrors{
+		{Filename: "b", Fields: []string{"Amount", "Date"}},
+	}, report.WorstDocuments)
+}
+
+func TestExtractionEvaluator_Matches_Result_Value(t *testing.T) {
+	truth := &evaluation.GroundTruth{
+		Fields: []string{"Amount"},
+		Values: map[string]map[string]string{"a": {"Amount": "12.5"}},
+	}
+	sut := evaluation.NewExtractionEvaluator(truth, evaluation.Matcher{Mode: evaluation.MatchExact})
+
+	require.NoError(t, sut.WriteResult("a", &results.ExtractionResult{
+		FieldResults: []results.FieldResult{
+			{FieldName: "Amount", Result: &results.InnerResult{Text: "£12.50", Value: 12.5}},
+		},
+	}))
+
+	assert.Equal(t, 1, sut.Report(10).Fields[0].Correct)
+}
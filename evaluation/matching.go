@@ -0,0 +1,100 @@
+package evaluation
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MatchMode determines how an extracted value is compared with the expected value.
+type MatchMode string
+
+const (
+	// MatchExact requires the values to be identical.
+	MatchExact MatchMode = "exact"
+	// MatchNormalised compares only the letters and digits of the values, ignoring case.
+	MatchNormalised MatchMode = "normalised"
+	// MatchFuzzy compares normalised values, allowing for a proportion of differences.
+	MatchFuzzy MatchMode = "fuzzy"
+)
+
+// MatchModes are the names of the available MatchModes.
+var MatchModes = []string{string(MatchExact), string(MatchNormalised), string(MatchFuzzy)}
+
+// Matcher compares extracted values with expected values.
+type Matcher struct {
+	Mode MatchMode
+	// FuzzyThreshold is the minimum similarity (between 0 and 1) of values which
+	// match, when using MatchFuzzy.
+	FuzzyThreshold float64
+}
+
+// Matches returns true if the actual value matches the expected one.
+func (m Matcher) Matches(expected, actual string) bool {
+	switch m.Mode {
+	case MatchExact:
+		return expected == actual
+	case MatchFuzzy:
+		return similarity(normalise(expected), normalise(actual)) >= m.FuzzyThreshold
+	default:
+		return normalise(expected) == normalise(actual)
+	}
+}
+
+func normalise(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, value)
+}
+
+// similarity returns 1 for identical strings, falling towards 0 as the edit distance
+// between them increases.
+func similarity(a, b string) float64 {
+	aRunes, bRunes := []rune(a), []rune(b)
+
+	longest := len(aRunes)
+	if len(bRunes) > longest {
+		longest = len(bRunes)
+	}
+	if longest == 0 {
+		return 1
+	}
+
+	return 1 - float64(editDistance(aRunes, bRunes))/float64(longest)
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minOf(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func minOf(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,31 @@
+package evaluation_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/waives/surf/evaluation"
+	"testing"
+)
+
+func TestMatcher_Matches(t *testing.T) {
+	fixtures := []struct {
+		mode     evaluation.MatchMode
+		expected string
+		actual   string
+		matches  bool
+	}{
+		{evaluation.MatchExact, "ABC 123", "ABC 123", true},
+		{evaluation.MatchExact, "ABC 123", "abc 123", false},
+		{evaluation.MatchNormalised, "ABC-123", "abc 123", true},
+		{evaluation.MatchNormalised, "£1,234.50", "1234.50", true},
+		{evaluation.MatchNormalised, "ABC 123", "ABC 124", false},
+		{evaluation.MatchFuzzy, "Acme Limited", "Acme Limted", true},
+		{evaluation.MatchFuzzy, "Acme Limited", "Widgets Inc", false},
+	}
+
+	for _, fixture := range fixtures {
+		matcher := evaluation.Matcher{Mode: fixture.mode, FuzzyThreshold: 0.8}
+
+		assert.Equal(t, fixture.matches, matcher.Matches(fixture.expected, fixture.actual),
+			"%s: %s / %s", fixture.mode, fixture.expected, fixture.actual)
+	}
+}
@@ -0,0 +1,72 @@
+package evaluation
+
+import (
+	"encoding/csv"
+	"github.com/pkg/errors"
+	"io"
+	"strings"
+)
+
+// GroundTruth holds the expected field values of a set of documents.
+type GroundTruth struct {
+	// Fields are the names of the fields with expected values, in the order in which
+	// they were read.
+	Fields []string
+	// Files are the files with expected values, in the order in which they were read.
+	Files []string
+	// Values holds the expected value of each field, keyed by file then field name. An
+	// empty value indicates that no result is expected for the field.
+	Values map[string]map[string]string
+}
+
+// ReadGroundTruth reads ground truth from csv. The first row is a header, naming the
+// fields in the columns after the first. Each subsequent row holds a filename followed
+// by the expected values of the fields in that file.
+func ReadGroundTruth(reader io.Reader) (*GroundTruth, error) {
+	csvReader := csv.NewReader(reader)
+
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return nil, errors.New("the ground truth is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(header) < 2 {
+		return nil, errors.New("the ground truth must have a column of filenames, followed by " +
+			"a column for each field")
+	}
+
+	truth := &GroundTruth{
+		Values: map[string]map[string]string{},
+	}
+	for _, field := range header[1:] {
+		truth.Fields = append(truth.Fields, strings.TrimSpace(field))
+	}
+
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		filename := strings.TrimSpace(record[0])
+		if _, exists := truth.Values[filename]; exists {
+			return nil, errors.Errorf("the ground truth for '%s' is specified more than once", filename)
+		}
+
+		values := map[string]string{}
+		for i, field := range truth.Fields {
+			values[field] = record[i+1]
+		}
+
+		truth.Files = append(truth.Files, filename)
+		truth.Values[filename] = values
+	}
+
+	return truth, nil
+}
@@ -0,0 +1,39 @@
+package evaluation_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/waives/surf/evaluation"
+	"strings"
+	"testing"
+)
+
+func TestReadGroundTruth_Reads_Expected_Values_By_File_And_Field(t *testing.T) {
+	truth, err := evaluation.ReadGroundTruth(strings.NewReader(
+		"file,Amount, Date\n" +
+			"a.pdf,12.50,2019-01-01\n" +
+			"b.pdf,,2019-02-01\n"))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Amount", "Date"}, truth.Fields)
+	assert.Equal(t, []string{"a.pdf", "b.pdf"}, truth.Files)
+	assert.Equal(t, map[string]map[string]string{
+		"a.pdf": {"Amount": "12.50", "Date": "2019-01-01"},
+		"b.pdf": {"Amount": "", "Date": "2019-02-01"},
+	}, truth.Values)
+}
+
+func TestReadGroundTruth_Returns_Error_For_Invalid_Csv(t *testing.T) {
+	fixtures := map[string]string{
+		"empty":           "",
+		"no fields":       "file\na.pdf\n",
+		"duplicate file":  "file,Amount\na.pdf,1\na.pdf,2\n",
+		"missing columns": "file,Amount,Date\na.pdf,1\n",
+	}
+
+	for description, csv := range fixtures {
+		_, err := evaluation.ReadGroundTruth(strings.NewReader(csv))
+
+		assert.Error(t, err, description)
+	}
+}
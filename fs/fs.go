@@ -31,13 +31,3 @@ func CreateDirectoryIfNotExists(dir string, permissions os.FileMode) error {
 	}
 	return nil
 }
-
-// OpenForWriting is a convenience function that wraps os.Create,
-// but which returns os.Stdout if the provided filename is "-" or the empty string.
-func OpenForWriting(filename string) (*os.File, error) {
-	if filename == "-" || filename == "" {
-		return os.Stdout, nil
-	}
-
-	return os.Create(filename)
-}
@@ -0,0 +1,71 @@
+package fs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// LabelledFile is a file within a labelled directory tree: one whose top-level
+// subdirectories are named after the label (e.g. the document type) of the files
+// within them.
+type LabelledFile struct {
+	Label string
+	Path  string
+}
+
+// ReadLabelledTree returns all the files within the subdirectories of dir, at any
+// depth, labelled with the name of the top-level subdirectory containing them. Files
+// directly within dir, and hidden files and directories, are ignored. The files are
+// returned sorted by label, then path.
+func ReadLabelledTree(dir string) ([]LabelledFile, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []LabelledFile
+	for _, entry := range entries {
+		if !entry.IsDir() || isHidden(entry.Name()) {
+			continue
+		}
+
+		label := entry.Name()
+		err = filepath.Walk(filepath.Join(dir, label), func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if isHidden(info.Name()) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			if info.Mode().IsRegular() {
+				files = append(files, LabelledFile{Label: label, Path: path})
+			}
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		if files[i].Label != files[j].Label {
+			return files[i].Label < files[j].Label
+		}
+		return files[i].Path < files[j].Path
+	})
+
+	return files, nil
+}
+
+func isHidden(name string) bool {
+	return strings.HasPrefix(name, ".")
+}
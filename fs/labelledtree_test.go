@@ -0,0 +1,27 @@
+package fs_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/waives/surf/fs"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadLabelledTree_Labels_Files_With_Top_Level_Directory(t *testing.T) {
+	root := filepath.Join("testdata", "labelled")
+
+	files, err := fs.ReadLabelledTree(root)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []fs.LabelledFile{
+		{Label: "invoice", Path: filepath.Join(root, "invoice", "2019", "b.pdf")},
+		{Label: "invoice", Path: filepath.Join(root, "invoice", "a.pdf")},
+		{Label: "receipt", Path: filepath.Join(root, "receipt", "c.tif")},
+	}, files)
+}
+
+func TestReadLabelledTree_Returns_Error_For_Missing_Directory(t *testing.T) {
+	_, err := fs.ReadLabelledTree(filepath.Join("testdata", "missing"))
+
+	assert.Error(t, err)
+}
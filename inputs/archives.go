@@ -0,0 +1,311 @@
+package inputs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+type archiveFormat int
+
+const (
+	notAnArchive archiveFormat = iota
+	zipArchive
+	tarArchive
+	gzippedTarArchive
+)
+
+func archiveFormatOf(filename string) archiveFormat {
+	filename = strings.ToLower(filename)
+
+	switch {
+	case strings.HasSuffix(filename, ".zip"):
+		return zipArchive
+	case strings.HasSuffix(filename, ".tar"):
+		return tarArchive
+	case strings.HasSuffix(filename, ".tar.gz"), strings.HasSuffix(filename, ".tgz"):
+		return gzippedTarArchive
+	default:
+		return notAnArchive
+	}
+}
+
+// entryName returns the input name for an archive entry, which is its path within
+// the archive. Entries which would be written outside of the current directory are
+// rejected.
+func entryName(archivePath, entryPath string) (string, error) {
+	cleaned := path.Clean(strings.Replace(entryPath, `\`, "/", -1))
+
+	if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") ||
+		filepath.VolumeName(cleaned) != "" {
+		return "", errors.Errorf("The archive %s contains an entry with an unsafe path: %s",
+			archivePath, entryPath)
+	}
+
+	return filepath.FromSlash(cleaned), nil
+}
+
+// addZipEntries adds each file in the zip archive. The archive is re-opened
+// whenever one of its entries is opened.
+func (i *Inputs) addZipEntries(archivePath string) error {
+	archive, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return errors.Wrapf(err, "Could not read the archive %s", archivePath)
+	}
+	defer archive.Close()
+
+	for index, file := range archive.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		name, err := entryName(archivePath, file.Name)
+		if err != nil {
+			return err
+		}
+
+		index := index // <- copy
+		err = i.add(name, func() (io.ReadCloser, error) {
+			return openZipEntry(archivePath, index)
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func openZipEntry(archivePath string, index int) (io.ReadCloser, error) {
+	archive, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := archive.File[index].Open()
+	if err != nil {
+		archive.Close()
+		return nil, err
+	}
+
+	return &entryReadCloser{Reader: entry, closers: []io.Closer{entry, archive}}, nil
+}
+
+// addTarEntries adds each regular file in the (optionally gzipped) tar archive. As tar
+// archives can only be read sequentially, the archive is read once here to find where
+// the contents of each entry are, so that they can be read directly when it is opened.
+func (i *Inputs) addTarEntries(archivePath string) error {
+	archive, err := openTar(archivePath)
+	if err != nil {
+		return errors.Wrapf(err, "Could not read the archive %s", archivePath)
+	}
+	defer archive.Close()
+
+	contents := &tarContents{archivePath: archivePath}
+	i.tarArchives = append(i.tarArchives, contents)
+
+	for {
+		header, err := archive.reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "Could not read the archive %s", archivePath)
+		}
+
+		if !header.FileInfo().Mode().IsRegular() {
+			continue
+		}
+
+		name, err := entryName(archivePath, header.Name)
+		if err != nil {
+			return err
+		}
+
+		// the header has just been read, so the entry's contents start here
+		entry := tarEntry{offset: archive.offset.count, size: header.Size}
+		err = i.add(name, func() (io.ReadCloser, error) {
+			return contents.open(entry)
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// tarEntry is the location of an entry's contents within an (uncompressed) tar archive.
+type tarEntry struct {
+	offset int64
+	size   int64
+}
+
+// tarContents reads the entries of a tar archive. A gzipped archive is decompressed to
+// a temporary file (once, when the first of its entries is opened), from which its
+// entries are read.
+type tarContents struct {
+	archivePath string
+
+	mutex        sync.Mutex
+	tempFilename string
+}
+
+func (t *tarContents) open(entry tarEntry) (io.ReadCloser, error) {
+	filename, err := t.uncompressedFilename()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entryReadCloser{
+		Reader:  io.NewSectionReader(file, entry.offset, entry.size),
+		closers: []io.Closer{file},
+	}, nil
+}
+
+// uncompressedFilename returns the name of the archive or, if it is gzipped, the
+// temporary file it is decompressed to.
+func (t *tarContents) uncompressedFilename() (string, error) {
+	if archiveFormatOf(t.archivePath) != gzippedTarArchive {
+		return t.archivePath, nil
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.tempFilename == "" {
+		tempFilename, err := decompressToTempFile(t.archivePath)
+		if err != nil {
+			return "", errors.Wrapf(err, "Could not read the archive %s", t.archivePath)
+		}
+		t.tempFilename = tempFilename
+	}
+
+	return t.tempFilename, nil
+}
+
+// removeTempFile removes the temporary file the archive was decompressed to, if any.
+func (t *tarContents) removeTempFile() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.tempFilename == "" {
+		return nil
+	}
+
+	err := os.Remove(t.tempFilename)
+	t.tempFilename = ""
+	return err
+}
+
+func decompressToTempFile(archivePath string) (string, error) {
+	archive, err := os.Open(archivePath)
+	if err != nil {
+		return "", err
+	}
+	defer archive.Close()
+
+	gzipReader, err := gzip.NewReader(archive)
+	if err != nil {
+		return "", err
+	}
+	defer gzipReader.Close()
+
+	temp, err := ioutil.TempFile("", "surf-*.tar")
+	if err != nil {
+		return "", err
+	}
+
+	_, err = io.Copy(temp, gzipReader)
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(temp.Name())
+		return "", err
+	}
+
+	return temp.Name(), nil
+}
+
+type tarArchiveReader struct {
+	reader *tar.Reader
+	// offset counts the (uncompressed) bytes read from the archive
+	offset  *countingReader
+	closers []io.Closer
+}
+
+func openTar(archivePath string) (*tarArchiveReader, error) {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := &tarArchiveReader{closers: []io.Closer{file}}
+	var contents io.Reader = file
+
+	if archiveFormatOf(archivePath) == gzippedTarArchive {
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		archive.closers = append([]io.Closer{gzipReader}, archive.closers...)
+		contents = gzipReader
+	}
+
+	archive.offset = &countingReader{reader: contents}
+	archive.reader = tar.NewReader(archive.offset)
+	return archive, nil
+}
+
+// countingReader counts the bytes read from the reader. (It does not implement io.Seeker,
+// so that a tar.Reader reads past the contents of each entry rather than seeking.)
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.count += int64(n)
+	return n, err
+}
+
+func (a *tarArchiveReader) Close() error {
+	return closeAll(a.closers)
+}
+
+// entryReadCloser reads an archive entry, closing the archive along with it.
+type entryReadCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (e *entryReadCloser) Close() error {
+	return closeAll(e.closers)
+}
+
+func closeAll(closers []io.Closer) error {
+	var firstErr error
+	for _, closer := range closers {
+		if err := closer.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
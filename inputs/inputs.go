@@ -0,0 +1,239 @@
+// Package inputs resolves the files processed by commands. As well as local files
+// matching glob patterns, an input may be a document read from stdin ("-"), a
+// document downloaded from an http(s) URL, or an entry of a zip or tar archive.
+package inputs
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"github.com/mattn/go-zglob"
+	"github.com/pkg/errors"
+	"github.com/waives/surf/net"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Stdin is the pattern (and file list name) which refers to stdin.
+const Stdin = "-"
+
+// StdinName is the name given to the document read from stdin.
+const StdinName = "stdin"
+
+type opener func() (io.ReadCloser, error)
+
+// Inputs are the files processed by a command, each identified by the name used
+// for its results. For local files this is their path; for archive entries it is
+// their path within the archive.
+type Inputs struct {
+	stdin     io.Reader
+	stdinUsed bool
+	names     []string
+	openers   map[string]opener
+
+	downloadCtx    context.Context
+	downloadClient net.HttpDoer
+
+	tarArchives []*tarContents
+}
+
+// New constructs an empty set of Inputs, which reads from stdin when required.
+func New(stdin io.Reader) *Inputs {
+	return &Inputs{
+		stdin:          stdin,
+		openers:        map[string]opener{},
+		downloadCtx:    context.Background(),
+		downloadClient: http.DefaultClient,
+	}
+}
+
+// WithDownloads configures the Inputs to download documents from URLs with the provided
+// client (e.g. to use the configured proxy and certificates), cancelling the downloads
+// when ctx is done.
+func (i *Inputs) WithDownloads(ctx context.Context, client net.HttpDoer) *Inputs {
+	i.downloadCtx = ctx
+	i.downloadClient = client
+	return i
+}
+
+// Names returns the names of the inputs, in the order they were added.
+func (i *Inputs) Names() []string {
+	return i.names
+}
+
+// Open opens the named input.
+func (i *Inputs) Open(name string) (io.ReadCloser, error) {
+	open, ok := i.openers[name]
+	if !ok {
+		return nil, errors.Errorf("There is no input named '%s'", name)
+	}
+
+	return open()
+}
+
+// Close removes any temporary files used to read the inputs.
+func (i *Inputs) Close() error {
+	var firstErr error
+	for _, archive := range i.tarArchives {
+		if err := archive.removeTempFile(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// AddPatterns adds the inputs for each of the patterns. A pattern may be a glob
+// pattern, "-" to read a document from stdin, or an http(s) URL.
+func (i *Inputs) AddPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		var err error
+
+		switch {
+		case pattern == Stdin:
+			err = i.addStdin()
+		case isURL(pattern):
+			err = i.addURL(pattern)
+		default:
+			err = i.addGlob(pattern)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddList adds the files listed in the named file (or stdin, if the name is "-"),
+// one per line or, if nullSeparated is set, separated by NUL characters. The
+// listed paths are not treated as glob patterns.
+func (i *Inputs) AddList(listName string, nullSeparated bool) error {
+	var list io.Reader
+
+	if listName == Stdin {
+		if err := i.useStdin(); err != nil {
+			return err
+		}
+		list = i.stdin
+	} else {
+		file, err := os.Open(listName)
+		if err != nil {
+			return errors.Wrapf(err, "Could not read the file list %s", listName)
+		}
+		defer file.Close()
+		list = file
+	}
+
+	separator := byte('\n')
+	if nullSeparated {
+		separator = 0
+	}
+
+	scanner := bufio.NewScanner(list)
+	scanner.Buffer(nil, 1024*1024)
+	scanner.Split(splitAt(separator))
+
+	for scanner.Scan() {
+		path := strings.TrimRight(scanner.Text(), "\r")
+		if !nullSeparated {
+			path = strings.TrimSpace(path)
+		}
+
+		if path == "" {
+			continue
+		}
+
+		if err := i.addPath(path); err != nil {
+			return err
+		}
+	}
+
+	return errors.Wrapf(scanner.Err(), "Could not read the file list %s", listName)
+}
+
+func (i *Inputs) add(name string, open opener) error {
+	if _, exists := i.openers[name]; exists {
+		return errors.Errorf("More than one input is named '%s'", name)
+	}
+
+	i.names = append(i.names, name)
+	i.openers[name] = open
+	return nil
+}
+
+func (i *Inputs) useStdin() error {
+	if i.stdinUsed {
+		return errors.New("stdin ('-') can only be used once")
+	}
+
+	i.stdinUsed = true
+	return nil
+}
+
+// addStdin adds the document read from stdin. It is read in full, as stdin can only
+// be read once.
+func (i *Inputs) addStdin() error {
+	if err := i.useStdin(); err != nil {
+		return err
+	}
+
+	contents, err := ioutil.ReadAll(i.stdin)
+	if err != nil {
+		return errors.Wrap(err, "Could not read from stdin")
+	}
+
+	return i.add(StdinName, func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(contents)), nil
+	})
+}
+
+func (i *Inputs) addGlob(pattern string) error {
+	paths, err := zglob.Glob(pattern)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		if err := i.addPath(path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addPath adds a local file, or the entries of a local archive.
+func (i *Inputs) addPath(path string) error {
+	switch archiveFormatOf(path) {
+	case zipArchive:
+		return i.addZipEntries(path)
+	case tarArchive, gzippedTarArchive:
+		return i.addTarEntries(path)
+	default:
+		return i.add(path, func() (io.ReadCloser, error) {
+			return os.Open(path)
+		})
+	}
+}
+
+func splitAt(separator byte) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		if atEOF && len(data) == 0 {
+			return 0, nil, nil
+		}
+
+		if i := bytes.IndexByte(data, separator); i >= 0 {
+			return i + 1, data[:i], nil
+		}
+
+		if atEOF {
+			return len(data), data, nil
+		}
+
+		return 0, nil, nil
+	}
+}
@@ -0,0 +1,314 @@
+package inputs_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/inputs"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type InputsSuite struct {
+	suite.Suite
+	dir   string
+	stdin *bytes.Buffer
+	sut   *inputs.Inputs
+}
+
+func (suite *InputsSuite) SetupTest() {
+	var err error
+	suite.dir, err = ioutil.TempDir("", "surf-inputs")
+	suite.Require().NoError(err)
+
+	suite.stdin = &bytes.Buffer{}
+	suite.sut = inputs.New(suite.stdin)
+}
+
+func (suite *InputsSuite) TearDownTest() {
+	os.RemoveAll(suite.dir)
+}
+
+func TestInputsSuiteRunner(t *testing.T) {
+	suite.Run(t, new(InputsSuite))
+}
+
+func (suite *InputsSuite) path(name string) string {
+	return filepath.Join(suite.dir, name)
+}
+
+func (suite *InputsSuite) writeFile(name, contents string) string {
+	path := suite.path(name)
+	suite.Require().NoError(ioutil.WriteFile(path, []byte(contents), 0666))
+	return path
+}
+
+func (suite *InputsSuite) assertContents(name, expected string) {
+	readCloser, err := suite.sut.Open(name)
+	suite.Require().NoError(err)
+	defer readCloser.Close()
+
+	contents, err := ioutil.ReadAll(readCloser)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(expected, string(contents))
+}
+
+func (suite *InputsSuite) TestAddPatterns_Adds_Matching_Files() {
+	first := suite.writeFile("1.pdf", "first")
+	second := suite.writeFile("2.pdf", "second")
+	suite.writeFile("3.txt", "third")
+
+	err := suite.sut.AddPatterns([]string{suite.path("*.pdf")})
+
+	suite.Require().NoError(err)
+	suite.Assert().Equal([]string{first, second}, suite.sut.Names())
+	suite.assertContents(second, "second")
+}
+
+func (suite *InputsSuite) TestAddPatterns_Reads_Document_From_Stdin() {
+	suite.stdin.WriteString("from stdin")
+
+	err := suite.sut.AddPatterns([]string{inputs.Stdin})
+
+	suite.Require().NoError(err)
+	suite.Assert().Equal([]string{inputs.StdinName}, suite.sut.Names())
+	suite.assertContents(inputs.StdinName, "from stdin")
+	suite.assertContents(inputs.StdinName, "from stdin")
+}
+
+func (suite *InputsSuite) TestAddPatterns_Rejects_Stdin_Used_Twice() {
+	err := suite.sut.AddPatterns([]string{inputs.Stdin, inputs.Stdin})
+
+	suite.Assert().Error(err)
+}
+
+func (suite *InputsSuite) TestAddPatterns_Downloads_URLs() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/docs/invoice.pdf" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, "downloaded")
+	}))
+	defer server.Close()
+
+	err := suite.sut.AddPatterns([]string{server.URL + "/docs/invoice.pdf", server.URL + "/missing.pdf"})
+
+	suite.Require().NoError(err)
+	suite.Assert().Equal([]string{"invoice.pdf", "missing.pdf"}, suite.sut.Names())
+	suite.assertContents("invoice.pdf", "downloaded")
+	_, err = suite.sut.Open("missing.pdf")
+	suite.Assert().Error(err)
+}
+
+func (suite *InputsSuite) TestAddPatterns_Downloads_URLs_With_The_Provided_Client() {
+	client := &recordingDoer{}
+	suite.sut.WithDownloads(context.Background(), client)
+
+	err := suite.sut.AddPatterns([]string{"https://example.com/docs/invoice.pdf"})
+
+	suite.Require().NoError(err)
+	suite.assertContents("invoice.pdf", "downloaded")
+	suite.Require().Len(client.requests, 1)
+	suite.Assert().Equal("https://example.com/docs/invoice.pdf", client.requests[0].URL.String())
+}
+
+func (suite *InputsSuite) TestOpen_Cancels_Download_When_Context_Is_Done() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "downloaded")
+	}))
+	defer server.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	suite.sut.WithDownloads(ctx, http.DefaultClient)
+
+	err := suite.sut.AddPatterns([]string{server.URL + "/invoice.pdf"})
+	suite.Require().NoError(err)
+	_, err = suite.sut.Open("invoice.pdf")
+
+	suite.Assert().Error(err)
+}
+
+func (suite *InputsSuite) TestAddPatterns_Expands_Zip_Archives() {
+	archivePath := suite.path("documents.zip")
+	suite.writeZip(archivePath, map[string]string{
+		"invoices/1.pdf": "first",
+		"2.pdf":          "second",
+	})
+
+	err := suite.sut.AddPatterns([]string{archivePath})
+
+	suite.Require().NoError(err)
+	suite.Assert().ElementsMatch([]string{filepath.FromSlash("invoices/1.pdf"), "2.pdf"},
+		suite.sut.Names())
+	suite.assertContents(filepath.FromSlash("invoices/1.pdf"), "first")
+	suite.assertContents("2.pdf", "second")
+}
+
+func (suite *InputsSuite) TestAddPatterns_Expands_Tar_Archives() {
+	for _, archiveName := range []string{"documents.tar", "documents.tar.gz", "documents.tgz"} {
+		suite.sut = inputs.New(suite.stdin)
+		archivePath := suite.path(archiveName)
+		suite.writeTar(archivePath, map[string]string{
+			"invoices/1.pdf": "first",
+			"2.pdf":          "second",
+		})
+
+		err := suite.sut.AddPatterns([]string{archivePath})
+
+		suite.Require().NoError(err, archiveName)
+		suite.Assert().Len(suite.sut.Names(), 2, archiveName)
+		suite.assertContents(filepath.FromSlash("invoices/1.pdf"), "first")
+		suite.assertContents("2.pdf", "second")
+	}
+}
+
+func (suite *InputsSuite) TestOpen_Reads_Gzipped_Tar_Entries_In_Any_Order() {
+	tempDir := suite.path("tmp")
+	suite.Require().NoError(os.Mkdir(tempDir, 0777))
+	defer os.Setenv("TMPDIR", os.Getenv("TMPDIR"))
+	os.Setenv("TMPDIR", tempDir)
+
+	archivePath := suite.path("documents.tgz")
+	entries := map[string]string{}
+	for n := 0; n < 20; n++ {
+		entries[fmt.Sprintf("%02d.pdf", n)] = strings.Repeat(fmt.Sprint(n), 1000)
+	}
+	suite.writeTar(archivePath, entries)
+
+	err := suite.sut.AddPatterns([]string{archivePath})
+
+	suite.Require().NoError(err)
+	names := suite.sut.Names()
+	suite.Require().Len(names, len(entries))
+	for n := len(names) - 1; n >= 0; n-- {
+		suite.assertContents(names[n], entries[names[n]])
+	}
+	suite.Require().NoError(suite.sut.Close())
+	tempFiles, _ := ioutil.ReadDir(tempDir)
+	suite.Assert().Empty(tempFiles)
+}
+
+func (suite *InputsSuite) TestAddPatterns_Rejects_Unsafe_Archive_Entries() {
+	for _, entryPath := range []string{"../escape.pdf", "/etc/escape.pdf", "a/../../escape.pdf"} {
+		suite.sut = inputs.New(suite.stdin)
+		archivePath := suite.path("unsafe.zip")
+		suite.writeZip(archivePath, map[string]string{entryPath: "contents"})
+
+		err := suite.sut.AddPatterns([]string{archivePath})
+
+		suite.Assert().Error(err, entryPath)
+	}
+}
+
+func (suite *InputsSuite) TestAddPatterns_Rejects_Duplicate_Names() {
+	suite.writeZip(suite.path("1.zip"), map[string]string{"a.pdf": "first"})
+	suite.writeZip(suite.path("2.zip"), map[string]string{"a.pdf": "second"})
+
+	err := suite.sut.AddPatterns([]string{suite.path("*.zip")})
+
+	suite.Assert().EqualError(err, "More than one input is named 'a.pdf'")
+}
+
+func (suite *InputsSuite) TestAddList_Adds_Listed_Files() {
+	first := suite.writeFile("1 [draft].pdf", "first")
+	second := suite.writeFile("2.pdf", "second")
+	list := suite.writeFile("list.txt", first+"\n\n"+second+"\r\n")
+
+	err := suite.sut.AddList(list, false)
+
+	suite.Require().NoError(err)
+	suite.Assert().Equal([]string{first, second}, suite.sut.Names())
+}
+
+func (suite *InputsSuite) TestAddList_Reads_Null_Separated_List_From_Stdin() {
+	first := suite.writeFile("first\nline.pdf", "first")
+	second := suite.writeFile("2.pdf", "second")
+	suite.stdin.WriteString(first + "\x00" + second + "\x00")
+
+	err := suite.sut.AddList(inputs.Stdin, true)
+
+	suite.Require().NoError(err)
+	suite.Assert().Equal([]string{first, second}, suite.sut.Names())
+	suite.Assert().Error(suite.sut.AddPatterns([]string{inputs.Stdin}))
+}
+
+func (suite *InputsSuite) TestOpen_Returns_Error_For_Unknown_Input() {
+	_, err := suite.sut.Open("unknown.pdf")
+
+	suite.Assert().Error(err)
+}
+
+func (suite *InputsSuite) writeZip(archivePath string, entries map[string]string) {
+	file, err := os.Create(archivePath)
+	suite.Require().NoError(err)
+	defer file.Close()
+
+	archive := zip.NewWriter(file)
+	for name, contents := range entries {
+		entry, err := archive.Create(name)
+		suite.Require().NoError(err)
+		_, err = io.WriteString(entry, contents)
+		suite.Require().NoError(err)
+	}
+	suite.Require().NoError(archive.Close())
+}
+
+func (suite *InputsSuite) writeTar(archivePath string, entries map[string]string) {
+	file, err := os.Create(archivePath)
+	suite.Require().NoError(err)
+	defer file.Close()
+
+	var writer io.Writer = file
+	if !strings.HasSuffix(archivePath, ".tar") {
+		gzipWriter := gzip.NewWriter(file)
+		defer gzipWriter.Close()
+		writer = gzipWriter
+	}
+
+	archive := tar.NewWriter(writer)
+	suite.Require().NoError(archive.WriteHeader(&tar.Header{
+		Name: "invoices/", Typeflag: tar.TypeDir, Mode: 0777,
+	}))
+	for name, contents := range entries {
+		suite.Require().NoError(archive.WriteHeader(&tar.Header{
+			Name: name, Typeflag: tar.TypeReg, Mode: 0666, Size: int64(len(contents)),
+		}))
+		_, err = io.WriteString(archive, contents)
+		suite.Require().NoError(err)
+	}
+	suite.Require().NoError(archive.Close())
+}
+
+func TestNew_Has_No_Inputs(t *testing.T) {
+	sut := inputs.New(os.Stdin)
+
+	assert.Empty(t, sut.Names())
+	_, err := sut.Open("anything")
+	require.Error(t, err)
+}
+
+// recordingDoer records the requests it is sent, and responds to each with a document.
+type recordingDoer struct {
+	requests []*http.Request
+}
+
+func (d *recordingDoer) Do(request *http.Request) (*http.Response, error) {
+	d.requests = append(d.requests, request)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader("downloaded")),
+	}, nil
+}
@@ -0,0 +1,52 @@
+package inputs
+
+import (
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+func isURL(pattern string) bool {
+	lower := strings.ToLower(pattern)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// addURL adds the document at the URL, which is downloaded when it is opened. It
+// is named after the last element of the URL's path.
+func (i *Inputs) addURL(rawURL string) error {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.Wrapf(err, "'%s' is not a valid URL", rawURL)
+	}
+
+	name := path.Base(parsedURL.Path)
+	if name == "/" || name == "." {
+		name = parsedURL.Hostname()
+	}
+
+	return i.add(name, func() (io.ReadCloser, error) {
+		return i.download(rawURL)
+	})
+}
+
+func (i *Inputs) download(rawURL string) (io.ReadCloser, error) {
+	request, err := http.NewRequest("GET", rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := i.downloadClient.Do(request.WithContext(i.downloadCtx))
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		return nil, errors.Errorf("Could not download %s: %s", rawURL, response.Status)
+	}
+
+	return response.Body, nil
+}
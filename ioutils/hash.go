@@ -0,0 +1,38 @@
+package ioutils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+)
+
+// Sha256 calculates the hex-encoded SHA256 hash of the contents of the provided reader.
+// Since hashing consumes the reader, a reader positioned at the start of the same
+// contents is also returned.
+//
+// If the reader is an io.ReadSeeker (e.g. an *os.File) it is rewound and returned,
+// otherwise its contents are buffered in memory.
+func Sha256(reader io.Reader) (string, io.Reader, error) {
+	hash := sha256.New()
+
+	if seeker, ok := reader.(io.ReadSeeker); ok {
+		if _, err := io.Copy(hash, seeker); err != nil {
+			return "", nil, err
+		}
+
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return "", nil, err
+		}
+
+		return hex.EncodeToString(hash.Sum(nil)), seeker, nil
+	}
+
+	buf, err := DrainClose(reader)
+	if err != nil {
+		return "", nil, err
+	}
+
+	_, _ = hash.Write(buf.Bytes())
+
+	return hex.EncodeToString(hash.Sum(nil)), buf, nil
+}
@@ -0,0 +1,30 @@
+package ioutils
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+func TestSha256(t *testing.T) {
+	contents := []byte("some data")
+
+	fixtures := []io.Reader{
+		bytes.NewBuffer(contents),
+		bytes.NewReader(contents),
+		ioutil.NopCloser(bytes.NewBuffer(contents)),
+	}
+
+	for _, fixture := range fixtures {
+		hash, rewound, err := Sha256(fixture)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1307990e6ba5ca145eb35e99182a9bec46531bc54ddf656a602c780fa0240dee", hash)
+
+		remaining, err := ioutil.ReadAll(rewound)
+		assert.NoError(t, err)
+		assert.Equal(t, contents, remaining)
+	}
+}
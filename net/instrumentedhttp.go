@@ -0,0 +1,58 @@
+package net
+
+import (
+	"github.com/waives/surf/telemetry"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var _ HttpDoer = (*InstrumentedHttpClient)(nil)
+
+// InstrumentedHttpClient is an HttpDoer decorator that records the time taken to receive
+// the response to each request, and whether it was a retry (see RetryAttempt), by the
+// operation it performs.
+type InstrumentedHttpClient struct {
+	wrapped   HttpDoer
+	recorder  *telemetry.Recorder
+	operation func(request *http.Request) string
+}
+
+// NewInstrumentedHttpClient constructs an InstrumentedHttpClient, which names the
+// operation each request performs with operation.
+func NewInstrumentedHttpClient(wrappedClient HttpDoer, recorder *telemetry.Recorder,
+	operation func(request *http.Request) string) *InstrumentedHttpClient {
+	return &InstrumentedHttpClient{
+		wrapped:   wrappedClient,
+		recorder:  recorder,
+		operation: operation,
+	}
+}
+
+func (h *InstrumentedHttpClient) Do(request *http.Request) (*http.Response, error) {
+	labels := telemetry.Labels{
+		"operation": h.operation(request),
+		"method":    request.Method,
+	}
+
+	if RetryAttempt(request.Context()) > 1 {
+		h.recorder.Add(telemetry.RequestRetries, labels, 1)
+	}
+
+	start := time.Now()
+	response, err := h.wrapped.Do(request)
+	duration := time.Since(start)
+
+	status := "error"
+	if err == nil && response != nil {
+		status = strconv.Itoa(response.StatusCode)
+	}
+
+	h.recorder.Observe(telemetry.RequestDuration, telemetry.Labels{
+		"operation": labels["operation"],
+		"method":    labels["method"],
+		"status":    status,
+	}, duration.Seconds())
+
+	return response, err
+}
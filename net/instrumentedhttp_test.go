@@ -0,0 +1,39 @@
+package net
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/waives/surf/net/mocks"
+	"github.com/waives/surf/telemetry"
+	"net/http"
+	"testing"
+)
+
+func TestInstrumentedHttpClient_Records_Request_Durations_And_Retries(t *testing.T) {
+	// Arrange
+	var (
+		wrappedDoer = mocks.HttpDoer{}
+		recorder    = telemetry.NewRecorder()
+		sut         = NewInstrumentedHttpClient(&wrappedDoer, recorder, func(*http.Request) string {
+			return "read"
+		})
+		request, _ = http.NewRequest("GET", "https://api.cloudhub360.com/documents/id/reads", nil)
+		retry      = request.WithContext(context.WithValue(request.Context(), retryAttemptKey{}, 2))
+		metrics    = bytes.Buffer{}
+	)
+	wrappedDoer.On("Do", mock.Anything).Return(&http.Response{StatusCode: 200}, nil)
+
+	// Act
+	_, _ = sut.Do(request)
+	_, _ = sut.Do(retry)
+
+	// Assert
+	require.NoError(t, recorder.WritePrometheus(&metrics))
+	assert.Contains(t, metrics.String(),
+		`surf_http_request_duration_seconds_count{method="GET",operation="read",status="200"} 2`)
+	assert.Contains(t, metrics.String(),
+		`surf_http_request_retries_total{method="GET",operation="read"} 1`)
+}
@@ -0,0 +1,149 @@
+package net
+
+import (
+	"context"
+	"github.com/waives/surf/telemetry"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var _ HttpDoer = (*RateLimitingHttpClient)(nil)
+
+// RateLimits are the limits a RateLimitingHttpClient applies to requests. A zero
+// value for either limit means that it is not limited.
+type RateLimits struct {
+	// MaxRequestsPerSecond is the average number of requests which may be sent each
+	// second. Short bursts of up to this many requests (or one request, if it is less
+	// than one) are allowed.
+	MaxRequestsPerSecond float64
+	// MaxConcurrency is the number of requests which may be in progress at once.
+	MaxConcurrency int
+}
+
+// RateLimitingHttpClient is an HttpDoer decorator that limits the rate and concurrency
+// of HTTP requests (with a token bucket and a semaphore, respectively). When a 429 or
+// 503 response has a Retry-After header, no further requests are sent until the
+// requested time has passed.
+type RateLimitingHttpClient struct {
+	wrapped  HttpDoer
+	limits   RateLimits
+	slots    chan struct{}
+	recorder *telemetry.Recorder
+
+	mu          sync.Mutex
+	tokens      float64
+	lastRefill  time.Time
+	pausedUntil time.Time
+}
+
+func NewRateLimitingHttpClient(wrappedClient HttpDoer, limits RateLimits) *RateLimitingHttpClient {
+	client := &RateLimitingHttpClient{
+		wrapped: wrappedClient,
+		limits:  limits,
+		tokens:  limits.burst(),
+	}
+
+	if limits.MaxConcurrency > 0 {
+		client.slots = make(chan struct{}, limits.MaxConcurrency)
+	}
+
+	return client
+}
+
+// WithRecorder configures the RateLimitingHttpClient to record how long each request
+// waits to be sent.
+func (h *RateLimitingHttpClient) WithRecorder(recorder *telemetry.Recorder) *RateLimitingHttpClient {
+	h.recorder = recorder
+	return h
+}
+
+func (l RateLimits) burst() float64 {
+	return math.Max(1, math.Floor(l.MaxRequestsPerSecond))
+}
+
+func (h *RateLimitingHttpClient) Do(request *http.Request) (*http.Response, error) {
+	ctx := request.Context()
+	start := time.Now()
+
+	if h.slots != nil {
+		select {
+		case h.slots <- struct{}{}:
+			// The slot is held until the response (not its body) has been received
+			defer func() { <-h.slots }()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if err := sleep(ctx, h.reserve()); err != nil {
+		return nil, err
+	}
+
+	h.recorder.Observe(telemetry.SlotWait, nil, time.Since(start).Seconds())
+
+	response, err := h.wrapped.Do(request)
+
+	if retryAfter, ok := RetryAfter(response); ok {
+		h.pauseFor(retryAfter)
+	}
+
+	return response, err
+}
+
+// reserve takes a token from the bucket (going into debt if it is empty), returning how
+// long to wait before sending the request.
+func (h *RateLimitingHttpClient) reserve() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	var delay time.Duration
+
+	if h.limits.MaxRequestsPerSecond > 0 {
+		if !h.lastRefill.IsZero() {
+			elapsed := now.Sub(h.lastRefill).Seconds()
+			h.tokens = math.Min(h.limits.burst(), h.tokens+elapsed*h.limits.MaxRequestsPerSecond)
+		}
+		h.lastRefill = now
+		h.tokens--
+
+		if h.tokens < 0 {
+			delay = time.Duration(-h.tokens / h.limits.MaxRequestsPerSecond * float64(time.Second))
+		}
+	}
+
+	if paused := h.pausedUntil.Sub(now); paused > delay {
+		delay = paused
+	}
+
+	return delay
+}
+
+func (h *RateLimitingHttpClient) pauseFor(delay time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if until := time.Now().Add(delay); until.After(h.pausedUntil) {
+		h.pausedUntil = until
+	}
+}
+
+// sleep waits for the delay, returning early (with the context's error) if the
+// context is cancelled.
+func sleep(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,155 @@
+package net_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/waives/surf/net"
+	"github.com/waives/surf/net/mocks"
+	"github.com/waives/surf/telemetry"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimitingHttpClient_Do_Returns_Values_From_Underlying(t *testing.T) {
+	// Arrange
+	expectedResp := &http.Response{StatusCode: 200}
+	mockHttpDoer := mocks.HttpDoer{}
+	mockHttpDoer.On("Do", mock.Anything).Return(expectedResp, nil)
+	sut := net.NewRateLimitingHttpClient(&mockHttpDoer, net.RateLimits{})
+	req, _ := http.NewRequest("GET", "https://api.cloudhub360.com/version", nil)
+
+	// Act
+	receivedResp, receivedErr := sut.Do(req)
+
+	// Assert
+	mockHttpDoer.AssertCalled(t, "Do", req)
+	assert.NoError(t, receivedErr)
+	assert.Equal(t, expectedResp, receivedResp)
+}
+
+func TestRateLimitingHttpClient_Do_Limits_Request_Rate(t *testing.T) {
+	// Arrange
+	mockHttpDoer := mocks.HttpDoer{}
+	mockHttpDoer.On("Do", mock.Anything).Return(&http.Response{StatusCode: 200}, nil)
+	sut := net.NewRateLimitingHttpClient(&mockHttpDoer, net.RateLimits{MaxRequestsPerSecond: 20})
+	req, _ := http.NewRequest("GET", "https://api.cloudhub360.com/version", nil)
+	start := time.Now()
+
+	// Act: a burst of 20 requests is allowed, then one every 50ms
+	for i := 0; i < 25; i++ {
+		_, err := sut.Do(req)
+		require.NoError(t, err)
+	}
+
+	// Assert
+	assert.True(t, time.Since(start) >= 200*time.Millisecond)
+}
+
+func TestRateLimitingHttpClient_Do_Limits_Concurrency(t *testing.T) {
+	// Arrange
+	var (
+		mu             sync.Mutex
+		inProgress     int
+		maxInProgress  int
+		maxConcurrency = 2
+		wg             sync.WaitGroup
+	)
+	mockHttpDoer := mocks.HttpDoer{}
+	mockHttpDoer.On("Do", mock.Anything).
+		Run(func(_ mock.Arguments) {
+			mu.Lock()
+			inProgress++
+			if inProgress > maxInProgress {
+				maxInProgress = inProgress
+			}
+			mu.Unlock()
+
+			time.Sleep(20 * time.Millisecond)
+
+			mu.Lock()
+			inProgress--
+			mu.Unlock()
+		}).
+		Return(&http.Response{StatusCode: 200}, nil)
+	sut := net.NewRateLimitingHttpClient(&mockHttpDoer, net.RateLimits{MaxConcurrency: maxConcurrency})
+
+	// Act
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest("GET", "https://api.cloudhub360.com/version", nil)
+			_, _ = sut.Do(req)
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, maxConcurrency, maxInProgress)
+}
+
+func TestRateLimitingHttpClient_Do_Pauses_Requests_For_Retry_After(t *testing.T) {
+	// Arrange
+	mockHttpDoer := mocks.HttpDoer{}
+	mockHttpDoer.On("Do", mock.Anything).
+		Return(&http.Response{
+			StatusCode: 429,
+			Header:     http.Header{"Retry-After": []string{"1"}},
+		}, nil).Once()
+	mockHttpDoer.On("Do", mock.Anything).Return(&http.Response{StatusCode: 200}, nil)
+	sut := net.NewRateLimitingHttpClient(&mockHttpDoer, net.RateLimits{})
+	req, _ := http.NewRequest("GET", "https://api.cloudhub360.com/version", nil)
+
+	// Act
+	_, _ = sut.Do(req)
+	start := time.Now()
+	resp, err := sut.Do(req)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.True(t, time.Since(start) >= time.Second)
+}
+
+func TestRateLimitingHttpClient_Do_Returns_Err_From_Context_When_Cancelled_While_Waiting(t *testing.T) {
+	// Arrange
+	mockHttpDoer := mocks.HttpDoer{}
+	mockHttpDoer.On("Do", mock.Anything).Return(&http.Response{StatusCode: 200}, nil)
+	sut := net.NewRateLimitingHttpClient(&mockHttpDoer, net.RateLimits{MaxRequestsPerSecond: 0.1})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequest("GET", "https://api.cloudhub360.com/version", nil)
+	req = req.WithContext(ctx)
+
+	// Act
+	_, err := sut.Do(req)
+	require.NoError(t, err)
+	_, err = sut.Do(req)
+
+	// Assert
+	assert.Equal(t, context.DeadlineExceeded, err)
+	mockHttpDoer.AssertNumberOfCalls(t, "Do", 1)
+}
+
+func TestRateLimitingHttpClient_Records_Slot_Wait(t *testing.T) {
+	// Arrange
+	mockHttpDoer := mocks.HttpDoer{}
+	mockHttpDoer.On("Do", mock.Anything).Return(&http.Response{StatusCode: 200}, nil)
+	recorder := telemetry.NewRecorder()
+	sut := net.NewRateLimitingHttpClient(&mockHttpDoer, net.RateLimits{MaxConcurrency: 1}).
+		WithRecorder(recorder)
+	req, _ := http.NewRequest("GET", "https://api.cloudhub360.com/version", nil)
+	metrics := bytes.Buffer{}
+
+	// Act
+	_, _ = sut.Do(req)
+
+	// Assert
+	require.NoError(t, recorder.WritePrometheus(&metrics))
+	assert.Contains(t, metrics.String(), "surf_http_slot_wait_seconds_count 1")
+}
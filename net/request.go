@@ -2,20 +2,66 @@ package net
 
 import (
 	"bytes"
-	"github.com/waives/surf/ioutils"
+	"io"
 	"io/ioutil"
 	"net/http"
 )
 
-// RequestBodyBytes reads the body from a request into a bytes.Buffer,
-// resets the request Body and returns the buffer.
-func RequestBodyBytes(request *http.Request) *bytes.Buffer {
-	bodyBuffer, err := ioutils.DrainClose(request.Body)
+// SetRewindableBody sets the request's body to be read from the ReadSeeker's current
+// position, so that it is streamed rather than read into memory. The request's GetBody
+// seeks back to that position, so that the request can be sent again (e.g. if it is
+// retried). The ReadSeeker is not closed when the request is sent.
+func SetRewindableBody(request *http.Request, body io.ReadSeeker) error {
+	start, err := body.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
 
+	end, err := body.Seek(0, io.SeekEnd)
 	if err != nil {
-		return nil
+		return err
 	}
 
-	request.Body = ioutil.NopCloser(bytes.NewBuffer(bodyBuffer.Bytes()))
-	return bodyBuffer
+	if _, err = body.Seek(start, io.SeekStart); err != nil {
+		return err
+	}
+
+	request.ContentLength = end - start
+	request.Body = ioutil.NopCloser(body)
+	request.GetBody = func() (io.ReadCloser, error) {
+		if _, err := body.Seek(start, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return ioutil.NopCloser(body), nil
+	}
+
+	return nil
+}
+
+// PeekBody reads up to limit bytes from the start of a request or response body (to log
+// them, for example), without reading the rest of it. It returns the bytes read, a body
+// which reads the whole of the original body from the start, and whether the bytes
+// read are the whole body.
+func PeekBody(body io.ReadCloser, limit int64) ([]byte, io.ReadCloser, bool, error) {
+	if body == nil || body == http.NoBody {
+		return nil, body, true, nil
+	}
+
+	peeked, err := ioutil.ReadAll(io.LimitReader(body, limit+1))
+
+	whole := &peekedBody{
+		Reader: io.MultiReader(bytes.NewReader(peeked), body),
+		Closer: body,
+	}
+
+	if int64(len(peeked)) > limit {
+		return peeked[:limit], whole, false, err
+	}
+
+	return peeked, whole, true, err
+}
+
+type peekedBody struct {
+	io.Reader
+	io.Closer
 }
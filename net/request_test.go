@@ -0,0 +1,71 @@
+package net
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSetRewindableBody_Streams_Body_From_Current_Position(t *testing.T) {
+	// Arrange
+	body := strings.NewReader("skipped request body")
+	_, _ = body.Seek(int64(len("skipped ")), 0)
+	request, _ := http.NewRequest("POST", "https://api.cloudhub360.com/documents", nil)
+
+	// Act
+	err := SetRewindableBody(request, body)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(len("request body")), request.ContentLength)
+
+	for attempt := 0; attempt < 2; attempt++ {
+		actualBody, _ := ioutil.ReadAll(request.Body)
+		assert.Equal(t, "request body", string(actualBody))
+
+		request.Body, err = request.GetBody()
+		require.NoError(t, err)
+	}
+}
+
+func TestPeekBody_Returns_Whole_Short_Body(t *testing.T) {
+	// Act
+	peeked, body, complete, err := PeekBody(ioutil.NopCloser(strings.NewReader("short")), 10)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "short", string(peeked))
+	assert.True(t, complete)
+	actualBody, _ := ioutil.ReadAll(body)
+	assert.Equal(t, "short", string(actualBody))
+}
+
+func TestPeekBody_Returns_Start_Of_Long_Body(t *testing.T) {
+	// Arrange
+	contents := bytes.Repeat([]byte("0123456789"), 10)
+
+	// Act
+	peeked, body, complete, err := PeekBody(ioutil.NopCloser(bytes.NewReader(contents)), 10)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789", string(peeked))
+	assert.False(t, complete)
+	actualBody, _ := ioutil.ReadAll(body)
+	assert.Equal(t, contents, actualBody)
+}
+
+func TestPeekBody_Returns_No_Body(t *testing.T) {
+	// Act
+	peeked, body, complete, err := PeekBody(nil, 10)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, peeked)
+	assert.Nil(t, body)
+	assert.True(t, complete)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"github.com/pkg/errors"
+	"io"
 	"io/ioutil"
 	"net/http"
 )
@@ -18,32 +19,40 @@ type ResponseChecker interface {
 	CheckForErrors(response *http.Response) error
 }
 
+// maxErrorResponseSize is the most of an error response's body which is read, to find
+// the error message in it.
+const maxErrorResponseSize = 1 << 20
+
+// CheckForErrors returns an error for any response without a 2xx status code, with the
+// message from the response body if it has one. The bodies of successful responses
+// (which may be large, such as read results) are not read.
 func (c *ErrorChecker) CheckForErrors(response *http.Response) error {
+	// Check status code
+	if response.StatusCode < 300 {
+		return nil
+	}
 
 	buf := bytes.Buffer{}
-	_, err := buf.ReadFrom(response.Body)
+	if response.Body != nil {
+		_, err := buf.ReadFrom(io.LimitReader(response.Body, maxErrorResponseSize))
 
-	if err != nil {
-		return errors.WithMessage(err, "Unable to read from HTTP response body")
+		if err != nil {
+			return errors.WithMessage(err, "Unable to read from HTTP response body")
+		}
+		response.Body.Close()
 	}
-	response.Body.Close()
 
 	// We've read from the response body, and it can't be rewound, so 'recreate' it as a new io.Reader
 	// which will read from the start of the underlying byte array of 'buf'.
 	response.Body = ioutil.NopCloser(&buf)
 
-	// Check status code
-	if response.StatusCode < 300 {
-		return nil
-	}
-
 	if json.Valid(buf.Bytes()) {
 		var (
 			basicError    = &basicErrorResponse{}
 			detailedError = &DetailedErrorResponse{}
 		)
 		// Try the basic err json first...
-		err = json.Unmarshal(buf.Bytes(), &basicError)
+		err := json.Unmarshal(buf.Bytes(), &basicError)
 
 		if err == nil && len(basicError.Message) > 0 {
 			return basicError
@@ -66,6 +66,23 @@ func Test_Returns_Correct_Error_Type_For_Rfc7807_And_Simple_Responses(t *testing
 	}
 }
 
+func Test_Does_Not_Read_Successful_Response_Body(t *testing.T) {
+	// Arrange
+	sut := &ErrorChecker{}
+	body := ioutil.NopCloser(bytes.NewBufferString("large read result"))
+	response := http.Response{
+		StatusCode: 200,
+		Body:       body,
+	}
+
+	// Act
+	err := sut.CheckForErrors(&response)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, body, response.Body)
+}
+
 var rfc7807Response = `{
   "errors": [
     {
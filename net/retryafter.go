@@ -0,0 +1,44 @@
+package net
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryAfter returns how long the server asked for requests to be delayed by, with
+// the Retry-After header of a 429 (Too Many Requests) or 503 (Service Unavailable)
+// response. It returns false if the response has no (valid) Retry-After header.
+func RetryAfter(response *http.Response) (time.Duration, bool) {
+	if response == nil ||
+		(response.StatusCode != http.StatusTooManyRequests &&
+			response.StatusCode != http.StatusServiceUnavailable) {
+		return 0, false
+	}
+
+	value := strings.TrimSpace(response.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+
+	// either a number of seconds...
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	// ...or an HTTP date
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	delay := time.Until(date)
+	if delay < 0 {
+		delay = 0
+	}
+	return delay, true
+}
@@ -0,0 +1,58 @@
+package net
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryAfter(t *testing.T) {
+	var fixtures = []struct {
+		statusCode    int
+		retryAfter    string
+		expectedDelay time.Duration
+		expectedOk    bool
+	}{
+		{429, "5", 5 * time.Second, true},
+		{503, "0", 0, true},
+		{503, " 12 ", 12 * time.Second, true},
+		{503, time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, true},
+		{429, "", 0, false},
+		{429, "-1", 0, false},
+		{429, "soon", 0, false},
+		{500, "5", 0, false},
+		{200, "5", 0, false},
+	}
+
+	for _, fixture := range fixtures {
+		response := &http.Response{
+			StatusCode: fixture.statusCode,
+			Header:     http.Header{},
+		}
+		response.Header.Set("Retry-After", fixture.retryAfter)
+
+		delay, ok := RetryAfter(response)
+
+		assert.Equal(t, fixture.expectedOk, ok, fixture)
+		assert.Equal(t, fixture.expectedDelay, delay, fixture)
+	}
+}
+
+func TestRetryAfter_Parses_Http_Date(t *testing.T) {
+	response := &http.Response{
+		StatusCode: 429,
+		Header:     http.Header{"Retry-After": []string{time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)}},
+	}
+
+	delay, ok := RetryAfter(response)
+
+	assert.True(t, ok)
+	assert.InDelta(t, float64(time.Minute), float64(delay), float64(2*time.Second))
+}
+
+func TestRetryAfter_Returns_False_For_Nil_Response(t *testing.T) {
+	_, ok := RetryAfter(nil)
+
+	assert.False(t, ok)
+}
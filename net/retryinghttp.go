@@ -2,69 +2,169 @@ package net
 
 import (
 	"bytes"
+	"context"
 	"github.com/cenkalti/backoff"
 	"github.com/pkg/errors"
 	"github.com/waives/surf/ioutils"
+	"io"
 	"io/ioutil"
+	"time"
 
 	"net/http"
 )
 
 var _ HttpDoer = (*RetryingHttpClient)(nil)
 
-// RetryingHttpClient is an HttpDoer decorator that retries HTTP requests
-// for any response with HTTP status 500+, or any network error.
+// RetryingHttpClient is an HttpDoer decorator that retries HTTP requests according
+// to the RetryPolicy for each request: by default, for any response with HTTP status
+// 408, 429, 500, 502, 503 or 504, or any network error. If the response has a Retry-After
+// header, the request is not retried any sooner than it asks.
 type RetryingHttpClient struct {
-	wrapped       HttpDoer
-	retryAttempts int
-	multiplier    float64
+	wrapped  HttpDoer
+	policies RetryPolicies
 }
 
+// NewRetryingHttpClient constructs a RetryingHttpClient which uses the default policies,
+// with the specified number of attempts and multiplier.
 func NewRetryingHttpClient(wrappedClient HttpDoer, retryAttempts int, multiplier float64) *RetryingHttpClient {
 	return &RetryingHttpClient{
-		wrapped:       wrappedClient,
-		retryAttempts: retryAttempts,
-		multiplier:    multiplier,
+		wrapped: wrappedClient,
+		policies: DefaultRetryPolicies().Map(func(policy RetryPolicy) RetryPolicy {
+			policy.Attempts = retryAttempts
+			policy.Multiplier = multiplier
+			return policy
+		}),
 	}
 }
 
+// WithPolicies replaces the policies used to retry requests.
+func (h *RetryingHttpClient) WithPolicies(policies RetryPolicies) *RetryingHttpClient {
+	h.policies = policies
+	return h
+}
+
 func (h *RetryingHttpClient) Do(request *http.Request) (*http.Response, error) {
 	var (
 		response *http.Response
 		err      error
 	)
-	requestBody, err := ioutils.DrainClose(request.Body)
+	getBody, err := rewindable(request)
 
 	if err != nil {
 		return nil, errors.WithMessage(err, "Unable to save request body")
 	}
 
-	var exponentialPolicy = backoff.NewExponentialBackOff()
-	exponentialPolicy.Multiplier = h.multiplier
-	backoffPolicy := backoff.WithMaxRetries(exponentialPolicy, uint64(h.retryAttempts))
-	backoffPolicy = backoff.WithContext(backoffPolicy, request.Context())
+	ctx := request.Context()
+	policy := h.policies.For(request)
+	retryAfterPolicy := &retryAfterBackOff{
+		BackOff: policy.backOff(),
+	}
+	backoffPolicy := backoff.WithContext(retryAfterPolicy, ctx)
 
+	attempt := 0
 	err = backoff.Retry(func() error {
-		// Reset the body on the request to ensure it's readable (rewound)
-		request.Body = ioutil.NopCloser(bytes.NewBuffer(requestBody.Bytes()))
+		// the previous (failed) response is discarded
+		if response != nil && response.Body != nil {
+			response.Body.Close()
+		}
+		attempt++
 
-		response, err = h.wrapped.Do(request)
-		return shouldRetry(response, err)
+		// each attempt sends a copy of the request, with the attempt number recorded in
+		// its context (e.g. to be logged), and its body from the start
+		attemptRequest := request.WithContext(context.WithValue(ctx, retryAttemptKey{}, attempt))
+		if attempt > 1 || request.GetBody == nil {
+			body, err := getBody()
+			if err != nil {
+				return backoff.Permanent(errors.WithMessage(err, "Unable to rewind request body"))
+			}
+			attemptRequest.Body = body
+		}
+		attemptRequest.GetBody = getBody
+
+		response, err = h.wrapped.Do(attemptRequest)
+		retryAfterPolicy.retryAfter, _ = RetryAfter(response)
+		return policy.shouldRetry(attemptRequest, response, err)
 	}, backoffPolicy)
 
+	if IsAmbiguousResult(err) && response != nil {
+		response.Body.Close()
+		response = nil
+	}
+
 	return response, err
 }
 
-// shouldRetry returns an error if the provided response and error are retryable.
-func shouldRetry(response *http.Response, err error) error {
+type retryAttemptKey struct{}
+
+// RetryAttempt returns the number of the attempt (from 1) a RetryingHttpClient is making
+// to send the request with the context, or 0 if it is not being sent by one.
+func RetryAttempt(ctx context.Context) int {
+	attempt, _ := ctx.Value(retryAttemptKey{}).(int)
+	return attempt
+}
+
+// rewindable returns a function which returns the request's body from the start, so
+// that it can be sent again. Bodies which cannot be rewound (with the request's GetBody)
+// are read into memory, and are only readable from the function afterwards.
+func rewindable(request *http.Request) (func() (io.ReadCloser, error), error) {
+	if request.GetBody != nil {
+		return request.GetBody, nil
+	}
+
+	if request.Body == nil || request.Body == http.NoBody {
+		body := request.Body
+		return func() (io.ReadCloser, error) {
+			return body, nil
+		}, nil
+	}
+
+	requestBody, err := ioutils.DrainClose(request.Body)
 	if err != nil {
+		return nil, err
+	}
+
+	return func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(requestBody.Bytes())), nil
+	}, nil
+}
+
+// shouldRetry returns an error if the provided response and error are retryable. A
+// *backoff.PermanentError is returned if they would be, but the request may have been
+// acted on and the policy is for non-idempotent requests.
+func (p RetryPolicy) shouldRetry(request *http.Request, response *http.Response, err error) error {
+	if err != nil {
+		if p.NonIdempotent && request.Context().Err() == nil {
+			return backoff.Permanent(&AmbiguousResultError{Err: err})
+		}
 		return err
 	}
 
-	if response.StatusCode >= 500 || response.StatusCode == 408 {
-		return errors.Errorf("Unexpected HTTP response %v", response.StatusCode)
+	if p.isRetryable(response.StatusCode) {
+		err = errors.Errorf("Unexpected HTTP response %v", response.StatusCode)
+
+		if p.NonIdempotent && !wasNotActedOn(response.StatusCode) {
+			return backoff.Permanent(&AmbiguousResultError{Err: err})
+		}
+		return err
 	}
 
 	// no error, don't retry
 	return nil
 }
+
+// retryAfterBackOff waits for at least the delay requested by the last response's
+// Retry-After header (if it had one) before the next attempt.
+type retryAfterBackOff struct {
+	backoff.BackOff
+	retryAfter time.Duration
+}
+
+func (b *retryAfterBackOff) NextBackOff() time.Duration {
+	next := b.BackOff.NextBackOff()
+
+	if next != backoff.Stop && b.retryAfter > next {
+		next = b.retryAfter
+	}
+
+	return next
+}
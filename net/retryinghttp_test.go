@@ -6,10 +6,15 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/waives/surf/ioutils"
 	"github.com/waives/surf/net/mocks"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestRetryingHttpClient_Should_Return_Response_From_Wrapped_Doer_On_Success(t *testing.T) {
@@ -101,7 +106,7 @@ func TestRetryingHttpClient_Should_Specify_The_Same_Request_Data_On_Each_Retry(t
 			// Capture request and request body on each attempt
 			actualRequest := (args.Get(0)).(*http.Request)
 			actualRequests = append(actualRequests, actualRequest)
-			bodyBuf, _ := ioutils.DrainClose(request.Body)
+			bodyBuf, _ := ioutils.DrainClose(actualRequest.Body)
 			actualRequestBodies = append(actualRequestBodies, bodyBuf.Bytes())
 		}).
 		Return(expectedResponse, expectedErr)
@@ -111,8 +116,11 @@ func TestRetryingHttpClient_Should_Specify_The_Same_Request_Data_On_Each_Retry(t
 	_, _ = sut.Do(request)
 
 	// Assert
+	assert.Len(t, actualRequests, retryAttempts+1)
 	for _, actualRequest := range actualRequests {
-		assert.Equal(t, request, actualRequest)
+		assert.Equal(t, request.Method, actualRequest.Method)
+		assert.Equal(t, request.URL, actualRequest.URL)
+		assert.Equal(t, request.Header, actualRequest.Header)
 	}
 	for _, actualRequestBody := range actualRequestBodies {
 		assert.Equal(t, expectedBody, actualRequestBody)
@@ -149,3 +157,173 @@ func TestRetryingHttpClient_Should_Not_Retry_If_Cancelled(t *testing.T) {
 	assert.Equal(t, expectedCallCount, actualCallCount)
 	assert.Equal(t, expectedErr, actualErr)
 }
+
+func TestRetryingHttpClient_Should_Retry_On_Http_429(t *testing.T) {
+	// Arrange
+	var (
+		wrappedDoer       = mocks.HttpDoer{}
+		retryAttempts     = 2
+		expectedCallCount = retryAttempts + 1
+		request, _        = http.NewRequest("GET", "https://api.cloudhub360.com/version", nil)
+		actualCallCount   int
+	)
+	wrappedDoer.
+		On("Do", mock.Anything).
+		Run(func(_ mock.Arguments) {
+			actualCallCount++
+		}).
+		Return(&http.Response{StatusCode: 429}, nil)
+	sut := NewRetryingHttpClient(&wrappedDoer, retryAttempts, 0.01)
+
+	// Act
+	_, _ = sut.Do(request)
+
+	// Assert
+	assert.Equal(t, expectedCallCount, actualCallCount)
+}
+
+func TestRetryingHttpClient_Should_Wait_For_Retry_After(t *testing.T) {
+	// Arrange
+	var (
+		wrappedDoer = mocks.HttpDoer{}
+		request, _  = http.NewRequest("GET", "https://api.cloudhub360.com/version", nil)
+		callTimes   []time.Time
+	)
+	wrappedDoer.
+		On("Do", mock.Anything).
+		Run(func(_ mock.Arguments) {
+			callTimes = append(callTimes, time.Now())
+		}).
+		Return(&http.Response{
+			StatusCode: 503,
+			Header:     http.Header{"Retry-After": []string{"1"}},
+		}, nil)
+	sut := NewRetryingHttpClient(&wrappedDoer, 1, 0.01)
+
+	// Act
+	_, _ = sut.Do(request)
+
+	// Assert
+	require.Len(t, callTimes, 2)
+	assert.True(t, callTimes[1].Sub(callTimes[0]) >= time.Second)
+}
+
+func TestRetryingHttpClient_Should_Rewind_Request_Body_With_GetBody(t *testing.T) {
+	// Arrange
+	var (
+		wrappedDoer         = mocks.HttpDoer{}
+		expectedBody        = "test request body"
+		request, _          = http.NewRequest("PUT", "https://api.cloudhub360.com/documents/id/reads", nil)
+		getBodyCallCount    int
+		actualRequestBodies []string
+	)
+	request.Body = ioutil.NopCloser(strings.NewReader(expectedBody))
+	request.GetBody = func() (io.ReadCloser, error) {
+		getBodyCallCount++
+		return ioutil.NopCloser(strings.NewReader(expectedBody)), nil
+	}
+	wrappedDoer.
+		On("Do", mock.Anything).
+		Run(func(args mock.Arguments) {
+			body, _ := ioutil.ReadAll(args.Get(0).(*http.Request).Body)
+			actualRequestBodies = append(actualRequestBodies, string(body))
+		}).
+		Return(nil, errors.New("test error"))
+	sut := NewRetryingHttpClient(&wrappedDoer, 2, 0.01)
+
+	// Act
+	_, _ = sut.Do(request)
+
+	// Assert
+	assert.Equal(t, 2, getBodyCallCount)
+	assert.Equal(t, []string{expectedBody, expectedBody, expectedBody}, actualRequestBodies)
+}
+
+func TestRetryingHttpClient_Should_Close_Responses_Which_Are_Retried(t *testing.T) {
+	// Arrange
+	var (
+		wrappedDoer = mocks.HttpDoer{}
+		request, _  = http.NewRequest("GET", "https://api.cloudhub360.com/version", nil)
+		failedBody  = &closeRecorder{Reader: strings.NewReader("")}
+		successBody = &closeRecorder{Reader: strings.NewReader("")}
+	)
+	wrappedDoer.
+		On("Do", mock.Anything).
+		Return(&http.Response{StatusCode: 500, Body: failedBody}, nil).Once()
+	wrappedDoer.
+		On("Do", mock.Anything).
+		Return(&http.Response{StatusCode: 200, Body: successBody}, nil)
+	sut := NewRetryingHttpClient(&wrappedDoer, 1, 0.01)
+
+	// Act
+	response, err := sut.Do(request)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, successBody, response.Body)
+	assert.True(t, failedBody.closed)
+	assert.False(t, successBody.closed)
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestRetryingHttpClient_Should_Record_Attempt_In_Request_Context(t *testing.T) {
+	// Arrange
+	var (
+		wrappedDoer    = mocks.HttpDoer{}
+		request, _     = http.NewRequest("GET", "https://api.cloudhub360.com/version", nil)
+		actualAttempts []int
+	)
+	wrappedDoer.
+		On("Do", mock.Anything).
+		Run(func(args mock.Arguments) {
+			actualRequest := args.Get(0).(*http.Request)
+			actualAttempts = append(actualAttempts, RetryAttempt(actualRequest.Context()))
+		}).
+		Return(nil, errors.New("test error"))
+	sut := NewRetryingHttpClient(&wrappedDoer, 2, 0.01)
+
+	// Act
+	_, _ = sut.Do(request)
+
+	// Assert
+	assert.Equal(t, []int{1, 2, 3}, actualAttempts)
+	assert.Equal(t, 0, RetryAttempt(request.Context()))
+}
+
+func TestRetryingHttpClient_Should_Not_Modify_The_Request(t *testing.T) {
+	// Arrange
+	var (
+		wrappedDoer    = mocks.HttpDoer{}
+		request, _     = http.NewRequest("POST", "https://api.cloudhub360.com/documents", bytes.NewBufferString("body"))
+		actualRequests []*http.Request
+	)
+	originalRequest := *request
+	wrappedDoer.
+		On("Do", mock.Anything).
+		Run(func(args mock.Arguments) {
+			actualRequest := args.Get(0).(*http.Request)
+			actualRequests = append(actualRequests, actualRequest)
+			_, _ = ioutils.DrainClose(actualRequest.Body)
+		}).
+		Return(nil, errors.New("test error"))
+	sut := NewRetryingHttpClient(&wrappedDoer, 2, 0.01)
+
+	// Act
+	_, _ = sut.Do(request)
+
+	// Assert
+	for _, actualRequest := range actualRequests {
+		assert.True(t, actualRequest != request, "a copy of the request is sent")
+	}
+	assert.True(t, request.Context() == originalRequest.Context(), "the request's context is unchanged")
+	assert.True(t, request.Body == originalRequest.Body, "the request's body is unchanged")
+}
@@ -0,0 +1,163 @@
+package net
+
+import (
+	"github.com/cenkalti/backoff"
+	"github.com/pkg/errors"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+// RetryPolicy decides whether, when and how often a failed request is retried.
+type RetryPolicy struct {
+	// Attempts is the maximum number of times a request is retried.
+	Attempts int
+	// MaxElapsedTime is how long after the first attempt a request may be retried
+	// (0 for no limit).
+	MaxElapsedTime time.Duration
+	// Multiplier is the factor by which the delay between retries increases.
+	Multiplier float64
+	// Jitter is the proportion (from 0 to 1) by which each delay is randomly varied.
+	Jitter float64
+	// RetryableStatusCodes are the HTTP status codes of the responses which are retried.
+	RetryableStatusCodes []int
+	// NonIdempotent requests may have been acted on even if they fail, so are only
+	// retried after responses which show they were not (408, 429 and 503). Any other
+	// retryable failure results in an AmbiguousResultError.
+	NonIdempotent bool
+}
+
+// DefaultRetryableStatusCodes are the status codes retried by default.
+var DefaultRetryableStatusCodes = []int{408, 429, 500, 502, 503, 504}
+
+// DefaultRetryPolicy returns the policy used for requests which no rule applies to.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		Attempts:             3,
+		MaxElapsedTime:       backoff.DefaultMaxElapsedTime,
+		Multiplier:           2,
+		Jitter:               backoff.DefaultRandomizationFactor,
+		RetryableStatusCodes: DefaultRetryableStatusCodes,
+	}
+}
+
+// RetryRule applies a RetryPolicy to the requests it matches.
+type RetryRule struct {
+	// Method is the HTTP method of the requests the rule matches (any, if empty).
+	Method string
+	// Path is a pattern (as used by path.Match) matching the URL paths of the
+	// requests the rule matches (any, if empty).
+	Path   string
+	Policy RetryPolicy
+}
+
+// Matches returns true if the rule applies to the request.
+func (r RetryRule) Matches(request *http.Request) bool {
+	return matches(r.Method, r.Path, request)
+}
+
+// matches returns true if the request has the method and its URL path matches the
+// pattern, either of which may be empty to match any request.
+func matches(method, pathPattern string, request *http.Request) bool {
+	if method != "" && !strings.EqualFold(method, request.Method) {
+		return false
+	}
+
+	if pathPattern != "" {
+		matched, err := path.Match(pathPattern, request.URL.Path)
+		return err == nil && matched
+	}
+
+	return true
+}
+
+// RetryPolicies choose the RetryPolicy for each request.
+type RetryPolicies struct {
+	Default RetryPolicy
+	// Rules override the Default policy for the requests they match. The first
+	// matching rule is used.
+	Rules []RetryRule
+}
+
+// DefaultRetryPolicies returns the default policy, with a rule which prevents document
+// uploads (POST /documents) from being retried when they may have succeeded.
+func DefaultRetryPolicies() RetryPolicies {
+	createDocument := DefaultRetryPolicy()
+	createDocument.NonIdempotent = true
+
+	return RetryPolicies{
+		Default: DefaultRetryPolicy(),
+		Rules: []RetryRule{
+			{Method: "POST", Path: "/documents", Policy: createDocument},
+		},
+	}
+}
+
+// For returns the policy for the request.
+func (p RetryPolicies) For(request *http.Request) RetryPolicy {
+	for _, rule := range p.Rules {
+		if rule.Matches(request) {
+			return rule.Policy
+		}
+	}
+
+	return p.Default
+}
+
+// Map returns the policies with fn applied to each of them.
+func (p RetryPolicies) Map(fn func(RetryPolicy) RetryPolicy) RetryPolicies {
+	mapped := RetryPolicies{
+		Default: fn(p.Default),
+	}
+
+	for _, rule := range p.Rules {
+		rule.Policy = fn(rule.Policy)
+		mapped.Rules = append(mapped.Rules, rule)
+	}
+
+	return mapped
+}
+
+func (p RetryPolicy) backOff() backoff.BackOff {
+	exponentialPolicy := backoff.NewExponentialBackOff()
+	exponentialPolicy.Multiplier = p.Multiplier
+	exponentialPolicy.RandomizationFactor = p.Jitter
+	exponentialPolicy.MaxElapsedTime = p.MaxElapsedTime
+
+	return backoff.WithMaxRetries(exponentialPolicy, uint64(p.Attempts))
+}
+
+func (p RetryPolicy) isRetryable(statusCode int) bool {
+	for _, retryable := range p.RetryableStatusCodes {
+		if statusCode == retryable {
+			return true
+		}
+	}
+
+	return false
+}
+
+// wasNotActedOn returns true for responses which show the server did not act on the
+// request.
+func wasNotActedOn(statusCode int) bool {
+	return statusCode == http.StatusRequestTimeout ||
+		statusCode == http.StatusTooManyRequests ||
+		statusCode == http.StatusServiceUnavailable
+}
+
+// AmbiguousResultError is returned when a non-idempotent request fails in a way which
+// means it may (or may not) have been acted on, so it is not retried.
+type AmbiguousResultError struct {
+	Err error
+}
+
+func (e *AmbiguousResultError) Error() string {
+	return e.Err.Error()
+}
+
+// IsAmbiguousResult returns true if the error (or its cause) is an AmbiguousResultError.
+func IsAmbiguousResult(err error) bool {
+	_, ok := errors.Cause(err).(*AmbiguousResultError)
+	return ok
+}
@@ -0,0 +1,139 @@
+package net
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/waives/surf/net/mocks"
+	"net/http"
+	"testing"
+)
+
+func TestRetryPolicies_For_Returns_Policy_Of_First_Matching_Rule(t *testing.T) {
+	var (
+		upload = RetryPolicy{Attempts: 1}
+		reads  = RetryPolicy{Attempts: 2}
+		sut    = RetryPolicies{
+			Default: RetryPolicy{Attempts: 3},
+			Rules: []RetryRule{
+				{Method: "POST", Path: "/documents", Policy: upload},
+				{Path: "/documents/*/reads", Policy: reads},
+			},
+		}
+		fixtures = []struct {
+			method   string
+			url      string
+			expected RetryPolicy
+		}{
+			{"POST", "https://api.cloudhub360.com/documents", upload},
+			{"post", "https://api.cloudhub360.com/documents", upload},
+			{"GET", "https://api.cloudhub360.com/documents", sut.Default},
+			{"POST", "https://api.cloudhub360.com/documents/123/reads", reads},
+			{"GET", "https://api.cloudhub360.com/documents/123/reads", reads},
+			{"GET", "https://api.cloudhub360.com/documents/123/reads/pdf", sut.Default},
+		}
+	)
+
+	for _, fixture := range fixtures {
+		request, _ := http.NewRequest(fixture.method, fixture.url, nil)
+
+		assert.Equal(t, fixture.expected, sut.For(request), fixture)
+	}
+}
+
+func TestRetryPolicies_Map_Applies_Function_To_Each_Policy(t *testing.T) {
+	sut := DefaultRetryPolicies().Map(func(policy RetryPolicy) RetryPolicy {
+		policy.Attempts = 7
+		return policy
+	})
+
+	assert.Equal(t, 7, sut.Default.Attempts)
+	for _, rule := range sut.Rules {
+		assert.Equal(t, 7, rule.Policy.Attempts)
+	}
+	assert.Equal(t, 3, DefaultRetryPolicies().Default.Attempts)
+}
+
+func TestRetryingHttpClient_Should_Only_Retry_Configured_Status_Codes(t *testing.T) {
+	for statusCode, expectedCallCount := range map[int]int{429: 3, 500: 1} {
+		// Arrange
+		var (
+			wrappedDoer     = mocks.HttpDoer{}
+			request, _      = http.NewRequest("GET", "https://api.cloudhub360.com/version", nil)
+			actualCallCount int
+		)
+		wrappedDoer.
+			On("Do", mock.Anything).
+			Run(func(_ mock.Arguments) {
+				actualCallCount++
+			}).
+			Return(&http.Response{StatusCode: statusCode}, nil)
+		sut := NewRetryingHttpClient(&wrappedDoer, 0, 0).WithPolicies(RetryPolicies{
+			Default: RetryPolicy{Attempts: 2, Multiplier: 1.01, RetryableStatusCodes: []int{429}},
+		})
+
+		// Act
+		_, _ = sut.Do(request)
+
+		// Assert
+		assert.Equal(t, expectedCallCount, actualCallCount, statusCode)
+	}
+}
+
+func TestRetryingHttpClient_Should_Not_Retry_Ambiguous_Failures_Of_Non_Idempotent_Requests(t *testing.T) {
+	var fixtures = []struct {
+		response *http.Response
+		err      error
+	}{
+		{nil, errors.New("connection reset")},
+		{&http.Response{StatusCode: 500, Body: http.NoBody}, nil},
+		{&http.Response{StatusCode: 502, Body: http.NoBody}, nil},
+	}
+
+	for _, fixture := range fixtures {
+		// Arrange
+		var (
+			wrappedDoer     = mocks.HttpDoer{}
+			request, _      = http.NewRequest("POST", "https://api.cloudhub360.com/documents", nil)
+			actualCallCount int
+		)
+		wrappedDoer.
+			On("Do", mock.Anything).
+			Run(func(_ mock.Arguments) {
+				actualCallCount++
+			}).
+			Return(fixture.response, fixture.err)
+		sut := NewRetryingHttpClient(&wrappedDoer, 3, 0.01)
+
+		// Act
+		response, err := sut.Do(request)
+
+		// Assert
+		assert.Equal(t, 1, actualCallCount, fixture)
+		assert.Nil(t, response, fixture)
+		assert.True(t, IsAmbiguousResult(err), fixture)
+	}
+}
+
+func TestRetryingHttpClient_Should_Retry_Non_Idempotent_Requests_Which_Were_Not_Acted_On(t *testing.T) {
+	// Arrange
+	var (
+		wrappedDoer     = mocks.HttpDoer{}
+		request, _      = http.NewRequest("POST", "https://api.cloudhub360.com/documents", nil)
+		actualCallCount int
+	)
+	wrappedDoer.
+		On("Do", mock.Anything).
+		Run(func(_ mock.Arguments) {
+			actualCallCount++
+		}).
+		Return(&http.Response{StatusCode: 503}, nil)
+	sut := NewRetryingHttpClient(&wrappedDoer, 2, 0.01)
+
+	// Act
+	_, err := sut.Do(request)
+
+	// Assert
+	assert.Equal(t, 3, actualCallCount)
+	assert.False(t, IsAmbiguousResult(err))
+}
@@ -0,0 +1,132 @@
+package net
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Scrubbed replaces secrets (such as credentials and access tokens) which are removed
+// from logged requests and responses.
+const Scrubbed = "REDACTED"
+
+// secretHeaders are the headers whose values are secret.
+var secretHeaders = []string{"Authorization", "Proxy-Authorization"}
+
+// secretFields are the names of the form fields and json properties whose values are secret.
+var secretFields = map[string]bool{
+	"client_secret": true,
+	"access_token":  true,
+	"refresh_token": true,
+	"password":      true,
+}
+
+// ScrubHeaders returns a copy of the headers, with the values of any secret headers
+// (such as Authorization) replaced.
+func ScrubHeaders(headers http.Header) http.Header {
+	scrubbed := http.Header{}
+	for name, values := range headers {
+		scrubbed[name] = values
+	}
+
+	for _, name := range secretHeaders {
+		if values, ok := scrubbed[http.CanonicalHeaderKey(name)]; ok {
+			replaced := make([]string, len(values))
+			for i := range replaced {
+				replaced[i] = Scrubbed
+			}
+			scrubbed[http.CanonicalHeaderKey(name)] = replaced
+		}
+	}
+
+	return scrubbed
+}
+
+// ScrubBody returns the body with the values of any secret form fields or json
+// properties (such as client_secret) replaced, according to its content type. Bodies
+// with no secrets are returned as they are.
+func ScrubBody(contentType string, body []byte) []byte {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	if mediaType == "application/x-www-form-urlencoded" {
+		return scrubForm(body)
+	}
+
+	if json.Valid(body) {
+		return scrubJson(body)
+	}
+
+	return body
+}
+
+func scrubForm(body []byte) []byte {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		// it can't be logged safely
+		return []byte(Scrubbed)
+	}
+
+	scrubbed := false
+	for name := range form {
+		if secretFields[strings.ToLower(name)] {
+			form.Set(name, Scrubbed)
+			scrubbed = true
+		}
+	}
+
+	if !scrubbed {
+		return body
+	}
+
+	return []byte(form.Encode())
+}
+
+func scrubJson(body []byte) []byte {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return body
+	}
+
+	// only bodies with secrets are re-encoded, since that doesn't preserve their formatting
+	if !scrubValue(value) {
+		return body
+	}
+
+	buf := bytes.Buffer{}
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return []byte(Scrubbed)
+	}
+
+	return bytes.TrimSpace(buf.Bytes())
+}
+
+// scrubValue replaces the values of any secret properties in the decoded json value,
+// returning true if there were any.
+func scrubValue(value interface{}) bool {
+	scrubbed := false
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, property := range v {
+			if secretFields[strings.ToLower(name)] {
+				v[name] = Scrubbed
+				scrubbed = true
+			} else if scrubValue(property) {
+				scrubbed = true
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if scrubValue(item) {
+				scrubbed = true
+			}
+		}
+	}
+
+	return scrubbed
+}
@@ -0,0 +1,59 @@
+package net_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/waives/surf/net"
+	"net/http"
+	"testing"
+)
+
+func TestScrubHeaders_Replaces_Secret_Headers(t *testing.T) {
+	headers := http.Header{
+		"Authorization": []string{"Bearer token"},
+		"Accept":        []string{"application/pdf"},
+	}
+
+	scrubbed := net.ScrubHeaders(headers)
+
+	assert.Equal(t, http.Header{
+		"Authorization": []string{net.Scrubbed},
+		"Accept":        []string{"application/pdf"},
+	}, scrubbed)
+	assert.Equal(t, "Bearer token", headers.Get("Authorization"))
+}
+
+func TestScrubBody_Replaces_Secret_Fields(t *testing.T) {
+	fixtures := []struct {
+		contentType  string
+		body         string
+		expectedBody string
+	}{
+		{
+			contentType:  "application/x-www-form-urlencoded",
+			body:         "client_id=id&client_secret=secret&grant_type=client_credentials",
+			expectedBody: "client_id=id&client_secret=REDACTED&grant_type=client_credentials",
+		}, {
+			contentType:  "application/json; charset=utf-8",
+			body:         `{"access_token": "token", "token_type": "Bearer"}`,
+			expectedBody: `{"access_token":"REDACTED","token_type":"Bearer"}`,
+		}, {
+			contentType:  "",
+			body:         `[{"nested": {"Client_Secret": "secret"}}]`,
+			expectedBody: `[{"nested":{"Client_Secret":"REDACTED"}}]`,
+		}, {
+			contentType:  "application/json",
+			body:         `{"message": "no secrets here"}`,
+			expectedBody: `{"message": "no secrets here"}`,
+		}, {
+			contentType:  "application/pdf",
+			body:         "%PDF-1.4",
+			expectedBody: "%PDF-1.4",
+		},
+	}
+
+	for _, fixture := range fixtures {
+		scrubbed := net.ScrubBody(fixture.contentType, []byte(fixture.body))
+
+		assert.Equal(t, fixture.expectedBody, string(scrubbed))
+	}
+}
@@ -0,0 +1,102 @@
+package net
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+var _ HttpDoer = (*TimeoutHttpClient)(nil)
+
+// Timeouts are the time limits on requests, including reading their responses. A
+// zero timeout means there is no limit.
+type Timeouts struct {
+	Default time.Duration
+	// Rules override the Default timeout for the requests they match. The first
+	// matching rule is used.
+	Rules []TimeoutRule
+}
+
+// TimeoutRule applies a timeout to the requests it matches.
+type TimeoutRule struct {
+	// Method is the HTTP method of the requests the rule matches (any, if empty).
+	Method string
+	// Path is a pattern (as used by path.Match) matching the URL paths of the
+	// requests the rule matches (any, if empty).
+	Path    string
+	Timeout time.Duration
+}
+
+// Matches returns true if the rule applies to the request.
+func (r TimeoutRule) Matches(request *http.Request) bool {
+	return matches(r.Method, r.Path, request)
+}
+
+// For returns the timeout for the request.
+func (t Timeouts) For(request *http.Request) time.Duration {
+	for _, rule := range t.Rules {
+		if rule.Matches(request) {
+			return rule.Timeout
+		}
+	}
+
+	return t.Default
+}
+
+// TimeoutHttpClient is an HttpDoer decorator which cancels requests that are not
+// complete (i.e. whose response bodies have not been read and closed) within the
+// timeout for each request.
+type TimeoutHttpClient struct {
+	wrapped  HttpDoer
+	timeouts Timeouts
+}
+
+func NewTimeoutHttpClient(wrappedClient HttpDoer, timeouts Timeouts) *TimeoutHttpClient {
+	return &TimeoutHttpClient{
+		wrapped:  wrappedClient,
+		timeouts: timeouts,
+	}
+}
+
+func (h *TimeoutHttpClient) Do(request *http.Request) (*http.Response, error) {
+	timeout := h.timeouts.For(request)
+	if timeout <= 0 {
+		return h.wrapped.Do(request)
+	}
+
+	ctx, cancel := context.WithTimeout(request.Context(), timeout)
+	response, err := h.wrapped.Do(request.WithContext(ctx))
+
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded && request.Context().Err() == nil {
+			err = errors.Wrapf(err, "The request timed out after %s", timeout)
+		}
+		cancel()
+		return response, err
+	}
+
+	if response == nil || response.Body == nil {
+		cancel()
+		return response, err
+	}
+
+	// the timeout continues to apply while the body is read
+	response.Body = &cancellingBody{
+		ReadCloser: response.Body,
+		cancel:     cancel,
+	}
+
+	return response, nil
+}
+
+type cancellingBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancellingBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,106 @@
+package net_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/waives/surf/net"
+	"github.com/waives/surf/net/mocks"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeouts_For_Returns_Timeout_Of_First_Matching_Rule(t *testing.T) {
+	timeouts := net.Timeouts{
+		Default: time.Minute,
+		Rules: []net.TimeoutRule{
+			{Method: "GET", Path: "/documents/*/reads", Timeout: 2 * time.Minute},
+			{Path: "/documents/*/reads", Timeout: 3 * time.Minute},
+		},
+	}
+	fixtures := []struct {
+		method          string
+		url             string
+		expectedTimeout time.Duration
+	}{
+		{"GET", "https://api.cloudhub360.com/documents/id/reads", 2 * time.Minute},
+		{"PUT", "https://api.cloudhub360.com/documents/id/reads", 3 * time.Minute},
+		{"GET", "https://api.cloudhub360.com/documents", time.Minute},
+	}
+
+	for _, fixture := range fixtures {
+		request, _ := http.NewRequest(fixture.method, fixture.url, nil)
+
+		assert.Equal(t, fixture.expectedTimeout, timeouts.For(request), fixture.url)
+	}
+}
+
+func TestTimeoutHttpClient_Passes_Request_Through_Without_Timeout(t *testing.T) {
+	// Arrange
+	expectedResp := &http.Response{StatusCode: 200}
+	mockHttpDoer := mocks.HttpDoer{}
+	mockHttpDoer.On("Do", mock.Anything).Return(expectedResp, nil)
+	sut := net.NewTimeoutHttpClient(&mockHttpDoer, net.Timeouts{})
+	req, _ := http.NewRequest("GET", "https://api.cloudhub360.com/version", nil)
+
+	// Act
+	receivedResp, receivedErr := sut.Do(req)
+
+	// Assert
+	mockHttpDoer.AssertCalled(t, "Do", req)
+	assert.NoError(t, receivedErr)
+	assert.Equal(t, expectedResp, receivedResp)
+}
+
+func TestTimeoutHttpClient_Cancels_Request_After_Timeout(t *testing.T) {
+	// Arrange
+	mockHttpDoer := mocks.HttpDoer{}
+	mockHttpDoer.
+		On("Do", mock.Anything).
+		Return(func(request *http.Request) *http.Response {
+			<-request.Context().Done()
+			return nil
+		}, func(request *http.Request) error {
+			return request.Context().Err()
+		})
+	sut := net.NewTimeoutHttpClient(&mockHttpDoer, net.Timeouts{Default: 10 * time.Millisecond})
+	req, _ := http.NewRequest("GET", "https://api.cloudhub360.com/version", nil)
+
+	// Act
+	_, err := sut.Do(req)
+
+	// Assert
+	assert.EqualError(t, err, "The request timed out after 10ms: context deadline exceeded")
+}
+
+func TestTimeoutHttpClient_Applies_Timeout_Until_Response_Body_Is_Closed(t *testing.T) {
+	// Arrange
+	var requestSent *http.Request
+	mockHttpDoer := mocks.HttpDoer{}
+	mockHttpDoer.
+		On("Do", mock.Anything).
+		Run(func(args mock.Arguments) {
+			requestSent = args.Get(0).(*http.Request)
+		}).
+		Return(&http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(strings.NewReader("body")),
+		}, nil)
+	sut := net.NewTimeoutHttpClient(&mockHttpDoer, net.Timeouts{Default: time.Minute})
+	req, _ := http.NewRequest("GET", "https://api.cloudhub360.com/version", nil)
+
+	// Act
+	response, err := sut.Do(req)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NoError(t, requestSent.Context().Err())
+	body, _ := ioutil.ReadAll(response.Body)
+	assert.Equal(t, "body", string(body))
+
+	response.Body.Close()
+	assert.Error(t, requestSent.Context().Err())
+}
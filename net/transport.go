@@ -0,0 +1,108 @@
+package net
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	stdnet "net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// TransportOptions configure how connections to the API are made.
+type TransportOptions struct {
+	// Proxy is the URL of the proxy requests are sent via. If it is empty, the proxy
+	// (if any) is chosen by the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+	Proxy string
+	// CACertificates is the name of a PEM file of the certificate authorities which
+	// are trusted, as well as the system's (e.g. that of a TLS-intercepting proxy).
+	CACertificates string
+	// ClientCertificate and ClientKey are the names of the PEM files of the certificate
+	// (and its private key) presented to authenticate the client, if required. The key
+	// may be in the certificate's file, in which case ClientKey may be empty.
+	ClientCertificate string
+	ClientKey         string
+}
+
+// NewTransport constructs an http.Transport, with the same defaults as
+// http.DefaultTransport, which makes connections as specified by the options.
+func NewTransport(options TransportOptions) (*http.Transport, error) {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&stdnet.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			DualStack: true,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+
+	if options.Proxy != "" {
+		proxyUrl, err := url.Parse(options.Proxy)
+		if err != nil || proxyUrl.Scheme == "" || proxyUrl.Host == "" {
+			return nil, errors.Errorf("The proxy '%s' is not a valid URL, such as "+
+				"http://proxy.example.com:8080.", options.Proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+
+	if options.ClientKey != "" && options.ClientCertificate == "" {
+		return nil, errors.New("A client key was specified without a client certificate.")
+	}
+
+	if options.CACertificates == "" && options.ClientCertificate == "" {
+		return transport, nil
+	}
+
+	transport.TLSClientConfig = &tls.Config{}
+
+	if options.CACertificates != "" {
+		rootCAs, err := certPool(options.CACertificates)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig.RootCAs = rootCAs
+	}
+
+	if options.ClientCertificate != "" {
+		keyFile := options.ClientKey
+		if keyFile == "" {
+			keyFile = options.ClientCertificate
+		}
+
+		certificate, err := tls.LoadX509KeyPair(options.ClientCertificate, keyFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Unable to load the client certificate '%s'",
+				options.ClientCertificate)
+		}
+		transport.TLSClientConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return transport, nil
+}
+
+// certPool returns the system's certificate pool, with the certificates in the PEM file.
+func certPool(filename string) (*x509.CertPool, error) {
+	pem, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to read the CA certificates '%s'", filename)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		// e.g. on Windows
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.Errorf("The CA certificates '%s' do not contain any PEM "+
+			"certificates.", filename)
+	}
+
+	return pool, nil
+}
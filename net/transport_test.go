@@ -0,0 +1,86 @@
+package net_test
+
+import (
+	"encoding/pem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/waives/surf/net"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+)
+
+func TestNewTransport_Uses_Environment_Proxy_By_Default(t *testing.T) {
+	transport, err := net.NewTransport(net.TransportOptions{})
+
+	require.NoError(t, err)
+	assert.NotNil(t, transport.Proxy)
+	assert.Nil(t, transport.TLSClientConfig)
+}
+
+func TestNewTransport_Uses_Specified_Proxy(t *testing.T) {
+	transport, err := net.NewTransport(net.TransportOptions{Proxy: "http://proxy.example.com:8080"})
+	require.NoError(t, err)
+
+	request, _ := http.NewRequest("GET", "https://api.cloudhub360.com/version", nil)
+	proxyUrl, err := transport.Proxy(request)
+
+	require.NoError(t, err)
+	assert.Equal(t, &url.URL{Scheme: "http", Host: "proxy.example.com:8080"}, proxyUrl)
+}
+
+func TestNewTransport_Trusts_Specified_CA_Certificates(t *testing.T) {
+	// Arrange
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	caFile := tempFile(t, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.Certificate().Raw,
+	}))
+	defer os.Remove(caFile)
+
+	transport, err := net.NewTransport(net.TransportOptions{CACertificates: caFile})
+	require.NoError(t, err)
+
+	// Act
+	response, err := (&http.Client{Transport: transport}).Get(server.URL)
+
+	// Assert
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, 200, response.StatusCode)
+}
+
+func TestNewTransport_Returns_Error_For_Invalid_Options(t *testing.T) {
+	notPem := tempFile(t, []byte("not a certificate"))
+	defer os.Remove(notPem)
+
+	fixtures := []net.TransportOptions{
+		{Proxy: "proxy.example.com"},
+		{CACertificates: notPem},
+		{CACertificates: notPem + ".missing"},
+		{ClientCertificate: notPem},
+		{ClientKey: notPem},
+	}
+
+	for _, fixture := range fixtures {
+		_, err := net.NewTransport(fixture)
+
+		assert.Error(t, err, fixture)
+	}
+}
+
+func tempFile(t *testing.T, contents []byte) string {
+	file, err := ioutil.TempFile("", "transport_test")
+	require.NoError(t, err)
+	defer file.Close()
+
+	_, err = file.Write(contents)
+	require.NoError(t, err)
+
+	return file.Name()
+}
@@ -0,0 +1,174 @@
+package diff
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown either side of each change.
+const contextLines = 3
+
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+type op struct {
+	kind opKind
+	line string
+	// the (zero-based) line numbers in the old and new text before this op is applied
+	oldLine, newLine int
+}
+
+// WriteUnified writes a unified diff of the old and new texts to writer, labelling them
+// with oldName and newName. It writes nothing and returns false if the texts are identical.
+func WriteUnified(writer io.Writer, oldName, newName, oldText, newText string) (bool, error) {
+	if oldText == newText {
+		return false, nil
+	}
+
+	ops := diffLines(splitLines(oldText), splitLines(newText))
+
+	_, err := fmt.Fprintf(writer, "--- %s\n+++ %s\n", oldName, newName)
+	if err != nil {
+		return true, err
+	}
+
+	for _, hunk := range hunks(ops) {
+		if err = writeHunk(writer, hunk); err != nil {
+			return true, err
+		}
+	}
+
+	return true, nil
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines computes the edit script between a and b from their longest common
+// subsequence. The texts diffed here are small, so the quadratic cost is acceptable.
+func diffLines(a, b []string) []op {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ops []op
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, op{opEqual, a[i], i, j})
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] > lcs[i+1][j]):
+			ops = append(ops, op{opInsert, b[j], i, j})
+			j++
+		default:
+			ops = append(ops, op{opDelete, a[i], i, j})
+			i++
+		}
+	}
+
+	return ops
+}
+
+// hunks groups the changes in ops together with their surrounding context lines.
+func hunks(ops []op) [][]op {
+	var (
+		result [][]op
+		start  = -1
+		end    int
+	)
+
+	for index, o := range ops {
+		if o.kind == opEqual {
+			continue
+		}
+
+		hunkStart := max(0, index-contextLines)
+		if start >= 0 && hunkStart > end {
+			result = append(result, ops[start:end])
+			start = -1
+		}
+		if start < 0 {
+			start = hunkStart
+		}
+		end = min(len(ops), index+contextLines+1)
+	}
+
+	if start >= 0 {
+		result = append(result, ops[start:end])
+	}
+
+	return result
+}
+
+func writeHunk(writer io.Writer, hunk []op) error {
+	oldCount, newCount := 0, 0
+	for _, o := range hunk {
+		if o.kind != opInsert {
+			oldCount++
+		}
+		if o.kind != opDelete {
+			newCount++
+		}
+	}
+
+	_, err := fmt.Fprintf(writer, "@@ -%s +%s @@\n",
+		hunkRange(hunk[0].oldLine, oldCount), hunkRange(hunk[0].newLine, newCount))
+	if err != nil {
+		return err
+	}
+
+	prefixes := map[opKind]string{opEqual: " ", opDelete: "-", opInsert: "+"}
+	for _, o := range hunk {
+		if _, err = fmt.Fprintln(writer, prefixes[o.kind]+o.line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func hunkRange(firstLine, count int) string {
+	if count == 0 {
+		// by convention, an empty range refers to the line before it
+		return fmt.Sprintf("%d,0", firstLine)
+	}
+	return fmt.Sprintf("%d,%d", firstLine+1, count)
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,75 @@
+package diff_test
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/waives/surf/output/diff"
+	"testing"
+)
+
+func TestWriteUnified_Writes_Nothing_For_Identical_Texts(t *testing.T) {
+	output := &bytes.Buffer{}
+
+	differs, err := diff.WriteUnified(output, "a", "b", "one\ntwo\n", "one\ntwo\n")
+
+	assert.NoError(t, err)
+	assert.False(t, differs)
+	assert.Empty(t, output.String())
+}
+
+func TestWriteUnified_Writes_Changed_Lines_With_Context(t *testing.T) {
+	output := &bytes.Buffer{}
+	oldText := "1\n2\n3\n4\n5\n6\n7\n8\n9\n"
+	newText := "1\n2\n3\n4\nfive\n6\n7\n8\n9\n"
+
+	differs, err := diff.WriteUnified(output, "old", "new", oldText, newText)
+
+	assert.NoError(t, err)
+	assert.True(t, differs)
+	assert.Equal(t, `--- old
++++ new
+@@ -2,7 +2,7 @@
+ 2
+ 3
+ 4
+-5
++five
+ 6
+ 7
+ 8
+`, output.String())
+}
+
+func TestWriteUnified_Writes_Separate_Hunks_For_Distant_Changes(t *testing.T) {
+	output := &bytes.Buffer{}
+	oldText := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+	newText := "one\n2\n3\n4\n5\n6\n7\n8\n9\nten\n"
+
+	_, err := diff.WriteUnified(output, "old", "new", oldText, newText)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `--- old
++++ new
+@@ -1,4 +1,4 @@
+-1
++one
+ 2
+ 3
+ 4
+@@ -7,4 +7,4 @@
+ 7
+ 8
+ 9
+-10
++ten
+`, output.String())
+}
+
+func TestWriteUnified_Handles_Added_Text(t *testing.T) {
+	output := &bytes.Buffer{}
+
+	_, err := diff.WriteUnified(output, "old", "new", "", "added\n")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "--- old\n+++ new\n@@ -0,0 +1,1 @@\n+added\n", output.String())
+}
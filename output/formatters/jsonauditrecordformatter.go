@@ -0,0 +1,40 @@
+package formatters
+
+import (
+	"encoding/json"
+	"github.com/waives/surf/audit"
+	"io"
+)
+
+// JsonAuditRecordFormatter writes the audit trail of a redacted file as a
+// single json object.
+type JsonAuditRecordFormatter struct {
+}
+
+var _ ResultsFormatter = (*JsonAuditRecordFormatter)(nil)
+
+func NewJsonAuditRecordFormatter() *JsonAuditRecordFormatter {
+	return &JsonAuditRecordFormatter{}
+}
+
+func (f *JsonAuditRecordFormatter) WriteResult(writer io.Writer, filename string, result interface{}, options FormatOption) error {
+	record, ok := result.(*audit.Record)
+
+	if !ok {
+		return ErrUnexpectedType(result)
+	}
+
+	bytes, err := json.MarshalIndent(record, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(bytes)
+
+	return err
+}
+
+func (f *JsonAuditRecordFormatter) Flush(writer io.Writer) error {
+	return nil
+}
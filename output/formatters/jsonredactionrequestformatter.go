@@ -0,0 +1,67 @@
+package formatters
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/waives/surf/ch360/request"
+	"io"
+	"path/filepath"
+)
+
+// JsonRedactionRequestFormatter writes the requests which would be used to redact
+// files, so that the marks can be reviewed before redacting.
+type JsonRedactionRequestFormatter struct {
+	resultsWritten bool
+	headerWritten  bool
+}
+
+var _ ResultsFormatter = (*JsonRedactionRequestFormatter)(nil)
+
+func NewJsonRedactionRequestFormatter() *JsonRedactionRequestFormatter {
+	return &JsonRedactionRequestFormatter{}
+}
+
+func (f *JsonRedactionRequestFormatter) WriteResult(writer io.Writer, filename string, result interface{}, options FormatOption) error {
+
+	redactRequest, ok := result.(*request.RedactedPdfRequest)
+
+	if !ok {
+		return ErrUnexpectedType(result)
+	}
+
+	if options&IncludeHeader == IncludeHeader {
+		f.headerWritten = true
+		fmt.Fprint(writer, "[") // header
+	} else if f.resultsWritten {
+		fmt.Fprint(writer, ",\n") // write separator
+	}
+
+	// add filename to original request
+	var output = struct {
+		Filename string `json:"filename"`
+		*request.RedactedPdfRequest
+	}{
+		Filename:           filepath.FromSlash(filename),
+		RedactedPdfRequest: redactRequest,
+	}
+
+	bytes, err := json.MarshalIndent(&output, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(bytes)
+
+	f.resultsWritten = true
+
+	return err
+}
+
+func (f *JsonRedactionRequestFormatter) Flush(writer io.Writer) error {
+	if f.headerWritten {
+		_, err := fmt.Fprint(writer, "]")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package tests
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/waives/surf/audit"
+	"github.com/waives/surf/output/formatters"
+	"testing"
+	"time"
+)
+
+func TestJsonAuditRecordFormatter_Writes_Record(t *testing.T) {
+	output := &bytes.Buffer{}
+	record := &audit.Record{
+		SourceFile:    "document.pdf",
+		SourceSHA256:  "source-hash",
+		OutputFile:    "document.redacted.pdf",
+		OutputSHA256:  "output-hash",
+		RedactedAt:    time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC),
+		Configuration: audit.Configuration{Method: audit.MethodPattern, Patterns: []string{`\d+`}, Bookmarks: true},
+		MarksApplied:  true,
+		Marks:         []audit.Mark{{PageNumber: 1, Top: 1, Left: 2, Bottom: 3, Right: 4, Pattern: `\d+`}},
+	}
+
+	err := formatters.NewJsonAuditRecordFormatter().
+		WriteResult(output, "document.pdf", record, formatters.IncludeHeader)
+
+	require.Nil(t, err)
+	assert.JSONEq(t, `{
+		"source_file": "document.pdf",
+		"source_sha256": "source-hash",
+		"output_file": "document.redacted.pdf",
+		"output_sha256": "output-hash",
+		"redacted_at": "2019-03-01T12:00:00Z",
+		"configuration": {"method": "pattern", "patterns": ["\\d+"], "preview": false, "bookmarks": true},
+		"marks_applied": true,
+		"marks": [{"page_number": 1, "top": 1, "left": 2, "bottom": 3, "right": 4, "pattern": "\\d+"}]
+	}`, output.String())
+}
+
+func TestJsonAuditRecordFormatter_Returns_Error_For_Unexpected_Type(t *testing.T) {
+	err := formatters.NewJsonAuditRecordFormatter().
+		WriteResult(&bytes.Buffer{}, "document.pdf", "not a record", 0)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/ch360/request"
+	"github.com/waives/surf/output/formatters"
+	"strings"
+	"testing"
+)
+
+type JsonRedactionRequestFormatterSuite struct {
+	suite.Suite
+	output *bytes.Buffer
+	sut    *formatters.JsonRedactionRequestFormatter
+	result *request.RedactedPdfRequest
+}
+
+func (suite *JsonRedactionRequestFormatterSuite) SetupTest() {
+	suite.output = &bytes.Buffer{}
+	suite.sut = formatters.NewJsonRedactionRequestFormatter()
+	suite.result = &request.RedactedPdfRequest{
+		Marks: []request.RedactionMark{
+			{Area: request.RedactionArea{Top: 1, Left: 2, Bottom: 3, Right: 4, PageNumber: 1}},
+		},
+		ApplyMarks: true,
+		Bookmarks:  []request.RedactionBookmark{{Text: "Name", PageNumber: 1}},
+	}
+}
+
+func TestJsonRedactionRequestFormatterRunner(t *testing.T) {
+	suite.Run(t, new(JsonRedactionRequestFormatterSuite))
+}
+
+func (suite *JsonRedactionRequestFormatterSuite) TestWrites_Requests_As_Json_Array() {
+	err := suite.sut.WriteResult(suite.output, "document1.pdf", suite.result, formatters.IncludeHeader)
+	require.Nil(suite.T(), err)
+	err = suite.sut.WriteResult(suite.output, "document2.pdf", suite.result, 0)
+	require.Nil(suite.T(), err)
+	suite.sut.Flush(suite.output)
+
+	assert.True(suite.T(), IsJSON(suite.output.String()))
+	assert.True(suite.T(), strings.HasPrefix(suite.output.String(), "["))
+	assert.True(suite.T(), strings.HasSuffix(suite.output.String(), "]"))
+}
+
+func (suite *JsonRedactionRequestFormatterSuite) TestWrites_Filename_And_Marks() {
+	err := suite.sut.WriteResult(suite.output, "document1.pdf", suite.result, 0)
+
+	require.Nil(suite.T(), err)
+	assert.JSONEq(suite.T(), `{
+		"filename": "document1.pdf",
+		"marks": [{"area": {"top": 1, "left": 2, "bottom": 3, "right": 4, "page_number": 1}}],
+		"apply_marks": true,
+		"bookmarks": [{"text": "Name", "page_number": 1}]
+	}`, suite.output.String())
+}
+
+func (suite *JsonRedactionRequestFormatterSuite) TestWriteResult_Returns_Error_For_Unexpected_Type() {
+	err := suite.sut.WriteResult(suite.output, "document1.pdf", "not a request", 0)
+
+	assert.Error(suite.T(), err)
+}
@@ -0,0 +1,34 @@
+package tests
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/waives/surf/output/formatters"
+	"github.com/waives/surf/pages"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestTextPagesResultsFormatter_Writes_Selected_Pages(t *testing.T) {
+	output := &bytes.Buffer{}
+	sut := formatters.NewTextPagesResultsFormatter(pages.Selection{
+		{First: 1, Last: 1},
+		{First: 3, Last: 3},
+	})
+
+	err := sut.WriteResult(output, "document.pdf",
+		ioutil.NopCloser(strings.NewReader("one\ftwo\fthree")), formatters.IncludeHeader)
+
+	require.NoError(t, err)
+	assert.Equal(t, "one\fthree", output.String())
+}
+
+func TestTextPagesResultsFormatter_Returns_Error_For_Unexpected_Type(t *testing.T) {
+	sut := formatters.NewTextPagesResultsFormatter(nil)
+
+	err := sut.WriteResult(&bytes.Buffer{}, "document.pdf", "not a reader", 0)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"archive/zip"
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/waives/surf/ch360/wvdoc"
+	"github.com/waives/surf/output/formatters"
+	"github.com/waives/surf/pages"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func aWvdocResult(t *testing.T) io.ReadCloser {
+	buf := &bytes.Buffer{}
+	archive := zip.NewWriter(buf)
+	entry, err := archive.Create(wvdoc.DocumentEntryName)
+	require.NoError(t, err)
+	_, err = entry.Write([]byte(`{"pages": [{"page_number": 1, "width": 100, "height": 200, "lines": [
+		{"words": [{"text": "Hello", "left": 1, "top": 2, "right": 3, "bottom": 4, "confidence": 1}]}
+	]}]}`))
+	require.NoError(t, err)
+	require.NoError(t, archive.Close())
+
+	return ioutil.NopCloser(buf)
+}
+
+func TestWvdocResultsFormatter_Writes_Json_Array(t *testing.T) {
+	output := &bytes.Buffer{}
+	sut := formatters.NewWvdocResultsFormatter(formatters.WvdocJSON)
+
+	require.NoError(t, sut.WriteResult(output, "document1.pdf", aWvdocResult(t), formatters.IncludeHeader))
+	require.NoError(t, sut.WriteResult(output, "document2.pdf", aWvdocResult(t), 0))
+	require.NoError(t, sut.Flush(output))
+
+	assert.True(t, IsJSON(output.String()))
+	assert.True(t, strings.HasPrefix(output.String(), `[{
+  "filename": "document1.pdf",
+  "pages": [`))
+	assert.Contains(t, output.String(), `"text": "Hello"`)
+	assert.True(t, strings.HasSuffix(output.String(), "]"))
+}
+
+func TestWvdocResultsFormatter_Writes_HOCR(t *testing.T) {
+	output := &bytes.Buffer{}
+
+	err := formatters.NewWvdocResultsFormatter(formatters.WvdocHOCR).
+		WriteResult(output, "document1.pdf", aWvdocResult(t), formatters.IncludeHeader)
+
+	require.NoError(t, err)
+	assert.Contains(t, output.String(), "<span class='ocrx_word' id='word_1_1_1' "+
+		"title='bbox 1 2 3 4; x_wconf 100'>Hello</span>")
+}
+
+func TestWvdocResultsFormatter_Writes_ALTO(t *testing.T) {
+	output := &bytes.Buffer{}
+
+	err := formatters.NewWvdocResultsFormatter(formatters.WvdocALTO).
+		WriteResult(output, "document1.pdf", aWvdocResult(t), formatters.IncludeHeader)
+
+	require.NoError(t, err)
+	assert.Contains(t, output.String(), `CONTENT="Hello"`)
+}
+
+func TestWvdocResultsFormatter_Returns_Error_For_Invalid_Wvdoc(t *testing.T) {
+	err := formatters.NewWvdocResultsFormatter(formatters.WvdocJSON).
+		WriteResult(&bytes.Buffer{}, "document1.pdf", ioutil.NopCloser(strings.NewReader("nope")), 0)
+
+	assert.Error(t, err)
+}
+
+func TestWvdocResultsFormatter_Writes_Only_Selected_Pages(t *testing.T) {
+	output := &bytes.Buffer{}
+
+	err := formatters.NewWvdocResultsFormatter(formatters.WvdocALTO).
+		WithPages(pages.Selection{{First: 2, Last: 3}}).
+		WriteResult(output, "document1.pdf", aWvdocResult(t), formatters.IncludeHeader)
+
+	require.NoError(t, err)
+	assert.NotContains(t, output.String(), `CONTENT="Hello"`)
+}
@@ -0,0 +1,42 @@
+package formatters
+
+import (
+	"github.com/waives/surf/ioutils"
+	"github.com/waives/surf/pages"
+	"io"
+)
+
+var _ ResultsFormatter = (*TextPagesResultsFormatter)(nil)
+
+// TextPagesResultsFormatter writes the selected pages of text read results (passed
+// to it as an io.ReadCloser), in which pages are separated by form feeds.
+type TextPagesResultsFormatter struct {
+	selection pages.Selection
+}
+
+func NewTextPagesResultsFormatter(selection pages.Selection) *TextPagesResultsFormatter {
+	return &TextPagesResultsFormatter{
+		selection: selection,
+	}
+}
+
+func (f *TextPagesResultsFormatter) WriteResult(writer io.Writer, filename string, result interface{},
+	options FormatOption) error {
+	readCloser, ok := result.(io.ReadCloser)
+
+	if !ok {
+		return ErrUnexpectedType(result)
+	}
+
+	buf, err := ioutils.DrainClose(readCloser)
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(f.selection.SelectText(buf.Bytes()))
+	return err
+}
+
+func (f *TextPagesResultsFormatter) Flush(writer io.Writer) error {
+	return nil
+}
@@ -0,0 +1,111 @@
+package formatters
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/waives/surf/ch360/wvdoc"
+	"github.com/waives/surf/pages"
+	"io"
+	"path/filepath"
+)
+
+// The formats the WvdocResultsFormatter can write.
+const (
+	WvdocHOCR = "hocr"
+	WvdocALTO = "alto"
+	WvdocJSON = "json"
+)
+
+// WvdocResultsFormatter converts read results in the wvdoc format (passed to it as
+// an io.ReadCloser) to hOCR, ALTO or json.
+type WvdocResultsFormatter struct {
+	format         string
+	selection      pages.Selection
+	resultsWritten bool
+	headerWritten  bool
+}
+
+var _ ResultsFormatter = (*WvdocResultsFormatter)(nil)
+
+// NewWvdocResultsFormatter constructs a WvdocResultsFormatter writing the specified
+// format (one of WvdocHOCR, WvdocALTO or WvdocJSON).
+func NewWvdocResultsFormatter(format string) *WvdocResultsFormatter {
+	return &WvdocResultsFormatter{
+		format: format,
+	}
+}
+
+// WithPages limits the output to the selected pages of each document.
+func (f *WvdocResultsFormatter) WithPages(selection pages.Selection) *WvdocResultsFormatter {
+	f.selection = selection
+	return f
+}
+
+func (f *WvdocResultsFormatter) WriteResult(writer io.Writer, filename string, result interface{}, options FormatOption) error {
+	readCloser, ok := result.(io.ReadCloser)
+
+	if !ok {
+		return ErrUnexpectedType(result)
+	}
+	defer readCloser.Close()
+
+	document, err := wvdoc.Read(readCloser)
+
+	if err != nil {
+		return err
+	}
+
+	if !f.selection.IsEmpty() {
+		document = document.SelectPages(f.selection.Includes)
+	}
+
+	filename = filepath.FromSlash(filename)
+
+	switch f.format {
+	case WvdocHOCR:
+		return document.WriteHOCR(writer, filename)
+	case WvdocALTO:
+		return document.WriteALTO(writer, filename)
+	default:
+		return f.writeJson(writer, filename, document, options)
+	}
+}
+
+func (f *WvdocResultsFormatter) writeJson(writer io.Writer, filename string, document *wvdoc.Document,
+	options FormatOption) error {
+	if options&IncludeHeader == IncludeHeader {
+		f.headerWritten = true
+		fmt.Fprint(writer, "[") // header
+	} else if f.resultsWritten {
+		fmt.Fprint(writer, ",\n") // write separator
+	}
+
+	// add filename to the document
+	var output = struct {
+		Filename string `json:"filename"`
+		*wvdoc.Document
+	}{
+		Filename: filename,
+		Document: document,
+	}
+
+	bytes, err := json.MarshalIndent(&output, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(bytes)
+
+	f.resultsWritten = true
+
+	return err
+}
+
+func (f *WvdocResultsFormatter) Flush(writer io.Writer) error {
+	if f.headerWritten {
+		_, err := fmt.Fprint(writer, "]")
+		return err
+	}
+	return nil
+}
@@ -45,6 +45,12 @@ func (c *ProgressHandler) Notify(filename string, result interface{}) error {
 	return c.resultsWriter.WriteResult(filename, result)
 }
 
+// SkipsOutputFor returns true if the results for the file would be discarded, because its
+// output files already exist and existing files are skipped.
+func (c *ProgressHandler) SkipsOutputFor(filename string) (bool, error) {
+	return resultsWriters.SkipsOutputFor(c.resultsWriter, filename)
+}
+
 func (c *ProgressHandler) NotifyErr(filename string, err error) error {
 	if !c.started {
 		return errors.New("NotifyStart must be called before NotifyErr")
@@ -76,3 +82,15 @@ func (c *ProgressHandler) NotifyFinish() error {
 	}
 	return c.resultsWriter.Finish()
 }
+
+// NotifyAbort is called instead of NotifyFinish when processing failed or was cancelled,
+// so that incomplete results are discarded.
+func (c *ProgressHandler) NotifyAbort() error {
+	if !c.started {
+		return errors.New("NotifyStart must be called before NotifyAbort")
+	}
+	if c.showProgress {
+		c.progress.Stop()
+	}
+	return c.resultsWriter.Abort()
+}
@@ -53,6 +53,7 @@ func (suite *ClassifyProgressHandlerSuite) setupMockResultWriter(start, write, f
 
 	if finish {
 		suite.mockResultWriter.On("Finish").Return(nil)
+		suite.mockResultWriter.On("Abort").Return(nil)
 	}
 }
 
@@ -125,6 +126,33 @@ func (suite *ClassifyProgressHandlerSuite) Test_ClassifyProgressHandler_Returns_
 	}
 }
 
+func (suite *ClassifyProgressHandlerSuite) Test_ClassifyProgressHandler_NotifyAbort_Aborts_ResultWriter() {
+	for _, showProgress := range []bool{true, false} {
+		// Arrange
+		sut := progress.NewProgressHandler(suite.mockResultWriter, showProgress, suite.outBuffer)
+
+		// Act
+		sut.NotifyStart(1)
+		err := sut.NotifyAbort()
+
+		// Assert
+		suite.Assert().Nil(err)
+		suite.mockResultWriter.AssertCalled(suite.T(), "Abort")
+		suite.mockResultWriter.AssertNotCalled(suite.T(), "Finish")
+	}
+}
+
+func (suite *ClassifyProgressHandlerSuite) Test_ClassifyProgressHandler_Returns_Error_If_NotifyAbort_Is_Called_Before_NotifyStart() {
+	for _, sut := range suite.suts {
+		// Act
+		err := sut.NotifyAbort()
+
+		// Assert
+		suite.Assert().NotNil(err)
+		suite.mockResultWriter.AssertNotCalled(suite.T(), "Abort")
+	}
+}
+
 func AClassificationResult() *results.ClassificationResult {
 	return &results.ClassificationResult{}
 }
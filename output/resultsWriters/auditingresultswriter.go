@@ -0,0 +1,121 @@
+package resultsWriters
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/waives/surf/audit"
+	"github.com/waives/surf/output/formatters"
+	"github.com/waives/surf/output/sinks"
+	"io"
+	"path/filepath"
+	"time"
+)
+
+var _ ResultsWriter = (*AuditingResultsWriter)(nil)
+
+// The AuditingResultsWriter writes redacted files (passed to it as *audit.RedactedFile
+// results) with another ResultsWriter, and records an audit trail for each of them
+// with a second ResultsWriter.
+type AuditingResultsWriter struct {
+	resultsWriter   ResultsWriter
+	recordsWriter   ResultsWriter
+	outputExtension string
+	outputLocation  sinks.OutputLocation
+	configuration   audit.Configuration
+}
+
+// NewAuditingResultsWriter constructs an AuditingResultsWriter. The outputExtension is
+// the extension resultsWriter gives to the files it writes.
+func NewAuditingResultsWriter(resultsWriter, recordsWriter ResultsWriter, outputExtension string,
+	configuration audit.Configuration) *AuditingResultsWriter {
+	return &AuditingResultsWriter{
+		resultsWriter:   resultsWriter,
+		recordsWriter:   recordsWriter,
+		outputExtension: outputExtension,
+		configuration:   configuration,
+	}
+}
+
+// WithOutputLocation records the location resultsWriter writes its files to, when
+// they are not written adjacent to the input files.
+func (c *AuditingResultsWriter) WithOutputLocation(location sinks.OutputLocation) *AuditingResultsWriter {
+	c.outputLocation = location
+	return c
+}
+
+func (c *AuditingResultsWriter) Start() error {
+	err := c.resultsWriter.Start()
+	if err != nil {
+		return err
+	}
+
+	return c.recordsWriter.Start()
+}
+
+func (c *AuditingResultsWriter) WriteResult(filename string, result interface{}) error {
+	redactedFile, ok := result.(*audit.RedactedFile)
+
+	if !ok {
+		return formatters.ErrUnexpectedType(result)
+	}
+
+	// an existing redacted file which is left in place keeps its existing record
+	skipped, err := c.SkipsOutputFor(filename)
+	if err != nil || skipped {
+		redactedFile.Contents.Close()
+		return err
+	}
+
+	// hash the redacted file as it is written
+	hash := sha256.New()
+	err = c.resultsWriter.WriteResult(filename, &teeReadCloser{
+		Reader: io.TeeReader(redactedFile.Contents, hash),
+		Closer: redactedFile.Contents,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	record := &audit.Record{
+		SourceFile:    filepath.FromSlash(filename),
+		SourceSHA256:  redactedFile.SourceSHA256,
+		OutputFile:    filepath.FromSlash(c.outputLocation.Filename(filename, c.outputExtension)),
+		OutputSHA256:  hex.EncodeToString(hash.Sum(nil)),
+		RedactedAt:    time.Now().UTC(),
+		Configuration: c.configuration,
+		MarksApplied:  redactedFile.Request.ApplyMarks,
+		Marks:         audit.MarksFor(redactedFile.Request),
+	}
+
+	return c.recordsWriter.WriteResult(filename, record)
+}
+
+// SkipsOutputFor returns true if the redacted file for the input file would be skipped, in
+// which case no audit record is written for it either.
+func (c *AuditingResultsWriter) SkipsOutputFor(filename string) (bool, error) {
+	return SkipsOutputFor(c.resultsWriter, filename)
+}
+
+func (c *AuditingResultsWriter) Finish() error {
+	err := c.resultsWriter.Finish()
+	if err != nil {
+		return err
+	}
+
+	return c.recordsWriter.Finish()
+}
+
+func (c *AuditingResultsWriter) Abort() error {
+	err := c.resultsWriter.Abort()
+	if recordsErr := c.recordsWriter.Abort(); err == nil {
+		err = recordsErr
+	}
+
+	return err
+}
+
+type teeReadCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -49,3 +49,8 @@ func (c *CombinedResultsWriter) Finish() error {
 
 	return c.resultSink.Close()
 }
+
+// Abort discards the results written so far, where the sink allows.
+func (c *CombinedResultsWriter) Abort() error {
+	return c.resultSink.Abort()
+}
@@ -40,11 +40,13 @@ func (c *IndividualResultsWriter) WriteResult(filename string, result interface{
 
 	err = c.resultsFormatter.WriteResult(resultSink, filename, result, formatters.IncludeHeader)
 	if err != nil {
+		resultSink.Abort()
 		return err
 	}
 
 	err = c.resultsFormatter.Flush(resultSink)
 	if err != nil {
+		resultSink.Abort()
 		return err
 	}
 
@@ -57,6 +59,22 @@ func (c *IndividualResultsWriter) WriteResult(filename string, result interface{
 	return nil
 }
 
+// SkipsOutputFor returns true if the output file for the input file already exists, and
+// existing files are skipped.
+func (c *IndividualResultsWriter) SkipsOutputFor(filename string) (bool, error) {
+	skipper, ok := c.sinkFactory.(sinks.OutputSkipper)
+	if !ok {
+		return false, nil
+	}
+
+	return skipper.SkipsOutputFor(filename)
+}
+
 func (c *IndividualResultsWriter) Finish() error {
 	return nil
 }
+
+// Abort does nothing, as each result is written (or discarded) in full by WriteResult.
+func (c *IndividualResultsWriter) Abort() error {
+	return nil
+}
@@ -9,6 +9,20 @@ type ResultsWriter struct {
 	mock.Mock
 }
 
+// Abort provides a mock function with given fields:
+func (_m *ResultsWriter) Abort() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Finish provides a mock function with given fields:
 func (_m *ResultsWriter) Finish() error {
 	ret := _m.Called()
@@ -0,0 +1,133 @@
+package resultsWriters
+
+import (
+	"bytes"
+	"github.com/pkg/errors"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/ioutils"
+	"github.com/waives/surf/output/formatters"
+	"github.com/waives/surf/output/sinks"
+	"io"
+	"io/ioutil"
+)
+
+var _ ResultsWriter = (*ReadModesResultsWriter)(nil)
+
+// ReadModeOutput is a ResultsWriter which writes the read results for a ReadMode.
+type ReadModeOutput struct {
+	Mode          ch360.ReadMode
+	ResultsWriter ResultsWriter
+}
+
+// The ReadModesResultsWriter writes the results of reading a file in several modes
+// (passed to it as map[ch360.ReadMode]io.ReadCloser results), writing the result
+// for each mode with the ResultsWriters for that mode.
+type ReadModesResultsWriter struct {
+	outputs []ReadModeOutput
+}
+
+// NewReadModesResultsWriter constructs a ReadModesResultsWriter. More than one
+// output may use the same mode.
+func NewReadModesResultsWriter(outputs ...ReadModeOutput) *ReadModesResultsWriter {
+	return &ReadModesResultsWriter{
+		outputs: outputs,
+	}
+}
+
+// NewReadFormatsResultsWriter constructs a ResultsWriter which writes each file's read
+// results in each of the output formats, to individual files at the location.
+func NewReadFormatsResultsWriter(location sinks.OutputLocation, outputFormats []string,
+	readModes map[string]ch360.ReadMode, options ReadOutputOptions) (ResultsWriter, error) {
+	var outputs []ReadModeOutput
+
+	for _, outputFormat := range outputFormats {
+		resultsWriter, err := NewReaderResultsWriter(Destination{MultiFileOut: true, Location: location},
+			outputFormat, options)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs = append(outputs, ReadModeOutput{
+			Mode:          readModes[outputFormat],
+			ResultsWriter: resultsWriter,
+		})
+	}
+
+	return NewReadModesResultsWriter(outputs...), nil
+}
+
+func (w *ReadModesResultsWriter) Start() error {
+	for _, output := range w.outputs {
+		if err := output.ResultsWriter.Start(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *ReadModesResultsWriter) WriteResult(filename string, result interface{}) error {
+	readResults, ok := result.(map[ch360.ReadMode]io.ReadCloser)
+
+	if !ok {
+		return formatters.ErrUnexpectedType(result)
+	}
+
+	// each result may be written more than once, so buffer them all first
+	buffers := map[ch360.ReadMode][]byte{}
+	for mode, readResult := range readResults {
+		buf, err := ioutils.DrainClose(readResult)
+		if err != nil {
+			return err
+		}
+		buffers[mode] = buf.Bytes()
+	}
+
+	for _, output := range w.outputs {
+		buf, ok := buffers[output.Mode]
+		if !ok {
+			return errors.Errorf("There is no %s read result for %s", output.Mode, filename)
+		}
+
+		err := output.ResultsWriter.WriteResult(filename, ioutil.NopCloser(bytes.NewReader(buf)))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SkipsOutputFor returns true if the output for every mode would be skipped.
+func (w *ReadModesResultsWriter) SkipsOutputFor(filename string) (bool, error) {
+	for _, output := range w.outputs {
+		skipped, err := SkipsOutputFor(output.ResultsWriter, filename)
+		if err != nil || !skipped {
+			return false, err
+		}
+	}
+
+	return len(w.outputs) > 0, nil
+}
+
+func (w *ReadModesResultsWriter) Finish() error {
+	for _, output := range w.outputs {
+		if err := output.ResultsWriter.Finish(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Abort aborts each of the outputs, returning the first error.
+func (w *ReadModesResultsWriter) Abort() error {
+	var firstErr error
+	for _, output := range w.outputs {
+		if err := output.ResultsWriter.Abort(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
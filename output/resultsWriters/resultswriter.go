@@ -1,9 +1,13 @@
 package resultsWriters
 
 import (
-	"github.com/waives/surf/fs"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/waives/surf/audit"
 	"github.com/waives/surf/output/formatters"
 	"github.com/waives/surf/output/sinks"
+	"github.com/waives/surf/pages"
+	"os"
 )
 
 //go:generate mockery -name ResultsWriter
@@ -11,11 +15,43 @@ type ResultsWriter interface {
 	Start() error
 	WriteResult(filename string, result interface{}) error
 	Finish() error
+	// Abort is called instead of Finish when processing failed or was cancelled. Any
+	// output which is incomplete is discarded.
+	Abort() error
+}
+
+// SkipsOutputFor returns true if the ResultsWriter would discard the results for the
+// input file, because its output files already exist and existing files are skipped.
+// Such files needn't be processed at all.
+func SkipsOutputFor(resultsWriter ResultsWriter, filename string) (bool, error) {
+	skipper, ok := resultsWriter.(sinks.OutputSkipper)
+	if !ok {
+		return false, nil
+	}
+
+	return skipper.SkipsOutputFor(filename)
+}
+
+// Destination is where results are written: each to its own file at Location (when
+// MultiFileOut is set), or all of them to OutputFile (or stdout, if it is not set).
+type Destination struct {
+	MultiFileOut bool
+	OutputFile   string
+	Location     sinks.OutputLocation
+}
+
+// fileSinkFactory returns a SinkFactory which creates the files with the specified
+// extension at the Destination's Location.
+func (d Destination) fileSinkFactory(fileExtension string) sinks.SinkFactory {
+	if d.Location == (sinks.OutputLocation{}) {
+		return sinks.NewExtensionSwappingFileSinkFactory(fileExtension)
+	}
+
+	return sinks.NewOutputDirFileSinkFactory(d.Location, fileExtension)
 }
 
 // NewExtractionResultsWriter constructs a ResultsWriter configured for extraction.
-func NewExtractionResultsWriter(multiFileOut bool,
-	outputFile,
+func NewExtractionResultsWriter(destination Destination,
 	outputFormat string) (ResultsWriter, error) {
 
 	var resultsFormatter formatters.ResultsFormatter
@@ -31,12 +67,11 @@ func NewExtractionResultsWriter(multiFileOut bool,
 
 	fileExtension := "." + outputFormat
 
-	return newResultsWriter(multiFileOut, outputFile, fileExtension, resultsFormatter)
+	return newResultsWriter(destination, fileExtension, resultsFormatter)
 }
 
 // NewClassificationResultsWriter constructs a ResultsWriter configured for classification.
-func NewClassificationResultsWriter(multiFileOut bool,
-	outputFile,
+func NewClassificationResultsWriter(destination Destination,
 	outputFormat string) (ResultsWriter, error) {
 
 	var resultsFormatter formatters.ResultsFormatter
@@ -52,44 +87,116 @@ func NewClassificationResultsWriter(multiFileOut bool,
 
 	fileExtension := "." + outputFormat
 
-	return newResultsWriter(multiFileOut, outputFile, fileExtension, resultsFormatter)
+	return newResultsWriter(destination, fileExtension, resultsFormatter)
+}
+
+// ReadOutputOptions control which pages of read results are written, and how.
+type ReadOutputOptions struct {
+	// Pages limits the output to the selected pages.
+	Pages pages.Selection
+	// SplitPages writes each page of text results to its own file (other formats
+	// are unaffected).
+	SplitPages bool
 }
 
-// NewReaderResultsWriter constructs a ResultsWriter configured for reading.
-func NewReaderResultsWriter(multiFileOut bool,
-	outputFile, outputFormat string) (ResultsWriter, error) {
+// NewReaderResultsWriter constructs a ResultsWriter configured for reading. The hocr,
+// alto and json formats are converted from read results in the wvdoc format.
+func NewReaderResultsWriter(destination Destination,
+	outputFormat string, options ReadOutputOptions) (ResultsWriter, error) {
 	fileExtension := ".ocr." + outputFormat
 
-	var resultsFormatter formatters.ResultsFormatter = formatters.NewNoopResultsFormatter()
+	if options.SplitPages && outputFormat == "txt" {
+		if !destination.MultiFileOut {
+			return nil, errors.New("you must use '-m' when splitting pages into separate files")
+		}
+
+		return NewIndividualResultsWriter(sinks.NewPageSplittingFileSinkFactory(destination.Location, ".txt",
+			options.Pages),
+			formatters.NewNoopResultsFormatter()), nil
+	}
 
-	return newResultsWriter(multiFileOut, outputFile, fileExtension, resultsFormatter)
+	var resultsFormatter formatters.ResultsFormatter
+
+	switch outputFormat {
+	case formatters.WvdocHOCR, formatters.WvdocJSON:
+		resultsFormatter = formatters.NewWvdocResultsFormatter(outputFormat).WithPages(options.Pages)
+	case formatters.WvdocALTO:
+		fileExtension = ".ocr.alto.xml"
+		resultsFormatter = formatters.NewWvdocResultsFormatter(outputFormat).WithPages(options.Pages)
+	case "txt":
+		resultsFormatter = formatters.NewNoopResultsFormatter()
+		if !options.Pages.IsEmpty() {
+			resultsFormatter = formatters.NewTextPagesResultsFormatter(options.Pages)
+		}
+	default:
+		if !options.Pages.IsEmpty() {
+			return nil, errors.Errorf("pages cannot be selected from the %s format", outputFormat)
+		}
+		resultsFormatter = formatters.NewNoopResultsFormatter()
+	}
+
+	return newResultsWriter(destination, fileExtension, resultsFormatter)
 }
 
-// NewReaderResultsWriter constructs a ResultsWriter configured for reading.
-func NewRedactResultsWriter(multiFileOut bool,
-	outputFile string) (ResultsWriter, error) {
+// NewRedactResultsWriter constructs a ResultsWriter configured for redaction.
+func NewRedactResultsWriter(destination Destination) (ResultsWriter, error) {
 	fileExtension := ".redacted.pdf"
 
 	var resultsFormatter formatters.ResultsFormatter = formatters.NewNoopResultsFormatter()
 
-	return newResultsWriter(multiFileOut, outputFile, fileExtension, resultsFormatter)
+	return newResultsWriter(destination, fileExtension, resultsFormatter)
+}
+
+// NewAuditedRedactResultsWriter constructs a ResultsWriter configured for redaction,
+// which also writes an audit trail for each redacted file alongside it. Results are
+// always written to individual files at the destination's Location.
+func NewAuditedRedactResultsWriter(destination Destination,
+	configuration audit.Configuration) ResultsWriter {
+	const outputExtension = ".redacted.pdf"
+
+	// an audit record is written whenever its redacted file is, so that it always
+	// describes the file alongside it
+	records := destination
+	if records.Location.ExistingFiles == sinks.SkipExisting {
+		records.Location.ExistingFiles = sinks.OverwriteExisting
+	}
+
+	return NewAuditingResultsWriter(
+		NewIndividualResultsWriter(destination.fileSinkFactory(outputExtension),
+			formatters.NewNoopResultsFormatter()),
+		NewIndividualResultsWriter(records.fileSinkFactory(".redaction.json"),
+			formatters.NewJsonAuditRecordFormatter()),
+		outputExtension,
+		configuration).
+		WithOutputLocation(destination.Location)
+}
+
+// NewRedactionRequestResultsWriter constructs a ResultsWriter configured for
+// writing the requests which would be used to redact files.
+func NewRedactionRequestResultsWriter(destination Destination) (ResultsWriter, error) {
+	fileExtension := ".marks.json"
+
+	var resultsFormatter formatters.ResultsFormatter = formatters.NewJsonRedactionRequestFormatter()
+
+	return newResultsWriter(destination, fileExtension, resultsFormatter)
 }
 
-func newResultsWriter(multiFileOut bool, outputFile, fileExtension string,
+func newResultsWriter(destination Destination, fileExtension string,
 	resultsFormatter formatters.ResultsFormatter) (ResultsWriter, error) {
 	var resultsWriter ResultsWriter
 
-	if multiFileOut {
-		sinkFactory := sinks.NewExtensionSwappingFileSinkFactory(fileExtension)
+	if destination.MultiFileOut {
+		sinkFactory := destination.fileSinkFactory(fileExtension)
 
 		resultsWriter = NewIndividualResultsWriter(sinkFactory, resultsFormatter)
 	} else {
-		outFile, err := fs.OpenForWriting(outputFile)
+		var sink sinks.Sink
 
-		if err != nil {
-			return nil, err
+		if destination.OutputFile == "-" || destination.OutputFile == "" {
+			sink = sinks.NewBasicWriterSink(os.Stdout)
+		} else {
+			sink = sinks.NewFileSink(afero.NewOsFs(), destination.OutputFile)
 		}
-		sink := sinks.NewBasicWriterSink(outFile)
 		resultsWriter = NewCombinedResultsWriter(sink, resultsFormatter)
 	}
 
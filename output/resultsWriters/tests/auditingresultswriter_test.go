@@ -0,0 +1,172 @@
+package tests
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/audit"
+	"github.com/waives/surf/ch360/request"
+	"github.com/waives/surf/output/resultsWriters"
+	"github.com/waives/surf/output/resultsWriters/mocks"
+	"github.com/waives/surf/output/sinks"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type AuditingResultsWriterSuite struct {
+	suite.Suite
+	sut           *resultsWriters.AuditingResultsWriter
+	resultsWriter *mocks.ResultsWriter
+	recordsWriter *mocks.ResultsWriter
+	configuration audit.Configuration
+	redactedFile  *audit.RedactedFile
+	contents      []byte
+	written       []byte
+}
+
+func (suite *AuditingResultsWriterSuite) SetupTest() {
+	suite.contents = []byte("redacted pdf")
+	suite.written = nil
+	suite.redactedFile = &audit.RedactedFile{
+		Contents:     ioutil.NopCloser(bytes.NewReader(suite.contents)),
+		SourceSHA256: "source-hash",
+		Request: &request.RedactedPdfRequest{
+			Marks: []request.RedactionMark{{
+				Area:  request.RedactionArea{Top: 1, Left: 2, Bottom: 3, Right: 4, PageNumber: 2},
+				Field: "Name",
+			}},
+			ApplyMarks: true,
+		},
+	}
+	suite.configuration = audit.Configuration{Method: audit.MethodExtractor, Extractor: "extractor"}
+
+	suite.resultsWriter = new(mocks.ResultsWriter)
+	suite.resultsWriter.On("Start").Return(nil)
+	suite.resultsWriter.On("Finish").Return(nil)
+	suite.resultsWriter.On("WriteResult", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			// consume the result, as writing it would
+			suite.written, _ = ioutil.ReadAll(args.Get(1).(io.Reader))
+		}).
+		Return(nil)
+
+	suite.recordsWriter = new(mocks.ResultsWriter)
+	suite.recordsWriter.On("Start").Return(nil)
+	suite.recordsWriter.On("Finish").Return(nil)
+	suite.recordsWriter.On("WriteResult", mock.Anything, mock.Anything).Return(nil)
+
+	suite.sut = resultsWriters.NewAuditingResultsWriter(suite.resultsWriter, suite.recordsWriter,
+		".redacted.pdf", suite.configuration)
+}
+
+func TestAuditingResultsWriterRunner(t *testing.T) {
+	suite.Run(t, new(AuditingResultsWriterSuite))
+}
+
+func (suite *AuditingResultsWriterSuite) TestStart_And_Finish_Both_Writers() {
+	require.Nil(suite.T(), suite.sut.Start())
+	require.Nil(suite.T(), suite.sut.Finish())
+
+	suite.resultsWriter.AssertCalled(suite.T(), "Start")
+	suite.recordsWriter.AssertCalled(suite.T(), "Start")
+	suite.resultsWriter.AssertCalled(suite.T(), "Finish")
+	suite.recordsWriter.AssertCalled(suite.T(), "Finish")
+}
+
+func (suite *AuditingResultsWriterSuite) TestWriteResult_Writes_Redacted_File() {
+	err := suite.sut.WriteResult("document.pdf", suite.redactedFile)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), suite.contents, suite.written)
+}
+
+func (suite *AuditingResultsWriterSuite) TestWriteResult_Writes_Audit_Record() {
+	filename := filepath.Join("folder", "document.pdf")
+	outputHash := sha256.Sum256(suite.contents)
+
+	err := suite.sut.WriteResult(filename, suite.redactedFile)
+
+	require.Nil(suite.T(), err)
+	record := suite.recordsWriter.Calls[0].Arguments.Get(1).(*audit.Record)
+	assert.Equal(suite.T(), filename, suite.recordsWriter.Calls[0].Arguments.Get(0))
+	assert.Equal(suite.T(), filename, record.SourceFile)
+	assert.Equal(suite.T(), "source-hash", record.SourceSHA256)
+	assert.Equal(suite.T(), filepath.Join("folder", "document.redacted.pdf"), record.OutputFile)
+	assert.Equal(suite.T(), hex.EncodeToString(outputHash[:]), record.OutputSHA256)
+	assert.WithinDuration(suite.T(), time.Now(), record.RedactedAt, time.Minute)
+	assert.Equal(suite.T(), suite.configuration, record.Configuration)
+	assert.True(suite.T(), record.MarksApplied)
+	assert.Equal(suite.T(), []audit.Mark{
+		{PageNumber: 2, Top: 1, Left: 2, Bottom: 3, Right: 4, Field: "Name"},
+	}, record.Marks)
+}
+
+func (suite *AuditingResultsWriterSuite) TestWriteResult_Records_Output_File_In_Output_Location() {
+	filename := filepath.Join("folder", "document.pdf")
+	suite.sut.WithOutputLocation(sinks.OutputLocation{OutputDir: "results"})
+
+	err := suite.sut.WriteResult(filename, suite.redactedFile)
+
+	require.Nil(suite.T(), err)
+	record := suite.recordsWriter.Calls[0].Arguments.Get(1).(*audit.Record)
+	assert.Equal(suite.T(), filepath.Join("results", "folder", "document.redacted.pdf"), record.OutputFile)
+}
+
+func (suite *AuditingResultsWriterSuite) TestWriteResult_Does_Not_Write_Record_If_Writing_File_Fails() {
+	suite.resultsWriter.ExpectedCalls = nil
+	expectedErr := errors.New("simulated error")
+	suite.resultsWriter.On("WriteResult", mock.Anything, mock.Anything).Return(expectedErr)
+
+	err := suite.sut.WriteResult("document.pdf", suite.redactedFile)
+
+	assert.Equal(suite.T(), expectedErr, err)
+	suite.recordsWriter.AssertNotCalled(suite.T(), "WriteResult", mock.Anything, mock.Anything)
+}
+
+func (suite *AuditingResultsWriterSuite) TestWriteResult_Does_Not_Write_Record_If_File_Is_Skipped() {
+	suite.sut = resultsWriters.NewAuditingResultsWriter(skippingResultsWriter{suite.resultsWriter},
+		suite.recordsWriter, ".redacted.pdf", suite.configuration)
+
+	err := suite.sut.WriteResult("document.pdf", suite.redactedFile)
+
+	assert.Nil(suite.T(), err)
+	suite.resultsWriter.AssertNotCalled(suite.T(), "WriteResult", mock.Anything, mock.Anything)
+	suite.recordsWriter.AssertNotCalled(suite.T(), "WriteResult", mock.Anything, mock.Anything)
+}
+
+func (suite *AuditingResultsWriterSuite) TestSkipsOutputFor_Skips_Files_Whose_Redacted_File_Is_Skipped() {
+	skipped, err := resultsWriters.SkipsOutputFor(suite.sut, "document.pdf")
+	require.NoError(suite.T(), err)
+	assert.False(suite.T(), skipped)
+
+	suite.sut = resultsWriters.NewAuditingResultsWriter(skippingResultsWriter{suite.resultsWriter},
+		suite.recordsWriter, ".redacted.pdf", suite.configuration)
+
+	skipped, err = resultsWriters.SkipsOutputFor(suite.sut, "document.pdf")
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), skipped)
+}
+
+func (suite *AuditingResultsWriterSuite) TestWriteResult_Returns_Error_For_Unexpected_Type() {
+	err := suite.sut.WriteResult("document.pdf", "not a redacted file")
+
+	assert.Error(suite.T(), err)
+}
+
+// skippingResultsWriter is a ResultsWriter whose output files all exist already, and
+// are skipped.
+type skippingResultsWriter struct {
+	*mocks.ResultsWriter
+}
+
+func (w skippingResultsWriter) SkipsOutputFor(filename string) (bool, error) {
+	return true, nil
+}
@@ -27,6 +27,7 @@ func (suite *CombinedResultsWriterSuite) SetupTest() {
 	suite.sink = new(sinkMocks.Sink)
 	suite.sink.On("Open").Return(nil)
 	suite.sink.On("Close").Return(nil)
+	suite.sink.On("Abort").Return(nil)
 
 	suite.formatter = new(formatterMocks.ResultsFormatter)
 	suite.formatter.On("WriteResult", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
@@ -114,6 +115,14 @@ func (suite *CombinedResultsWriterSuite) TestFinish_Returns_Error_From_SinkClose
 	assert.Equal(suite.T(), expectedErr, err)
 }
 
+func (suite *CombinedResultsWriterSuite) TestAbort_Aborts_Sink() {
+	err := suite.sut.Abort()
+
+	assert.Nil(suite.T(), err)
+	suite.sink.AssertCalled(suite.T(), "Abort")
+	suite.sink.AssertNotCalled(suite.T(), "Close")
+}
+
 type fakeSink struct {
 	IsOpen bool
 }
@@ -127,6 +136,10 @@ func (f *fakeSink) Close() error {
 	return nil
 }
 
+func (f *fakeSink) Abort() error {
+	return nil
+}
+
 func (f *fakeSink) Write(b []byte) (int, error) {
 	if f.IsOpen {
 		return 0, nil
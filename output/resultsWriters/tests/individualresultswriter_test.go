@@ -28,6 +28,7 @@ func (suite *IndividualResultsWriterSuite) SetupTest() {
 	suite.sink = new(sinkMocks.Sink)
 	suite.sink.On("Open").Return(nil)
 	suite.sink.On("Close").Return(nil)
+	suite.sink.On("Abort").Return(nil)
 
 	suite.sinkFactory = new(sinkMocks.SinkFactory)
 	suite.sinkFactory.On("Sink", mock.Anything).Return(suite.sink, nil)
@@ -125,3 +126,14 @@ func (suite *IndividualResultsWriterSuite) TestWriteResults_Returns_Error_From_C
 	err := suite.sut.WriteResult(suite.filename, suite.classificationResult)
 	assert.Equal(suite.T(), expectedErr, err)
 }
+
+func (suite *IndividualResultsWriterSuite) TestWriteResults_Aborts_Sink_On_Error_From_WriteResult() {
+	suite.formatter.ExpectedCalls = nil
+	suite.formatter.On("WriteResult", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("expectedError"))
+
+	suite.sut.WriteResult(suite.filename, suite.classificationResult)
+
+	suite.sink.AssertCalled(suite.T(), "Abort")
+	suite.sink.AssertNotCalled(suite.T(), "Close")
+}
@@ -0,0 +1,51 @@
+package tests
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/waives/surf/output/resultsWriters"
+	"github.com/waives/surf/pages"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReaderResultsWriter_Splits_Text_Pages_Into_Files(t *testing.T) {
+	dir, err := ioutil.TempDir("", "surf")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	sut, err := resultsWriters.NewReaderResultsWriter(resultsWriters.Destination{MultiFileOut: true}, "txt",
+		resultsWriters.ReadOutputOptions{SplitPages: true, Pages: pages.Selection{{First: 2, Last: 3}}})
+	require.NoError(t, err)
+
+	err = sut.WriteResult(filepath.Join(dir, "document.pdf"),
+		ioutil.NopCloser(strings.NewReader("one\ftwo\fthree")))
+	require.NoError(t, err)
+
+	written, err := filepath.Glob(filepath.Join(dir, "*"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "document.page-0002.txt"),
+		filepath.Join(dir, "document.page-0003.txt"),
+	}, written)
+}
+
+func TestReaderResultsWriter_Requires_MultiFileOut_To_Split_Pages(t *testing.T) {
+	_, err := resultsWriters.NewReaderResultsWriter(resultsWriters.Destination{}, "txt",
+		resultsWriters.ReadOutputOptions{SplitPages: true})
+
+	assert.Error(t, err)
+}
+
+func TestReaderResultsWriter_Rejects_Page_Selection_For_Binary_Formats(t *testing.T) {
+	for _, outputFormat := range []string{"pdf", "wvdoc"} {
+		_, err := resultsWriters.NewReaderResultsWriter(resultsWriters.Destination{MultiFileOut: true},
+			outputFormat,
+			resultsWriters.ReadOutputOptions{Pages: pages.Selection{{First: 1, Last: 1}}})
+
+		assert.Error(t, err, outputFormat)
+	}
+}
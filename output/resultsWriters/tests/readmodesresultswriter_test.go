@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/output/resultsWriters"
+	"github.com/waives/surf/output/resultsWriters/mocks"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+type ReadModesResultsWriterSuite struct {
+	suite.Suite
+	sut         *resultsWriters.ReadModesResultsWriter
+	textWriter  *mocks.ResultsWriter
+	wvdocWriter *mocks.ResultsWriter
+	hocrWriter  *mocks.ResultsWriter
+	written     map[*mocks.ResultsWriter]string
+}
+
+func (suite *ReadModesResultsWriterSuite) SetupTest() {
+	suite.written = map[*mocks.ResultsWriter]string{}
+	suite.textWriter = suite.newResultsWriter()
+	suite.wvdocWriter = suite.newResultsWriter()
+	suite.hocrWriter = suite.newResultsWriter()
+
+	suite.sut = resultsWriters.NewReadModesResultsWriter(
+		resultsWriters.ReadModeOutput{Mode: ch360.ReadText, ResultsWriter: suite.textWriter},
+		resultsWriters.ReadModeOutput{Mode: ch360.ReadWvdoc, ResultsWriter: suite.wvdocWriter},
+		resultsWriters.ReadModeOutput{Mode: ch360.ReadWvdoc, ResultsWriter: suite.hocrWriter},
+	)
+}
+
+func (suite *ReadModesResultsWriterSuite) newResultsWriter() *mocks.ResultsWriter {
+	resultsWriter := new(mocks.ResultsWriter)
+	resultsWriter.On("Start").Return(nil)
+	resultsWriter.On("Finish").Return(nil)
+	resultsWriter.On("WriteResult", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			written, _ := ioutil.ReadAll(args.Get(1).(io.Reader))
+			suite.written[resultsWriter] = string(written)
+		}).
+		Return(nil)
+
+	return resultsWriter
+}
+
+func TestReadModesResultsWriterRunner(t *testing.T) {
+	suite.Run(t, new(ReadModesResultsWriterSuite))
+}
+
+func readResult(contents string) io.ReadCloser {
+	return ioutil.NopCloser(bytes.NewBufferString(contents))
+}
+
+func (suite *ReadModesResultsWriterSuite) Test_Writes_Each_Result_With_Writers_For_Its_Mode() {
+	err := suite.sut.WriteResult("file.tif", map[ch360.ReadMode]io.ReadCloser{
+		ch360.ReadText:  readResult("text"),
+		ch360.ReadWvdoc: readResult("wvdoc"),
+	})
+
+	suite.Require().NoError(err)
+	suite.Assert().Equal("text", suite.written[suite.textWriter])
+	suite.Assert().Equal("wvdoc", suite.written[suite.wvdocWriter])
+	suite.Assert().Equal("wvdoc", suite.written[suite.hocrWriter])
+	suite.textWriter.AssertCalled(suite.T(), "WriteResult", "file.tif", mock.Anything)
+}
+
+func (suite *ReadModesResultsWriterSuite) Test_Returns_Error_If_Result_Is_Missing_For_A_Mode() {
+	err := suite.sut.WriteResult("file.tif", map[ch360.ReadMode]io.ReadCloser{
+		ch360.ReadText: readResult("text"),
+	})
+
+	suite.Assert().EqualError(err, "There is no wvdoc read result for file.tif")
+}
+
+func (suite *ReadModesResultsWriterSuite) Test_Returns_Error_For_Unexpected_Result_Type() {
+	err := suite.sut.WriteResult("file.tif", readResult("text"))
+
+	suite.Assert().Error(err)
+	suite.textWriter.AssertNotCalled(suite.T(), "WriteResult", mock.Anything, mock.Anything)
+}
+
+func (suite *ReadModesResultsWriterSuite) Test_Starts_And_Finishes_Each_Writer() {
+	suite.Require().NoError(suite.sut.Start())
+	suite.Require().NoError(suite.sut.Finish())
+
+	for _, resultsWriter := range []*mocks.ResultsWriter{suite.textWriter, suite.wvdocWriter,
+		suite.hocrWriter} {
+		resultsWriter.AssertCalled(suite.T(), "Start")
+		resultsWriter.AssertCalled(suite.T(), "Finish")
+	}
+}
@@ -0,0 +1,96 @@
+package sinks
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"os"
+	"path/filepath"
+)
+
+// atomicFile is written to a temporary file in the same directory as its filename, which
+// is only renamed to the filename when the file is committed. Output which is not committed
+// (as when a job fails, or is cancelled) therefore never appears under the filename.
+type atomicFile struct {
+	fileSystem afero.Fs
+	filename   string
+	temp       afero.File
+}
+
+// createAtomicFile creates the temporary file for filename, creating its directory if
+// it does not exist.
+func createAtomicFile(fileSystem afero.Fs, filename string) (*atomicFile, error) {
+	dir := filepath.Dir(filename)
+
+	err := fileSystem.MkdirAll(dir, 0777)
+	if err != nil {
+		return nil, err
+	}
+
+	temp, err := createTempFile(fileSystem, dir, "."+filepath.Base(filename)+".tmp")
+	if err != nil {
+		return nil, err
+	}
+
+	return &atomicFile{
+		fileSystem: fileSystem,
+		filename:   filename,
+		temp:       temp,
+	}, nil
+}
+
+// createTempFile creates a new file in dir, with a name beginning with prefix. Unlike
+// afero.TempFile, which makes the file readable only by its owner, it is created with the
+// same permissions as os.Create uses (0666, before the umask), as it becomes the output file.
+func createTempFile(fileSystem afero.Fs, dir, prefix string) (afero.File, error) {
+	suffix := make([]byte, 8)
+
+	for i := 0; i < 100; i++ {
+		if _, err := rand.Read(suffix); err != nil {
+			return nil, err
+		}
+
+		name := filepath.Join(dir, prefix+hex.EncodeToString(suffix))
+		file, err := fileSystem.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0666)
+		if os.IsExist(err) {
+			continue
+		}
+
+		return file, err
+	}
+
+	return nil, errors.Errorf("unable to create a temporary file in %s", dir)
+}
+
+func (f *atomicFile) Write(b []byte) (int, error) {
+	return f.temp.Write(b)
+}
+
+// Commit flushes the temporary file to disk, closes it and renames it to the filename,
+// replacing any existing file. The file is flushed first so that, after a crash, the
+// filename never refers to a file whose contents were not written.
+func (f *atomicFile) Commit() error {
+	if err := f.temp.Sync(); err != nil {
+		f.Abort()
+		return err
+	}
+
+	if err := f.temp.Close(); err != nil {
+		f.fileSystem.Remove(f.temp.Name())
+		return err
+	}
+
+	if err := f.fileSystem.Rename(f.temp.Name(), f.filename); err != nil {
+		f.fileSystem.Remove(f.temp.Name())
+		return err
+	}
+
+	return nil
+}
+
+// Abort closes and removes the temporary file, leaving any existing file in place.
+func (f *atomicFile) Abort() error {
+	f.temp.Close()
+	return f.fileSystem.Remove(f.temp.Name())
+}
@@ -27,6 +27,11 @@ func (f *BasicWriterSink) Close() error {
 	return nil
 }
 
+// Abort does nothing, as anything already written to the writer cannot be discarded.
+func (f *BasicWriterSink) Abort() error {
+	return nil
+}
+
 func (f *BasicWriterSink) Write(b []byte) (int, error) {
 	return f.writer.Write(b)
 }
@@ -7,21 +7,25 @@ import (
 )
 
 // The ExtensionSwappingFileSink creates a file adjacent to the specified inputFilename (but with the specified extension),
-// overwriting if necessary and returns an io.Writer that is that file
+// overwriting if necessary and returns an io.Writer that is that file. The directory of the inputFilename
+// is created if it does not exist (as when the input is an archive entry). The file is written to a
+// temporary file, which only replaces the destination file when the sink is closed.
 type ExtensionSwappingFileSink struct {
 	fileSystem          afero.Fs
 	destinationFilename string
-	file                afero.File
+	file                *atomicFile
 }
 
 func NewExtensionSwappingFileSink(fileSystem afero.Fs, fileExtension string, inputFilename string) *ExtensionSwappingFileSink {
 	return &ExtensionSwappingFileSink{
 		fileSystem:          fileSystem,
-		destinationFilename: replaceFileExtension(inputFilename, fileExtension),
+		destinationFilename: ReplaceFileExtension(inputFilename, fileExtension),
 	}
 }
 
-func replaceFileExtension(fullPath string, newFileExtension string) string {
+// ReplaceFileExtension returns the path of the file an ExtensionSwappingFileSink
+// writes to for the provided input file.
+func ReplaceFileExtension(fullPath string, newFileExtension string) string {
 	// the destinationFilename without the path
 	filename := filepath.Base(fullPath)
 
@@ -34,13 +38,29 @@ func replaceFileExtension(fullPath string, newFileExtension string) string {
 }
 
 func (f *ExtensionSwappingFileSink) Open() error {
-	file, err := f.fileSystem.Create(f.destinationFilename)
+	file, err := createAtomicFile(f.fileSystem, f.destinationFilename)
 	f.file = file
 	return err
 }
 
 func (f *ExtensionSwappingFileSink) Close() error {
-	return f.file.Close()
+	if f.file == nil {
+		return nil
+	}
+
+	file := f.file
+	f.file = nil
+	return file.Commit()
+}
+
+func (f *ExtensionSwappingFileSink) Abort() error {
+	if f.file == nil {
+		return nil
+	}
+
+	file := f.file
+	f.file = nil
+	return file.Abort()
 }
 
 func (f *ExtensionSwappingFileSink) Write(b []byte) (int, error) {
@@ -0,0 +1,53 @@
+package sinks
+
+import (
+	"github.com/spf13/afero"
+)
+
+var _ Sink = (*FileSink)(nil)
+
+// The FileSink writes to the specified file (as when all results are written to a single
+// output file). The file is written to a temporary file, which only replaces it when the
+// sink is closed.
+type FileSink struct {
+	fileSystem afero.Fs
+	filename   string
+	file       *atomicFile
+}
+
+func NewFileSink(fileSystem afero.Fs, filename string) *FileSink {
+	return &FileSink{
+		fileSystem: fileSystem,
+		filename:   filename,
+	}
+}
+
+func (f *FileSink) Open() error {
+	file, err := createAtomicFile(f.fileSystem, f.filename)
+	f.file = file
+	return err
+}
+
+func (f *FileSink) Close() error {
+	if f.file == nil {
+		return nil
+	}
+
+	file := f.file
+	f.file = nil
+	return file.Commit()
+}
+
+func (f *FileSink) Abort() error {
+	if f.file == nil {
+		return nil
+	}
+
+	file := f.file
+	f.file = nil
+	return file.Abort()
+}
+
+func (f *FileSink) Write(b []byte) (int, error) {
+	return f.file.Write(b)
+}
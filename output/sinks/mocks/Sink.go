@@ -9,6 +9,20 @@ type Sink struct {
 	mock.Mock
 }
 
+// Abort provides a mock function with given fields:
+func (_m *Sink) Abort() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Close provides a mock function with given fields:
 func (_m *Sink) Close() error {
 	ret := _m.Called()
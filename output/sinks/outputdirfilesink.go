@@ -0,0 +1,62 @@
+package sinks
+
+import (
+	"github.com/spf13/afero"
+)
+
+var _ Sink = (*OutputDirFileSink)(nil)
+
+// The OutputDirFileSink creates the file for the specified inputFilename (with the specified
+// extension) at an OutputLocation, recreating the directory structure of the input files
+// under its OutputDir, and returns an io.Writer that is that file. If the location's policy
+// is to skip existing files, anything written for an existing file is discarded. As with the
+// ExtensionSwappingFileSink, the file only replaces the destination file when the sink is closed.
+type OutputDirFileSink struct {
+	fileSystem          afero.Fs
+	location            OutputLocation
+	destinationFilename string
+	file                *atomicFile
+}
+
+func NewOutputDirFileSink(fileSystem afero.Fs, location OutputLocation, fileExtension string,
+	inputFilename string) *OutputDirFileSink {
+	return &OutputDirFileSink{
+		fileSystem:          fileSystem,
+		location:            location,
+		destinationFilename: location.Filename(inputFilename, fileExtension),
+	}
+}
+
+func (f *OutputDirFileSink) Open() error {
+	file, err := f.location.create(f.fileSystem, f.destinationFilename)
+	f.file = file
+	return err
+}
+
+func (f *OutputDirFileSink) Close() error {
+	if f.file == nil {
+		return nil
+	}
+
+	file := f.file
+	f.file = nil
+	return file.Commit()
+}
+
+func (f *OutputDirFileSink) Abort() error {
+	if f.file == nil {
+		return nil
+	}
+
+	file := f.file
+	f.file = nil
+	return file.Abort()
+}
+
+func (f *OutputDirFileSink) Write(b []byte) (int, error) {
+	if f.file == nil {
+		// skipping an existing file
+		return len(b), nil
+	}
+	return f.file.Write(b)
+}
@@ -0,0 +1,28 @@
+package sinks
+
+import "github.com/spf13/afero"
+
+type OutputDirFileSinkFactory struct {
+	location      OutputLocation
+	fileExtension string
+}
+
+// The OutputDirFileSinkFactory returns a new OutputDirFileSink (pointing to a new destination
+// file at the OutputLocation) each time Sink is called
+func NewOutputDirFileSinkFactory(location OutputLocation,
+	fileExtension string) *OutputDirFileSinkFactory {
+	return &OutputDirFileSinkFactory{
+		location:      location,
+		fileExtension: fileExtension,
+	}
+}
+
+func (p *OutputDirFileSinkFactory) Sink(params SinkParams) (Sink, error) {
+	return NewOutputDirFileSink(afero.NewOsFs(), p.location, p.fileExtension, params.InputFilename), nil
+}
+
+// SkipsOutputFor returns true if the output file for the input file already exists, and
+// the location's policy is to skip existing files.
+func (p *OutputDirFileSinkFactory) SkipsOutputFor(inputFilename string) (bool, error) {
+	return p.location.SkipsOutput(afero.NewOsFs(), inputFilename, p.fileExtension)
+}
@@ -0,0 +1,95 @@
+package sinks
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExistingFilePolicy decides what happens when an output file already exists.
+type ExistingFilePolicy int
+
+const (
+	// OverwriteExisting replaces existing files.
+	OverwriteExisting ExistingFilePolicy = iota
+	// SkipExisting leaves existing files as they are, discarding the new output.
+	SkipExisting
+	// FailIfExists returns an error if the file already exists.
+	FailIfExists
+)
+
+// OutputLocation decides where the output files for an input file are written. The
+// zero value writes them adjacent to the input file, overwriting any existing files.
+type OutputLocation struct {
+	// OutputDir, if set, is the directory under which the directory structure of the
+	// input files is recreated, and their output files written.
+	OutputDir     string
+	ExistingFiles ExistingFilePolicy
+}
+
+// Filename returns the path of the output file for the input file, with its extension
+// replaced with the specified one.
+func (l OutputLocation) Filename(inputFilename, fileExtension string) string {
+	filename := ReplaceFileExtension(inputFilename, fileExtension)
+
+	if l.OutputDir == "" {
+		return filename
+	}
+
+	return filepath.Join(l.OutputDir, relativePath(filename))
+}
+
+// relativePath returns the path of the file relative to the working directory or, if it
+// is outside of it, its absolute path without the volume name and root.
+func relativePath(filename string) string {
+	absolute, err := filepath.Abs(filename)
+	if err != nil {
+		return filename
+	}
+
+	if workingDir, err := os.Getwd(); err == nil {
+		relative, err := filepath.Rel(workingDir, absolute)
+		if err == nil && relative != ".." &&
+			!strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+			return relative
+		}
+	}
+
+	absolute = strings.TrimPrefix(absolute, filepath.VolumeName(absolute))
+	return strings.TrimLeft(absolute, `/\`)
+}
+
+// SkipsOutput returns true if the output file (with the specified extension) for the input
+// file already exists, and the policy is to skip existing files, so anything written for
+// it would be discarded.
+func (l OutputLocation) SkipsOutput(fileSystem afero.Fs, inputFilename,
+	fileExtension string) (bool, error) {
+	if l.ExistingFiles != SkipExisting {
+		return false, nil
+	}
+
+	return afero.Exists(fileSystem, l.Filename(inputFilename, fileExtension))
+}
+
+// create creates the (atomic) file, and its directory, according to the ExistingFiles policy.
+// It returns a nil file, without an error, if an existing file is to be skipped.
+func (l OutputLocation) create(fileSystem afero.Fs, filename string) (*atomicFile, error) {
+	if l.ExistingFiles != OverwriteExisting {
+		exists, err := afero.Exists(fileSystem, filename)
+		if err != nil {
+			return nil, err
+		}
+
+		if exists && l.ExistingFiles == SkipExisting {
+			return nil, nil
+		}
+
+		if exists {
+			return nil, errors.Errorf("The output file %s already exists", filename)
+		}
+	}
+
+	return createAtomicFile(fileSystem, filename)
+}
@@ -0,0 +1,86 @@
+package sinks
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/spf13/afero"
+	"github.com/waives/surf/pages"
+)
+
+var _ Sink = (*PageSplittingFileSink)(nil)
+
+// The PageSplittingFileSink splits the text written to it into pages (at each form feed)
+// and, when closed, writes each selected page to a file for the specified inputFilename
+// at an OutputLocation, with the page number and specified extension (e.g. name.page-0001.txt).
+// Nothing is written if the sink is aborted.
+type PageSplittingFileSink struct {
+	fileSystem    afero.Fs
+	location      OutputLocation
+	fileExtension string
+	inputFilename string
+	selection     pages.Selection
+	buffer        bytes.Buffer
+}
+
+func NewPageSplittingFileSink(fileSystem afero.Fs, location OutputLocation, fileExtension string,
+	inputFilename string, selection pages.Selection) *PageSplittingFileSink {
+	return &PageSplittingFileSink{
+		fileSystem:    fileSystem,
+		location:      location,
+		fileExtension: fileExtension,
+		inputFilename: inputFilename,
+		selection:     selection,
+	}
+}
+
+// PageFileExtension returns the extension of the file a PageSplittingFileSink writes
+// the page to.
+func PageFileExtension(pageNumber int, fileExtension string) string {
+	return fmt.Sprintf(".page-%04d%s", pageNumber, fileExtension)
+}
+
+func (f *PageSplittingFileSink) Open() error {
+	f.buffer.Reset()
+	return nil
+}
+
+func (f *PageSplittingFileSink) Close() error {
+	for i, page := range pages.SplitText(f.buffer.Bytes()) {
+		pageNumber := i + 1
+
+		if !f.selection.Includes(pageNumber) {
+			continue
+		}
+
+		if err := f.writePage(pageNumber, page); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *PageSplittingFileSink) writePage(pageNumber int, page []byte) error {
+	filename := f.location.Filename(f.inputFilename, PageFileExtension(pageNumber, f.fileExtension))
+
+	file, err := f.location.create(f.fileSystem, filename)
+	if err != nil || file == nil {
+		return err
+	}
+
+	if _, err := file.Write(page); err != nil {
+		file.Abort()
+		return err
+	}
+
+	return file.Commit()
+}
+
+func (f *PageSplittingFileSink) Abort() error {
+	f.buffer.Reset()
+	return nil
+}
+
+func (f *PageSplittingFileSink) Write(b []byte) (int, error) {
+	return f.buffer.Write(b)
+}
@@ -0,0 +1,28 @@
+package sinks
+
+import (
+	"github.com/spf13/afero"
+	"github.com/waives/surf/pages"
+)
+
+type PageSplittingFileSinkFactory struct {
+	location      OutputLocation
+	fileExtension string
+	selection     pages.Selection
+}
+
+// The PageSplittingFileSinkFactory returns a new PageSplittingFileSink (writing the selected
+// pages of a new destination file at the OutputLocation) each time Sink is called
+func NewPageSplittingFileSinkFactory(location OutputLocation, fileExtension string,
+	selection pages.Selection) *PageSplittingFileSinkFactory {
+	return &PageSplittingFileSinkFactory{
+		location:      location,
+		fileExtension: fileExtension,
+		selection:     selection,
+	}
+}
+
+func (p *PageSplittingFileSinkFactory) Sink(params SinkParams) (Sink, error) {
+	return NewPageSplittingFileSink(afero.NewOsFs(), p.location, p.fileExtension,
+		params.InputFilename, p.selection), nil
+}
@@ -11,9 +11,19 @@ type SinkFactory interface {
 	Sink(params SinkParams) (Sink, error)
 }
 
+// OutputSkipper is implemented by SinkFactories which can tell, before an input file is
+// processed, that its output would be discarded because its output file already exists.
+type OutputSkipper interface {
+	SkipsOutputFor(inputFilename string) (bool, error)
+}
+
 //go:generate mockery -name Sink
 type Sink interface {
 	Open() error
 	io.Closer
 	io.Writer
+	// Abort discards anything written since the sink was opened, where possible. It is
+	// called instead of Close when the results are incomplete (as when processing failed,
+	// or was cancelled).
+	Abort() error
 }
@@ -49,3 +49,33 @@ func (suite *ExtensionSwappingFileSinkSuite) TestWritesFile_With_Correct_Filenam
 	require.Nil(suite.T(), err)
 	assert.Equal(suite.T(), suite.filecontents, fmt.Sprintf("%s", contents))
 }
+
+func (suite *ExtensionSwappingFileSinkSuite) TestCreates_Missing_Directory() {
+	sut := sinks.NewExtensionSwappingFileSink(suite.fileSystem, suite.newExtension, "archive/dir/entry.tif")
+
+	require.NoError(suite.T(), sut.Open())
+	fmt.Fprint(sut, suite.filecontents)
+	require.NoError(suite.T(), sut.Close())
+
+	contents, err := afero.ReadFile(suite.fileSystem, "archive/dir/entry.ext")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), suite.filecontents, string(contents))
+}
+
+func (suite *ExtensionSwappingFileSinkSuite) TestFile_Is_Not_Written_Until_Closed() {
+	require.NoError(suite.T(), suite.sut.Open())
+	fmt.Fprint(suite.sut, suite.filecontents)
+
+	fileExists, _ := afero.Exists(suite.fileSystem, suite.expectedDestinationFilename)
+	assert.False(suite.T(), fileExists)
+}
+
+func (suite *ExtensionSwappingFileSinkSuite) TestAbort_Leaves_No_Files() {
+	require.NoError(suite.T(), suite.sut.Open())
+	fmt.Fprint(suite.sut, suite.filecontents)
+	require.NoError(suite.T(), suite.sut.Abort())
+
+	files, err := afero.ReadDir(suite.fileSystem, "/var/folder")
+	require.NoError(suite.T(), err)
+	assert.Empty(suite.T(), files)
+}
@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/output/sinks"
+	"os"
+	"testing"
+)
+
+type FileSinkSuite struct {
+	suite.Suite
+	sut        *sinks.FileSink
+	fileSystem afero.Fs
+	filename   string
+}
+
+func (suite *FileSinkSuite) SetupTest() {
+	suite.fileSystem = afero.NewMemMapFs()
+	suite.filename = "/results/out.csv"
+	suite.sut = sinks.NewFileSink(suite.fileSystem, suite.filename)
+
+	require.NoError(suite.T(), afero.WriteFile(suite.fileSystem, suite.filename, []byte("existing"), 0644))
+}
+
+func TestFileSinkRunner(t *testing.T) {
+	suite.Run(t, new(FileSinkSuite))
+}
+
+func (suite *FileSinkSuite) assertOnlyFile(expectedContents string) {
+	files, err := afero.ReadDir(suite.fileSystem, "/results")
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), files, 1)
+
+	contents, err := afero.ReadFile(suite.fileSystem, suite.filename)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedContents, string(contents))
+}
+
+func (suite *FileSinkSuite) TestClose_Replaces_File() {
+	require.NoError(suite.T(), suite.sut.Open())
+	fmt.Fprint(suite.sut, "contents")
+	contents, _ := afero.ReadFile(suite.fileSystem, suite.filename)
+	assert.Equal(suite.T(), "existing", string(contents))
+
+	require.NoError(suite.T(), suite.sut.Close())
+
+	suite.assertOnlyFile("contents")
+}
+
+func (suite *FileSinkSuite) TestAbort_Leaves_Existing_File() {
+	require.NoError(suite.T(), suite.sut.Open())
+	fmt.Fprint(suite.sut, "partial")
+
+	require.NoError(suite.T(), suite.sut.Abort())
+
+	suite.assertOnlyFile("existing")
+}
+
+func (suite *FileSinkSuite) TestClose_Leaves_Existing_File_If_It_Cannot_Be_Flushed() {
+	suite.sut = sinks.NewFileSink(&syncFailingFs{Fs: suite.fileSystem}, suite.filename)
+	require.NoError(suite.T(), suite.sut.Open())
+	fmt.Fprint(suite.sut, "contents")
+
+	assert.Error(suite.T(), suite.sut.Close())
+
+	suite.assertOnlyFile("existing")
+}
+
+// syncFailingFs opens files which cannot be flushed to disk.
+type syncFailingFs struct {
+	afero.Fs
+}
+
+func (fs *syncFailingFs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	file, err := fs.Fs.OpenFile(name, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+
+	return &syncFailingFile{File: file}, nil
+}
+
+type syncFailingFile struct {
+	afero.File
+}
+
+func (f *syncFailingFile) Sync() error {
+	return errors.New("simulated error")
+}
@@ -0,0 +1,143 @@
+package tests
+
+import (
+	"fmt"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/output/sinks"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type OutputDirFileSinkSuite struct {
+	suite.Suite
+	fileSystem afero.Fs
+	outputDir  string
+}
+
+func (suite *OutputDirFileSinkSuite) SetupTest() {
+	suite.fileSystem = afero.NewMemMapFs()
+	suite.outputDir = filepath.FromSlash("/results")
+}
+
+func TestOutputDirFileSinkRunner(t *testing.T) {
+	suite.Run(t, new(OutputDirFileSinkSuite))
+}
+
+func (suite *OutputDirFileSinkSuite) write(policy sinks.ExistingFilePolicy, inputFilename,
+	contents string) error {
+	location := sinks.OutputLocation{OutputDir: suite.outputDir, ExistingFiles: policy}
+	sut := sinks.NewOutputDirFileSink(suite.fileSystem, location, ".ext", inputFilename)
+
+	if err := sut.Open(); err != nil {
+		return err
+	}
+	fmt.Fprint(sut, contents)
+	return sut.Close()
+}
+
+func (suite *OutputDirFileSinkSuite) assertFile(filename, expected string) {
+	contents, err := afero.ReadFile(suite.fileSystem, filepath.FromSlash(filename))
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expected, string(contents))
+}
+
+func (suite *OutputDirFileSinkSuite) TestWrites_File_Under_Output_Dir_With_Relative_Path() {
+	err := suite.write(sinks.OverwriteExisting, filepath.FromSlash("invoices/2019/1.tif"), "contents")
+
+	require.NoError(suite.T(), err)
+	suite.assertFile("/results/invoices/2019/1.ext", "contents")
+}
+
+func (suite *OutputDirFileSinkSuite) TestOverwrites_Existing_File() {
+	require.NoError(suite.T(), suite.write(sinks.OverwriteExisting, "1.tif", "first"))
+	require.NoError(suite.T(), suite.write(sinks.OverwriteExisting, "1.tif", "second"))
+
+	suite.assertFile("/results/1.ext", "second")
+}
+
+func (suite *OutputDirFileSinkSuite) TestSkips_Existing_File() {
+	require.NoError(suite.T(), suite.write(sinks.SkipExisting, "1.tif", "first"))
+	require.NoError(suite.T(), suite.write(sinks.SkipExisting, "1.tif", "second"))
+
+	suite.assertFile("/results/1.ext", "first")
+}
+
+func (suite *OutputDirFileSinkSuite) TestFails_If_File_Exists() {
+	require.NoError(suite.T(), suite.write(sinks.FailIfExists, "1.tif", "first"))
+	err := suite.write(sinks.FailIfExists, "1.tif", "second")
+
+	assert.EqualError(suite.T(), err, "The output file "+filepath.FromSlash("/results/1.ext")+
+		" already exists")
+	suite.assertFile("/results/1.ext", "first")
+}
+
+func TestOutputLocation_Filename(t *testing.T) {
+	workingDir, err := os.Getwd()
+	require.NoError(t, err)
+	outsideWorkingDir := filepath.Join(filepath.Dir(workingDir), "elsewhere", "1.tif")
+	outsideWorkingDirOutput := sinks.ReplaceFileExtension(outsideWorkingDir, ".ext")
+	outsideWorkingDirOutput = strings.TrimLeft(
+		strings.TrimPrefix(outsideWorkingDirOutput, filepath.VolumeName(outsideWorkingDirOutput)), `/\`)
+	outputDir := filepath.FromSlash("/results")
+
+	fixtures := []struct {
+		location sinks.OutputLocation
+		input    string
+		expected string
+	}{
+		{sinks.OutputLocation{}, filepath.FromSlash("in/1.tif"), filepath.FromSlash("in/1.ext")},
+		{sinks.OutputLocation{OutputDir: outputDir}, filepath.FromSlash("in/1.tif"),
+			filepath.Join(outputDir, "in", "1.ext")},
+		{sinks.OutputLocation{OutputDir: outputDir}, filepath.Join(workingDir, "in", "1.tif"),
+			filepath.Join(outputDir, "in", "1.ext")},
+		{sinks.OutputLocation{OutputDir: outputDir}, outsideWorkingDir,
+			filepath.Join(outputDir, outsideWorkingDirOutput)},
+	}
+
+	for _, fixture := range fixtures {
+		assert.Equal(t, fixture.expected, fixture.location.Filename(fixture.input, ".ext"), fixture.input)
+	}
+}
+
+func (suite *OutputDirFileSinkSuite) TestLocation_Skips_Output_Only_For_Existing_Files_When_Skipping() {
+	require.NoError(suite.T(), suite.write(sinks.OverwriteExisting, "1.tif", "first"))
+	skipping := sinks.OutputLocation{OutputDir: suite.outputDir, ExistingFiles: sinks.SkipExisting}
+	overwriting := sinks.OutputLocation{OutputDir: suite.outputDir}
+
+	existing, err := skipping.SkipsOutput(suite.fileSystem, "1.tif", ".ext")
+	require.NoError(suite.T(), err)
+	missing, err := skipping.SkipsOutput(suite.fileSystem, "2.tif", ".ext")
+	require.NoError(suite.T(), err)
+	overwritten, err := overwriting.SkipsOutput(suite.fileSystem, "1.tif", ".ext")
+	require.NoError(suite.T(), err)
+
+	assert.True(suite.T(), existing)
+	assert.False(suite.T(), missing)
+	assert.False(suite.T(), overwritten)
+}
+
+func (suite *OutputDirFileSinkSuite) TestCreates_File_With_The_Same_Permissions_As_Os_Create() {
+	dir, err := ioutil.TempDir("", "surf-sinks")
+	require.NoError(suite.T(), err)
+	defer os.RemoveAll(dir)
+	created, err := os.Create(filepath.Join(dir, "created"))
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), created.Close())
+	location := sinks.OutputLocation{OutputDir: dir}
+	sut := sinks.NewOutputDirFileSink(afero.NewOsFs(), location, ".ext", "1.tif")
+
+	require.NoError(suite.T(), sut.Open())
+	require.NoError(suite.T(), sut.Close())
+
+	expected, err := os.Stat(filepath.Join(dir, "created"))
+	require.NoError(suite.T(), err)
+	actual, err := os.Stat(filepath.Join(dir, "1.ext"))
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expected.Mode().Perm(), actual.Mode().Perm())
+}
@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"fmt"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/output/sinks"
+	"github.com/waives/surf/pages"
+	"testing"
+)
+
+type PageSplittingFileSinkSuite struct {
+	suite.Suite
+	fileSystem    afero.Fs
+	inputFilename string
+}
+
+func (suite *PageSplittingFileSinkSuite) SetupTest() {
+	suite.inputFilename = "/var/folder/document.tif"
+	suite.fileSystem = afero.NewMemMapFs()
+}
+
+func TestPageSplittingFileSinkRunner(t *testing.T) {
+	suite.Run(t, new(PageSplittingFileSinkSuite))
+}
+
+func (suite *PageSplittingFileSinkSuite) write(selection pages.Selection, contents string) {
+	sut := sinks.NewPageSplittingFileSink(suite.fileSystem, sinks.OutputLocation{}, ".txt",
+		suite.inputFilename, selection)
+
+	require.NoError(suite.T(), sut.Open())
+	fmt.Fprint(sut, contents)
+	require.NoError(suite.T(), sut.Close())
+}
+
+func (suite *PageSplittingFileSinkSuite) assertFile(filename, expectedContents string) {
+	contents, err := afero.ReadFile(suite.fileSystem, filename)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedContents, string(contents))
+}
+
+func (suite *PageSplittingFileSinkSuite) TestWrites_Each_Page_To_Its_Own_File() {
+	suite.write(nil, "page one\fpage two")
+
+	suite.assertFile("/var/folder/document.page-0001.txt", "page one")
+	suite.assertFile("/var/folder/document.page-0002.txt", "page two")
+}
+
+func (suite *PageSplittingFileSinkSuite) TestWrites_Only_Selected_Pages() {
+	suite.write(pages.Selection{{First: 2, Last: 2}}, "page one\fpage two\fpage three")
+
+	exists, _ := afero.Exists(suite.fileSystem, "/var/folder/document.page-0001.txt")
+	assert.False(suite.T(), exists)
+	suite.assertFile("/var/folder/document.page-0002.txt", "page two")
+	exists, _ = afero.Exists(suite.fileSystem, "/var/folder/document.page-0003.txt")
+	assert.False(suite.T(), exists)
+}
+
+func (suite *PageSplittingFileSinkSuite) TestWrites_Single_Page_When_There_Are_No_Form_Feeds() {
+	suite.write(nil, "all the text")
+
+	suite.assertFile("/var/folder/document.page-0001.txt", "all the text")
+}
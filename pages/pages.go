@@ -0,0 +1,109 @@
+// Package pages selects pages of documents by their page numbers, which start at 1.
+package pages
+
+import (
+	"bytes"
+	"github.com/pkg/errors"
+	"strconv"
+	"strings"
+)
+
+// FormFeed separates the pages of text read results.
+const FormFeed = '\f'
+
+// Range is an inclusive range of page numbers.
+type Range struct {
+	First, Last int
+}
+
+// Selection is a set of page ranges. An empty Selection selects every page.
+type Selection []Range
+
+// ParseSelection parses a comma-separated list of page numbers and ranges of
+// page numbers, such as "1-3,7".
+func ParseSelection(text string) (Selection, error) {
+	var selection Selection
+
+	for _, item := range strings.Split(text, ",") {
+		item = strings.TrimSpace(item)
+
+		pageRange, err := parseRange(item)
+		if err != nil {
+			return nil, errors.Errorf("'%s' is not a valid page range. Specify page numbers "+
+				"or ranges of page numbers, separated by commas (e.g. 1-3,7)", item)
+		}
+
+		selection = append(selection, pageRange)
+	}
+
+	return selection, nil
+}
+
+func parseRange(text string) (Range, error) {
+	var (
+		pageRange Range
+		err       error
+	)
+
+	parts := strings.SplitN(text, "-", 2)
+
+	pageRange.First, err = strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return pageRange, err
+	}
+
+	pageRange.Last = pageRange.First
+	if len(parts) == 2 {
+		pageRange.Last, err = strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return pageRange, err
+		}
+	}
+
+	if pageRange.First < 1 || pageRange.Last < pageRange.First {
+		return pageRange, errors.New("invalid page range")
+	}
+
+	return pageRange, nil
+}
+
+// IsEmpty returns true if the Selection selects every page.
+func (s Selection) IsEmpty() bool {
+	return len(s) == 0
+}
+
+// Includes returns true if the page number is selected.
+func (s Selection) Includes(pageNumber int) bool {
+	if s.IsEmpty() {
+		return true
+	}
+
+	for _, pageRange := range s {
+		if pageNumber >= pageRange.First && pageNumber <= pageRange.Last {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SplitText splits text into pages at each FormFeed.
+func SplitText(text []byte) [][]byte {
+	return bytes.Split(text, []byte{FormFeed})
+}
+
+// SelectText returns the selected pages of text, separated by FormFeeds.
+func (s Selection) SelectText(text []byte) []byte {
+	if s.IsEmpty() {
+		return text
+	}
+
+	var selected [][]byte
+	for i, page := range SplitText(text) {
+		if s.Includes(i + 1) {
+			selected = append(selected, page)
+		}
+	}
+
+	return bytes.Join(selected, []byte{FormFeed})
+}
@@ -0,0 +1,48 @@
+package pages_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/waives/surf/pages"
+	"testing"
+)
+
+func TestParseSelection_Parses_Pages_And_Ranges(t *testing.T) {
+	selection, err := pages.ParseSelection("1-3, 7,9 - 10")
+
+	require.NoError(t, err)
+	assert.Equal(t, pages.Selection{
+		{First: 1, Last: 3},
+		{First: 7, Last: 7},
+		{First: 9, Last: 10},
+	}, selection)
+}
+
+func TestParseSelection_Rejects_Invalid_Ranges(t *testing.T) {
+	for _, text := range []string{"", "a", "0", "3-1", "1-", "-2", "1,,2", "1-2-3"} {
+		_, err := pages.ParseSelection(text)
+
+		assert.Error(t, err, text)
+	}
+}
+
+func TestSelection_Includes(t *testing.T) {
+	selection := pages.Selection{{First: 1, Last: 3}, {First: 7, Last: 7}}
+
+	for pageNumber, expected := range map[int]bool{1: true, 3: true, 4: false, 7: true, 8: false} {
+		assert.Equal(t, expected, selection.Includes(pageNumber), "page %d", pageNumber)
+	}
+}
+
+func TestSelection_Empty_Includes_All_Pages(t *testing.T) {
+	assert.True(t, pages.Selection{}.Includes(42))
+}
+
+func TestSelection_SelectText(t *testing.T) {
+	text := []byte("one\ftwo\fthree\ffour")
+
+	selection := pages.Selection{{First: 2, Last: 2}, {First: 4, Last: 9}}
+
+	assert.Equal(t, "two\ffour", string(selection.SelectText(text)))
+	assert.Equal(t, string(text), string(pages.Selection{}.SelectText(text)))
+}
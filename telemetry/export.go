@@ -0,0 +1,138 @@
+package telemetry
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// WritePrometheus writes the metrics in the Prometheus text format, e.g. to be read by
+// the node exporter's textfile collector.
+func (r *Recorder) WritePrometheus(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := bufio.NewWriter(w)
+	lastMetric := ""
+
+	for _, s := range r.sortedSeries() {
+		if s.metric.Name != lastMetric {
+			fmt.Fprintf(out, "# HELP %s %s\n", s.metric.Name, s.metric.Help)
+			fmt.Fprintf(out, "# TYPE %s %s\n", s.metric.Name, s.metric.kind)
+			lastMetric = s.metric.Name
+		}
+
+		if s.metric.kind == counterKind {
+			fmt.Fprintf(out, "%s%s %s\n", s.metric.Name, braced(s.labels.String()),
+				formatFloat(s.sum))
+			continue
+		}
+
+		for i, bound := range buckets {
+			fmt.Fprintf(out, "%s_bucket%s %d\n", s.metric.Name,
+				braced(withLabel(s.labels, "le", formatFloat(bound))), s.buckets[i])
+		}
+		fmt.Fprintf(out, "%s_bucket%s %d\n", s.metric.Name,
+			braced(withLabel(s.labels, "le", "+Inf")), s.count)
+		fmt.Fprintf(out, "%s_sum%s %s\n", s.metric.Name, braced(s.labels.String()),
+			formatFloat(s.sum))
+		fmt.Fprintf(out, "%s_count%s %d\n", s.metric.Name, braced(s.labels.String()), s.count)
+	}
+
+	return out.Flush()
+}
+
+func braced(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+func withLabel(labels Labels, name, value string) string {
+	all := Labels{name: value}
+	for n, v := range labels {
+		all[n] = v
+	}
+	return all.String()
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+type jsonSummary struct {
+	Metrics []jsonSeries `json:"metrics"`
+	Spans   []jsonSpan   `json:"spans"`
+}
+
+type jsonSeries struct {
+	Name   string  `json:"name"`
+	Type   string  `json:"type"`
+	Labels Labels  `json:"labels,omitempty"`
+	Count  uint64  `json:"count"`
+	Sum    float64 `json:"sum"`
+	Min    float64 `json:"min,omitempty"`
+	Max    float64 `json:"max,omitempty"`
+	Mean   float64 `json:"mean,omitempty"`
+}
+
+type jsonSpan struct {
+	Name       string    `json:"name"`
+	Attributes Labels    `json:"attributes,omitempty"`
+	Start      time.Time `json:"start"`
+	DurationMs float64   `json:"duration_ms"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// WriteJson writes a json summary of the metrics (the count, sum, minimum, maximum and
+// mean of each histogram), and the spans.
+func (r *Recorder) WriteJson(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	summary := jsonSummary{
+		Metrics: []jsonSeries{},
+		Spans:   []jsonSpan{},
+	}
+
+	for _, s := range r.sortedSeries() {
+		series := jsonSeries{
+			Name:   s.metric.Name,
+			Type:   string(s.metric.kind),
+			Labels: s.labels,
+			Count:  s.count,
+			Sum:    s.sum,
+		}
+
+		if s.metric.kind == histogramKind {
+			series.Min = s.min
+			series.Max = s.max
+			series.Mean = s.sum / float64(s.count)
+		}
+
+		summary.Metrics = append(summary.Metrics, series)
+	}
+
+	for _, s := range r.spans {
+		span := jsonSpan{
+			Name:       s.Name,
+			Attributes: s.Attributes,
+			Start:      s.Start.UTC(),
+			DurationMs: float64(s.Duration) / float64(time.Millisecond),
+		}
+
+		if s.Err != nil {
+			span.Error = s.Err.Error()
+		}
+
+		summary.Spans = append(summary.Spans, span)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(summary)
+}
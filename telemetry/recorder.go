@@ -0,0 +1,219 @@
+// Package telemetry records measurements of the API requests surf sends and the files
+// it processes (as metrics, and spans for each file), to be summarised when it exits.
+package telemetry
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Labels distinguish the series of measurements of a metric, e.g. by operation.
+type Labels map[string]string
+
+type metricKind string
+
+const (
+	counterKind   metricKind = "counter"
+	histogramKind metricKind = "histogram"
+)
+
+// Metric describes a kind of measurement.
+type Metric struct {
+	Name string
+	Help string
+	kind metricKind
+}
+
+var (
+	// RequestDuration is the time taken to receive the response to each API request
+	// (each attempt, if it is retried), by operation, method and status.
+	RequestDuration = Metric{
+		Name: "surf_http_request_duration_seconds",
+		Help: "Time taken to receive the response to an API request.",
+		kind: histogramKind,
+	}
+	// RequestRetries is the number of API requests which were sent again after failing.
+	RequestRetries = Metric{
+		Name: "surf_http_request_retries_total",
+		Help: "Number of API requests sent again after failing.",
+		kind: counterKind,
+	}
+	// SlotWait is the time API requests waited to be sent, within the rate and
+	// concurrency limits.
+	SlotWait = Metric{
+		Name: "surf_http_slot_wait_seconds",
+		Help: "Time API requests waited to be sent, within the rate and concurrency limits.",
+		kind: histogramKind,
+	}
+	// FileDuration is the time taken to process each file, by result.
+	FileDuration = Metric{
+		Name: "surf_file_processing_seconds",
+		Help: "Time taken to process a file.",
+		kind: histogramKind,
+	}
+)
+
+// buckets are the upper bounds of the histograms' buckets, in seconds: from 5ms to 10
+// minutes, since reads can take several minutes to perform OCR.
+var buckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600}
+
+// Recorder records measurements in memory. All of its methods may be called on a nil
+// *Recorder, in which case nothing is recorded, so recording is optional.
+type Recorder struct {
+	mu     sync.Mutex
+	series map[string]*series
+	spans  []Span
+}
+
+// series holds the measurements of a metric with particular labels.
+type series struct {
+	metric  Metric
+	labels  Labels
+	count   uint64
+	sum     float64
+	min     float64
+	max     float64
+	buckets []uint64
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{
+		series: map[string]*series{},
+	}
+}
+
+// Observe records a measurement (e.g. a duration, in seconds) of a histogram metric.
+func (r *Recorder) Observe(metric Metric, labels Labels, value float64) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := r.seriesFor(metric, labels)
+	if s.count == 0 || value < s.min {
+		s.min = value
+	}
+	if s.count == 0 || value > s.max {
+		s.max = value
+	}
+	s.count++
+	s.sum += value
+
+	for i, bound := range buckets {
+		if value <= bound {
+			s.buckets[i]++
+		}
+	}
+}
+
+// Add adds n to a counter metric.
+func (r *Recorder) Add(metric Metric, labels Labels, n float64) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := r.seriesFor(metric, labels)
+	s.count++
+	s.sum += n
+}
+
+func (r *Recorder) seriesFor(metric Metric, labels Labels) *series {
+	key := metric.Name + "{" + labels.String() + "}"
+
+	s, ok := r.series[key]
+	if !ok {
+		s = &series{
+			metric: metric,
+			labels: labels,
+		}
+		if metric.kind == histogramKind {
+			s.buckets = make([]uint64, len(buckets))
+		}
+		r.series[key] = s
+	}
+
+	return s
+}
+
+// sortedSeries returns the series, ordered by metric and labels.
+func (r *Recorder) sortedSeries() []*series {
+	var keys []string
+	for key := range r.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	sorted := make([]*series, len(keys))
+	for i, key := range keys {
+		sorted[i] = r.series[key]
+	}
+
+	return sorted
+}
+
+// String formats the labels as in the Prometheus text format, ordered by name.
+func (l Labels) String() string {
+	var names []string
+	for name := range l {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + `="` + labelValueEscaper.Replace(l[name]) + `"`
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// Span is a timed unit of work, such as processing a file.
+type Span struct {
+	Name       string
+	Attributes Labels
+	Start      time.Time
+	Duration   time.Duration
+	Err        error
+
+	recorder *Recorder
+}
+
+// StartSpan starts timing a unit of work, which is recorded when it ends.
+func (r *Recorder) StartSpan(name string, attributes Labels) *Span {
+	if r == nil {
+		return nil
+	}
+
+	return &Span{
+		Name:       name,
+		Attributes: attributes,
+		Start:      time.Now(),
+		recorder:   r,
+	}
+}
+
+// End records the span, with any error which caused the work to fail, and returns its
+// duration.
+func (s *Span) End(err error) time.Duration {
+	if s == nil {
+		return 0
+	}
+
+	s.Duration = time.Since(s.Start)
+	s.Err = err
+
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.recorder.spans = append(s.recorder.spans, *s)
+
+	return s.Duration
+}
@@ -0,0 +1,108 @@
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecorder_Nil_Records_Nothing(t *testing.T) {
+	var recorder *Recorder
+
+	recorder.Observe(RequestDuration, nil, 1)
+	recorder.Add(RequestRetries, nil, 1)
+	span := recorder.StartSpan("span", nil)
+
+	assert.Nil(t, span)
+	assert.Equal(t, time.Duration(0), span.End(nil))
+}
+
+func TestRecorder_WritePrometheus_Writes_Text_Format(t *testing.T) {
+	// Arrange
+	recorder := NewRecorder()
+	recorder.Add(RequestRetries, Labels{"operation": "upload", "method": "POST"}, 1)
+	recorder.Add(RequestRetries, Labels{"operation": "upload", "method": "POST"}, 2)
+	recorder.Observe(SlotWait, nil, 0.02)
+	recorder.Observe(SlotWait, nil, 3)
+	out := bytes.Buffer{}
+
+	// Act
+	err := recorder.WritePrometheus(&out)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, `# HELP surf_http_request_retries_total Number of API requests sent again after failing.
+# TYPE surf_http_request_retries_total counter
+surf_http_request_retries_total{method="POST",operation="upload"} 3
+# HELP surf_http_slot_wait_seconds Time API requests waited to be sent, within the rate and concurrency limits.
+# TYPE surf_http_slot_wait_seconds histogram
+surf_http_slot_wait_seconds_bucket{le="0.005"} 0
+surf_http_slot_wait_seconds_bucket{le="0.01"} 0
+surf_http_slot_wait_seconds_bucket{le="0.025"} 1
+surf_http_slot_wait_seconds_bucket{le="0.05"} 1
+surf_http_slot_wait_seconds_bucket{le="0.1"} 1
+surf_http_slot_wait_seconds_bucket{le="0.25"} 1
+surf_http_slot_wait_seconds_bucket{le="0.5"} 1
+surf_http_slot_wait_seconds_bucket{le="1"} 1
+surf_http_slot_wait_seconds_bucket{le="2.5"} 1
+surf_http_slot_wait_seconds_bucket{le="5"} 2
+surf_http_slot_wait_seconds_bucket{le="10"} 2
+surf_http_slot_wait_seconds_bucket{le="30"} 2
+surf_http_slot_wait_seconds_bucket{le="60"} 2
+surf_http_slot_wait_seconds_bucket{le="120"} 2
+surf_http_slot_wait_seconds_bucket{le="300"} 2
+surf_http_slot_wait_seconds_bucket{le="600"} 2
+surf_http_slot_wait_seconds_bucket{le="+Inf"} 2
+surf_http_slot_wait_seconds_sum 3.02
+surf_http_slot_wait_seconds_count 2
+`, out.String())
+}
+
+func TestRecorder_WritePrometheus_Escapes_Label_Values(t *testing.T) {
+	recorder := NewRecorder()
+	recorder.Add(RequestRetries, Labels{"operation": `a "quoted" \ name`}, 1)
+	out := bytes.Buffer{}
+
+	require.NoError(t, recorder.WritePrometheus(&out))
+
+	assert.Contains(t, out.String(), `{operation="a \"quoted\" \\ name"} 1`)
+}
+
+func TestRecorder_WriteJson_Writes_Summary_And_Spans(t *testing.T) {
+	// Arrange
+	recorder := NewRecorder()
+	recorder.Observe(FileDuration, Labels{"result": "ok"}, 1)
+	recorder.Observe(FileDuration, Labels{"result": "ok"}, 3)
+	recorder.StartSpan("process_file", Labels{"file": "a.pdf"}).End(errors.New("failed"))
+	out := bytes.Buffer{}
+
+	// Act
+	err := recorder.WriteJson(&out)
+
+	// Assert
+	require.NoError(t, err)
+	var summary jsonSummary
+	require.NoError(t, json.Unmarshal(out.Bytes(), &summary))
+
+	assert.Equal(t, []jsonSeries{{
+		Name:   FileDuration.Name,
+		Type:   "histogram",
+		Labels: Labels{"result": "ok"},
+		Count:  2,
+		Sum:    4,
+		Min:    1,
+		Max:    3,
+		Mean:   2,
+	}}, summary.Metrics)
+
+	require.Len(t, summary.Spans, 1)
+	assert.Equal(t, "process_file", summary.Spans[0].Name)
+	assert.Equal(t, Labels{"file": "a.pdf"}, summary.Spans[0].Attributes)
+	assert.Equal(t, "failed", summary.Spans[0].Error)
+	assert.True(t, strings.HasPrefix(out.String(), "{\n"))
+}
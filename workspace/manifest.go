@@ -0,0 +1,117 @@
+package workspace
+
+import (
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"path/filepath"
+)
+
+// DefaultManifestFilename is the name of the manifest file used when none is specified.
+const DefaultManifestFilename = "surf.yaml"
+
+// Manifest declares the classifiers and extractors a waives account should have.
+type Manifest struct {
+	Classifiers []ClassifierDefinition `yaml:"classifiers"`
+	Extractors  []ExtractorDefinition  `yaml:"extractors"`
+}
+
+// ClassifierDefinition declares a classifier, which is either trained from a zip
+// file of samples or uploaded from a trained classifier (.clf) file.
+type ClassifierDefinition struct {
+	Name       string `yaml:"name"`
+	Samples    string `yaml:"samples,omitempty"`
+	Classifier string `yaml:"classifier,omitempty"`
+}
+
+// ExtractorDefinition declares an extractor, created from a json extractor template.
+type ExtractorDefinition struct {
+	Name     string `yaml:"name"`
+	Template string `yaml:"template"`
+}
+
+// LoadManifest reads and validates the manifest in the named file. The file paths in
+// the manifest are resolved relative to the directory containing it.
+func LoadManifest(filename string) (*Manifest, error) {
+	data, err := ioutil.ReadFile(filename)
+
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to read manifest '%s'", filename)
+	}
+
+	manifest, err := DeserialiseManifest(data)
+
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to read manifest '%s'", filename)
+	}
+
+	manifest.resolvePaths(filepath.Dir(filename))
+	return manifest, nil
+}
+
+// DeserialiseManifest parses and validates a yaml manifest.
+func DeserialiseManifest(data []byte) (*Manifest, error) {
+	var manifest Manifest
+
+	if err := yaml.UnmarshalStrict(data, &manifest); err != nil {
+		return nil, err
+	}
+
+	if err := manifest.validate(); err != nil {
+		return nil, err
+	}
+
+	return &manifest, nil
+}
+
+func (m *Manifest) validate() error {
+	classifierNames := map[string]bool{}
+	for _, classifier := range m.Classifiers {
+		if classifier.Name == "" {
+			return errors.New("all classifiers must have a name")
+		}
+		if classifierNames[classifier.Name] {
+			return errors.Errorf("classifier '%s' is declared more than once", classifier.Name)
+		}
+		classifierNames[classifier.Name] = true
+
+		if (classifier.Samples == "") == (classifier.Classifier == "") {
+			return errors.Errorf("classifier '%s' must have exactly one of 'samples' or 'classifier'",
+				classifier.Name)
+		}
+	}
+
+	extractorNames := map[string]bool{}
+	for _, extractor := range m.Extractors {
+		if extractor.Name == "" {
+			return errors.New("all extractors must have a name")
+		}
+		if extractorNames[extractor.Name] {
+			return errors.Errorf("extractor '%s' is declared more than once", extractor.Name)
+		}
+		extractorNames[extractor.Name] = true
+
+		if extractor.Template == "" {
+			return errors.Errorf("extractor '%s' must have a 'template'", extractor.Name)
+		}
+	}
+
+	return nil
+}
+
+func (m *Manifest) resolvePaths(directory string) {
+	resolve := func(path string) string {
+		if path == "" || filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(directory, path)
+	}
+
+	for i := range m.Classifiers {
+		m.Classifiers[i].Samples = resolve(m.Classifiers[i].Samples)
+		m.Classifiers[i].Classifier = resolve(m.Classifiers[i].Classifier)
+	}
+	for i := range m.Extractors {
+		m.Extractors[i].Template = resolve(m.Extractors[i].Template)
+	}
+}
@@ -0,0 +1,54 @@
+package workspace_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/waives/surf/workspace"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadManifest_Resolves_Relative_Paths_Against_Manifest_Directory(t *testing.T) {
+	manifest, err := workspace.LoadManifest(filepath.Join("testdata", "surf.yaml"))
+
+	require.NoError(t, err)
+	assert.Equal(t, &workspace.Manifest{
+		Classifiers: []workspace.ClassifierDefinition{
+			{Name: "documents", Samples: filepath.Join("testdata", "samples.zip")},
+			{Name: "trained", Classifier: "/classifiers/trained.clf"},
+		},
+		Extractors: []workspace.ExtractorDefinition{
+			{Name: "dates", Template: filepath.Join("testdata", "extractors", "dates.json")},
+		},
+	}, manifest)
+}
+
+func TestLoadManifest_Returns_Error_For_Missing_File(t *testing.T) {
+	_, err := workspace.LoadManifest(filepath.Join("testdata", "missing.yaml"))
+
+	assert.Error(t, err)
+}
+
+func TestDeserialiseManifest_Rejects_Invalid_Manifests(t *testing.T) {
+	fixtures := map[string]string{
+		"unknown field": "classifers: []",
+		"duplicate classifier": `
+classifiers:
+  - {name: a, samples: a.zip}
+  - {name: a, samples: b.zip}`,
+		"classifier without source":   "classifiers: [{name: a}]",
+		"classifier with two sources": "classifiers: [{name: a, samples: a.zip, classifier: a.clf}]",
+		"unnamed extractor":           "extractors: [{template: a.json}]",
+		"extractor without template":  "extractors: [{name: a}]",
+		"duplicate extractor": `
+extractors:
+  - {name: a, template: a.json}
+  - {name: a, template: b.json}`,
+	}
+
+	for description, manifest := range fixtures {
+		_, err := workspace.DeserialiseManifest([]byte(manifest))
+
+		assert.Error(t, err, description)
+	}
+}
@@ -0,0 +1,56 @@
+package workspace
+
+import (
+	"fmt"
+	"github.com/waives/surf/ch360"
+)
+
+// Action is a change to be made to a resource.
+type Action string
+
+const (
+	ActionCreate  Action = "create"
+	ActionReplace Action = "replace"
+	ActionDelete  Action = "delete"
+)
+
+// Kind is the type of a resource.
+type Kind string
+
+const (
+	KindClassifier Kind = "classifier"
+	KindExtractor  Kind = "extractor"
+)
+
+// Change describes a single change required to bring an account in line with a manifest.
+type Change struct {
+	Action Action
+	Kind   Kind
+	Name   string
+
+	// Source is the file the resource is created from, if any.
+	Source string
+	// Trained indicates that a classifier is uploaded from a trained classifier file,
+	// rather than trained from samples.
+	Trained bool
+	// Template is the template an extractor is created from, if any.
+	Template *ch360.ExtractorTemplate
+}
+
+func (c Change) String() string {
+	description := fmt.Sprintf("%s %s '%s'", c.Action, c.Kind, c.Name)
+
+	if c.Source != "" {
+		description += fmt.Sprintf(" from %s", c.Source)
+	}
+
+	return description
+}
+
+// Plan is the list of changes required to bring an account in line with a manifest.
+type Plan []Change
+
+// IsEmpty returns true if no changes are required.
+func (p Plan) IsEmpty() bool {
+	return len(p) == 0
+}
@@ -0,0 +1,225 @@
+package workspace
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/waives/surf/ch360"
+	"io"
+	"os"
+)
+
+//go:generate mockery -name "ClassifierClient|ExtractorClient"
+
+type ClassifierClient interface {
+	GetAll(ctx context.Context) (ch360.ClassifierList, error)
+	Create(ctx context.Context, name string) error
+	Train(ctx context.Context, name string, samplesArchive io.Reader) error
+	Upload(ctx context.Context, name string, contents io.Reader) error
+	Delete(ctx context.Context, name string) error
+}
+
+type ExtractorClient interface {
+	GetAll(ctx context.Context) (ch360.ExtractorList, error)
+	GetTemplate(ctx context.Context, name string) (*ch360.ExtractorTemplate, error)
+	CreateFromModules(ctx context.Context, name string, modules ch360.ExtractorTemplate) error
+	Delete(ctx context.Context, name string) error
+}
+
+// Syncer brings the classifiers and extractors in a waives account in line with a
+// Manifest. Resources which are not declared in the manifest are only deleted when
+// pruning, and then only for the kinds of resource the manifest has a list of: a
+// manifest without an 'extractors' key leaves every extractor alone, whereas
+// 'extractors: []' deletes them all.
+//
+// The API does not expose the definition of a classifier, so a classifier which
+// already exists is left as it is. Existing extractors are replaced if their
+// definition differs from their template.
+type Syncer struct {
+	classifiers ClassifierClient
+	extractors  ExtractorClient
+	prune       bool
+}
+
+func NewSyncer(classifiers ClassifierClient, extractors ExtractorClient) *Syncer {
+	return &Syncer{
+		classifiers: classifiers,
+		extractors:  extractors,
+	}
+}
+
+// WithPruning configures the Syncer to delete resources which are not declared in the
+// manifest.
+func (s *Syncer) WithPruning(prune bool) *Syncer {
+	s.prune = prune
+	return s
+}
+
+// Plan compares the manifest with the account and returns the changes required.
+func (s *Syncer) Plan(ctx context.Context, manifest *Manifest) (Plan, error) {
+	classifierChanges, err := s.planClassifiers(ctx, manifest.Classifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	extractorChanges, err := s.planExtractors(ctx, manifest.Extractors)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(classifierChanges, extractorChanges...), nil
+}
+
+func (s *Syncer) planClassifiers(ctx context.Context, definitions []ClassifierDefinition) (Plan, error) {
+	existing, err := s.classifiers.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		plan     Plan
+		declared = map[string]bool{}
+	)
+
+	for _, definition := range definitions {
+		declared[definition.Name] = true
+
+		if existing.Contains(definition.Name) {
+			continue
+		}
+
+		change := Change{
+			Action: ActionCreate,
+			Kind:   KindClassifier,
+			Name:   definition.Name,
+			Source: definition.Samples,
+		}
+		if definition.Classifier != "" {
+			change.Source = definition.Classifier
+			change.Trained = true
+		}
+		plan = append(plan, change)
+	}
+
+	if !s.prune || definitions == nil {
+		return plan, nil
+	}
+
+	for _, classifier := range existing {
+		if !declared[classifier.Name] {
+			plan = append(plan, Change{Action: ActionDelete, Kind: KindClassifier, Name: classifier.Name})
+		}
+	}
+
+	return plan, nil
+}
+
+func (s *Syncer) planExtractors(ctx context.Context, definitions []ExtractorDefinition) (Plan, error) {
+	existing, err := s.extractors.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		plan     Plan
+		declared = map[string]bool{}
+	)
+
+	for _, definition := range definitions {
+		declared[definition.Name] = true
+
+		template, err := ch360.NewModulesTemplateFromFile(definition.Template)
+		if err != nil {
+			return nil, err
+		}
+
+		change := Change{
+			Action:   ActionCreate,
+			Kind:     KindExtractor,
+			Name:     definition.Name,
+			Source:   definition.Template,
+			Template: template,
+		}
+
+		if existing.Contains(definition.Name) {
+			existingTemplate, err := s.extractors.GetTemplate(ctx, definition.Name)
+			if err != nil {
+				return nil, errors.WithMessagef(err, "failed to get extractor '%s'", definition.Name)
+			}
+
+			if existingTemplate.Equals(*template) {
+				continue
+			}
+			change.Action = ActionReplace
+		}
+
+		plan = append(plan, change)
+	}
+
+	if !s.prune || definitions == nil {
+		return plan, nil
+	}
+
+	for _, extractor := range existing {
+		if !declared[extractor.Name] {
+			plan = append(plan, Change{Action: ActionDelete, Kind: KindExtractor, Name: extractor.Name})
+		}
+	}
+
+	return plan, nil
+}
+
+// Apply makes a single change from a Plan.
+func (s *Syncer) Apply(ctx context.Context, change Change) error {
+	switch change.Kind {
+	case KindClassifier:
+		return s.applyClassifierChange(ctx, change)
+	case KindExtractor:
+		return s.applyExtractorChange(ctx, change)
+	}
+
+	return errors.Errorf("unknown resource kind '%s'", change.Kind)
+}
+
+func (s *Syncer) applyClassifierChange(ctx context.Context, change Change) error {
+	if change.Action == ActionDelete {
+		return s.classifiers.Delete(ctx, change.Name)
+	}
+
+	file, err := os.Open(change.Source)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if change.Trained {
+		return s.classifiers.Upload(ctx, change.Name, file)
+	}
+
+	if err = s.classifiers.Create(ctx, change.Name); err != nil {
+		return err
+	}
+
+	if err = s.classifiers.Train(ctx, change.Name, file); err != nil {
+		_ = s.classifiers.Delete(ctx, change.Name)
+		return err
+	}
+
+	return nil
+}
+
+func (s *Syncer) applyExtractorChange(ctx context.Context, change Change) error {
+	switch change.Action {
+	case ActionDelete:
+		return s.extractors.Delete(ctx, change.Name)
+	case ActionCreate:
+		return s.extractors.CreateFromModules(ctx, change.Name, *change.Template)
+	}
+
+	existingTemplate, err := s.extractors.GetTemplate(ctx, change.Name)
+	if err != nil {
+		return err
+	}
+
+	return ch360.ReplaceExtractor(ctx, s.extractors, change.Name, *existingTemplate,
+		*change.Template)
+}
@@ -0,0 +1,233 @@
+package workspace_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/waives/surf/ch360"
+	"github.com/waives/surf/workspace"
+	"github.com/waives/surf/workspace/mocks"
+	"path/filepath"
+	"testing"
+)
+
+type SyncerSuite struct {
+	suite.Suite
+	sut           *workspace.Syncer
+	classifiers   *mocks.ClassifierClient
+	extractors    *mocks.ExtractorClient
+	manifest      *workspace.Manifest
+	datesTemplate *ch360.ExtractorTemplate
+	ctx           context.Context
+}
+
+func (suite *SyncerSuite) SetupTest() {
+	suite.classifiers = new(mocks.ClassifierClient)
+	suite.extractors = new(mocks.ExtractorClient)
+	suite.sut = workspace.NewSyncer(suite.classifiers, suite.extractors)
+	suite.ctx = context.Background()
+
+	var err error
+	suite.manifest, err = workspace.LoadManifest(filepath.Join("testdata", "surf.yaml"))
+	require.NoError(suite.T(), err)
+
+	suite.datesTemplate = &ch360.ExtractorTemplate{
+		Modules: []ch360.ModuleTemplate{{ID: "waives.date"}},
+	}
+}
+
+func TestSyncerSuiteRunner(t *testing.T) {
+	suite.Run(t, new(SyncerSuite))
+}
+
+func (suite *SyncerSuite) TestPlan_Creates_Missing_Resources() {
+	suite.classifiers.On("GetAll", mock.Anything).Return(nil, nil)
+	suite.extractors.On("GetAll", mock.Anything).Return(nil, nil)
+
+	plan, err := suite.sut.Plan(suite.ctx, suite.manifest)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), workspace.Plan{
+		{Action: workspace.ActionCreate, Kind: workspace.KindClassifier, Name: "documents",
+			Source: filepath.Join("testdata", "samples.zip")},
+		{Action: workspace.ActionCreate, Kind: workspace.KindClassifier, Name: "trained",
+			Source: "/classifiers/trained.clf", Trained: true},
+		{Action: workspace.ActionCreate, Kind: workspace.KindExtractor, Name: "dates",
+			Source:   filepath.Join("testdata", "extractors", "dates.json"),
+			Template: suite.datesTemplate},
+	}, plan)
+}
+
+func (suite *SyncerSuite) TestPlan_Is_Empty_When_Account_Matches_Manifest() {
+	suite.classifiers.On("GetAll", mock.Anything).
+		Return(ch360.ClassifierList{{Name: "documents"}, {Name: "trained"}}, nil)
+	suite.extractors.On("GetAll", mock.Anything).Return(ch360.ExtractorList{{Name: "dates"}}, nil)
+	suite.extractors.On("GetTemplate", mock.Anything, "dates").Return(suite.datesTemplate, nil)
+
+	plan, err := suite.sut.Plan(suite.ctx, suite.manifest)
+
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), plan.IsEmpty())
+}
+
+func (suite *SyncerSuite) TestPlan_Replaces_Changed_Extractors_And_Deletes_Undeclared_Resources_When_Pruning() {
+	suite.classifiers.On("GetAll", mock.Anything).
+		Return(ch360.ClassifierList{{Name: "documents"}, {Name: "trained"}, {Name: "old"}}, nil)
+	suite.extractors.On("GetAll", mock.Anything).
+		Return(ch360.ExtractorList{{Name: "dates"}, {Name: "stale"}}, nil)
+	suite.extractors.On("GetTemplate", mock.Anything, "dates").Return(&ch360.ExtractorTemplate{
+		Modules: []ch360.ModuleTemplate{{ID: "waives.amount"}},
+	}, nil)
+
+	plan, err := suite.sut.WithPruning(true).Plan(suite.ctx, suite.manifest)
+
+	assert.NoError(suite.T(), err)
+	require.Len(suite.T(), plan, 3)
+	assert.Equal(suite.T(), "delete classifier 'old'", plan[0].String())
+	assert.Equal(suite.T(), workspace.ActionReplace, plan[1].Action)
+	assert.Equal(suite.T(), "dates", plan[1].Name)
+	assert.Equal(suite.T(), "delete extractor 'stale'", plan[2].String())
+}
+
+func (suite *SyncerSuite) TestPlan_Does_Not_Delete_Undeclared_Resources_Unless_Pruning() {
+	suite.classifiers.On("GetAll", mock.Anything).
+		Return(ch360.ClassifierList{{Name: "documents"}, {Name: "trained"}, {Name: "old"}}, nil)
+	suite.extractors.On("GetAll", mock.Anything).
+		Return(ch360.ExtractorList{{Name: "dates"}, {Name: "stale"}}, nil)
+	suite.extractors.On("GetTemplate", mock.Anything, "dates").Return(suite.datesTemplate, nil)
+
+	plan, err := suite.sut.Plan(suite.ctx, suite.manifest)
+
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), plan.IsEmpty())
+}
+
+func (suite *SyncerSuite) TestPlan_Only_Prunes_Kinds_Of_Resource_In_The_Manifest() {
+	manifest, err := workspace.DeserialiseManifest([]byte(`
+classifiers:
+  - name: documents
+    samples: samples.zip
+`))
+	require.NoError(suite.T(), err)
+	suite.classifiers.On("GetAll", mock.Anything).
+		Return(ch360.ClassifierList{{Name: "documents"}, {Name: "old"}}, nil)
+	suite.extractors.On("GetAll", mock.Anything).Return(ch360.ExtractorList{{Name: "dates"}}, nil)
+
+	plan, err := suite.sut.WithPruning(true).Plan(suite.ctx, manifest)
+
+	assert.NoError(suite.T(), err)
+	require.Len(suite.T(), plan, 1)
+	assert.Equal(suite.T(), "delete classifier 'old'", plan[0].String())
+}
+
+func (suite *SyncerSuite) TestPlan_Prunes_All_Resources_Of_A_Kind_Declared_As_Empty() {
+	manifest, err := workspace.DeserialiseManifest([]byte("extractors: []\n"))
+	require.NoError(suite.T(), err)
+	suite.classifiers.On("GetAll", mock.Anything).Return(ch360.ClassifierList{{Name: "documents"}}, nil)
+	suite.extractors.On("GetAll", mock.Anything).Return(ch360.ExtractorList{{Name: "dates"}}, nil)
+
+	plan, err := suite.sut.WithPruning(true).Plan(suite.ctx, manifest)
+
+	assert.NoError(suite.T(), err)
+	require.Len(suite.T(), plan, 1)
+	assert.Equal(suite.T(), "delete extractor 'dates'", plan[0].String())
+}
+
+func (suite *SyncerSuite) TestPlan_Returns_Error_If_Classifiers_Cannot_Be_Retrieved() {
+	expectedErr := errors.New("simulated error")
+	suite.classifiers.On("GetAll", mock.Anything).Return(nil, expectedErr)
+
+	_, err := suite.sut.Plan(suite.ctx, suite.manifest)
+
+	assert.Equal(suite.T(), expectedErr, err)
+}
+
+func (suite *SyncerSuite) TestApply_Creates_And_Trains_Classifier_From_Samples() {
+	suite.classifiers.On("Create", mock.Anything, mock.Anything).Return(nil)
+	suite.classifiers.On("Train", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	err := suite.sut.Apply(suite.ctx, workspace.Change{
+		Action: workspace.ActionCreate,
+		Kind:   workspace.KindClassifier,
+		Name:   "documents",
+		Source: filepath.Join("testdata", "samples.zip"),
+	})
+
+	assert.NoError(suite.T(), err)
+	suite.classifiers.AssertCalled(suite.T(), "Create", suite.ctx, "documents")
+	suite.classifiers.AssertCalled(suite.T(), "Train", suite.ctx, "documents", mock.Anything)
+}
+
+func (suite *SyncerSuite) TestApply_Deletes_Classifier_If_Training_Fails() {
+	expectedErr := errors.New("simulated error")
+	suite.classifiers.On("Create", mock.Anything, mock.Anything).Return(nil)
+	suite.classifiers.On("Train", mock.Anything, mock.Anything, mock.Anything).Return(expectedErr)
+	suite.classifiers.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	err := suite.sut.Apply(suite.ctx, workspace.Change{
+		Action: workspace.ActionCreate,
+		Kind:   workspace.KindClassifier,
+		Name:   "documents",
+		Source: filepath.Join("testdata", "samples.zip"),
+	})
+
+	assert.Equal(suite.T(), expectedErr, err)
+	suite.classifiers.AssertCalled(suite.T(), "Delete", suite.ctx, "documents")
+}
+
+func (suite *SyncerSuite) TestApply_Replaces_Extractor() {
+	suite.extractors.On("GetTemplate", mock.Anything, mock.Anything).
+		Return(&ch360.ExtractorTemplate{}, nil)
+	suite.extractors.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	suite.extractors.On("CreateFromModules", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	err := suite.sut.Apply(suite.ctx, workspace.Change{
+		Action:   workspace.ActionReplace,
+		Kind:     workspace.KindExtractor,
+		Name:     "dates",
+		Template: suite.datesTemplate,
+	})
+
+	assert.NoError(suite.T(), err)
+	suite.extractors.AssertCalled(suite.T(), "Delete", suite.ctx, "dates")
+	suite.extractors.AssertCalled(suite.T(), "CreateFromModules", suite.ctx, "dates", *suite.datesTemplate)
+}
+
+func (suite *SyncerSuite) TestApply_Restores_Extractor_If_Replacement_Fails() {
+	previousTemplate := &ch360.ExtractorTemplate{
+		Modules: []ch360.ModuleTemplate{{ID: "waives.amount"}},
+	}
+	expectedErr := errors.New("simulated error")
+	suite.extractors.On("GetTemplate", mock.Anything, mock.Anything).Return(previousTemplate, nil)
+	suite.extractors.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	suite.extractors.On("CreateFromModules", mock.Anything, mock.Anything, *suite.datesTemplate).
+		Return(expectedErr)
+	suite.extractors.On("CreateFromModules", mock.Anything, mock.Anything, *previousTemplate).
+		Return(nil)
+
+	err := suite.sut.Apply(suite.ctx, workspace.Change{
+		Action:   workspace.ActionReplace,
+		Kind:     workspace.KindExtractor,
+		Name:     "dates",
+		Template: suite.datesTemplate,
+	})
+
+	assert.Equal(suite.T(), expectedErr, err)
+	suite.extractors.AssertCalled(suite.T(), "CreateFromModules", suite.ctx, "dates", *previousTemplate)
+}
+
+func (suite *SyncerSuite) TestApply_Deletes_Extractor() {
+	suite.extractors.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	err := suite.sut.Apply(suite.ctx, workspace.Change{
+		Action: workspace.ActionDelete,
+		Kind:   workspace.KindExtractor,
+		Name:   "stale",
+	})
+
+	assert.NoError(suite.T(), err)
+	suite.extractors.AssertCalled(suite.T(), "Delete", suite.ctx, "stale")
+}